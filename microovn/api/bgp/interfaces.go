@@ -0,0 +1,31 @@
+// Package bgp implements APIs related to the BGP integration.
+package bgp
+
+import (
+	"net/http"
+
+	"github.com/canonical/lxd/lxd/response"
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v2/rest"
+	"github.com/canonical/microcluster/v2/state"
+
+	"github.com/canonical/microovn/microovn/bgp"
+)
+
+// RedirectInterfaces defines endpoint for /1.0/bgp/interfaces
+var RedirectInterfaces = rest.Endpoint{
+	Path: "bgp/interfaces",
+	Get:  rest.EndpointAction{Handler: getRedirectInterfaces, AllowUntrusted: false, ProxyTarget: false},
+}
+
+// getRedirectInterfaces implements GET method for /1.0/bgp/interfaces. It returns names of the system
+// interfaces used for BGP redirect on every cluster member, in the format of types.BgpRedirectInterfaces.
+func getRedirectInterfaces(s state.State, r *http.Request) response.Response {
+	interfaces, err := bgp.ListRedirectInterfaces(r.Context(), s)
+	if err != nil {
+		logger.Errorf("Failed to list BGP redirect interfaces: %s", err)
+		return response.ErrorResponse(500, "internal server error")
+	}
+
+	return response.SyncResponse(true, interfaces)
+}
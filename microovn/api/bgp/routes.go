@@ -0,0 +1,44 @@
+package bgp
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/canonical/lxd/lxd/response"
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v2/rest"
+	"github.com/canonical/microcluster/v2/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/bgp"
+	"github.com/canonical/microovn/microovn/node"
+)
+
+// Routes defines endpoint for /1.0/bgp/routes
+var Routes = rest.Endpoint{
+	Path: "bgp/routes",
+	Get:  rest.EndpointAction{Handler: getRoutes, AllowUntrusted: false, ProxyTarget: true},
+}
+
+// getRoutes implements GET method for /1.0/bgp/routes. It returns routes learned by the BGP daemon on this
+// member together with their RPKI origin validation state, in the format of types.BgpRouteStatus.
+func getRoutes(s state.State, r *http.Request) response.Response {
+	hasBgp, err := node.HasServiceActive(r.Context(), s, types.SrvBgp)
+	if err != nil {
+		logger.Errorf("Failed to check if BGP is active on this node: %s", err)
+		return response.ErrorResponse(500, "internal server error")
+	}
+
+	if !hasBgp {
+		return response.BadRequest(fmt.Errorf("this node does not run 'bgp' service"))
+	}
+
+	status, err := bgp.RouteStatus(r.Context())
+	if err != nil {
+		logger.Errorf("Failed to get BGP route status: %s", err)
+		return response.ErrorResponse(500, "internal server error")
+	}
+	status.Member = s.Name()
+
+	return response.SyncResponse(true, status)
+}
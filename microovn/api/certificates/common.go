@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/canonical/lxd/shared/logger"
 	"github.com/canonical/microcluster/v2/state"
@@ -11,6 +12,9 @@ import (
 	"github.com/canonical/microovn/microovn/api/types"
 	"github.com/canonical/microovn/microovn/node"
 	"github.com/canonical/microovn/microovn/ovn/certificates"
+	ovnCmd "github.com/canonical/microovn/microovn/ovn/cmd"
+	"github.com/canonical/microovn/microovn/ovn/vswitch"
+	"github.com/canonical/microovn/microovn/snap"
 )
 
 // enabledOvnServices returns list of OVN services enabled on this MicroOVN cluster member.
@@ -34,6 +38,15 @@ func enabledOvnServices(ctx context.Context, s state.State) ([]string, error) {
 
 	if hasSwitch {
 		enabledServices = append(enabledServices, "ovn-controller")
+
+		hasRemoteManager, err := vswitch.RemoteManagerEnabled(ctx, s)
+		if err != nil {
+			wrappedError = errors.Join(wrappedError, fmt.Errorf("failed to lookup remote OVS manager configuration: %s", err))
+		}
+
+		if hasRemoteManager {
+			enabledServices = append(enabledServices, vswitch.RemoteManagerCertService)
+		}
 	}
 
 	// We always want a client certificate
@@ -42,25 +55,106 @@ func enabledOvnServices(ctx context.Context, s state.State) ([]string, error) {
 	return enabledServices, wrappedError
 }
 
-// reissueAllCertificates issues new certificates, using current CA, for every OVN service that is enabled
-// on this MicroOVN cluster member.
-func reissueAllCertificates(ctx context.Context, s state.State) (*types.IssueCertificateResponse, error) {
-	responseData := types.IssueCertificateResponse{}
+// certificateSnapServices maps OVN services, that use certificates, to snap services that need to be
+// restarted in order to start using newly issued certificate. Services that are not in this map pick up
+// new certificates automatically.
+var certificateSnapServices = map[string]string{
+	"ovnnb":          "ovn-ovsdb-server-nb",
+	"ovnsb":          "ovn-ovsdb-server-sb",
+	"ovn-northd":     "ovn-northd",
+	"ovn-controller": "chassis",
+}
+
+// certificateDatabases maps OVN services to databases that they serve. After such a service is restarted,
+// its database needs to reach "connected" state before it's safe to restart the same service on other
+// cluster members.
+var certificateDatabases = map[string]ovnCmd.OvsdbType{
+	"ovnnb": ovnCmd.OvsdbTypeNBLocal,
+	"ovnsb": ovnCmd.OvsdbTypeSBLocal,
+}
 
+// reissueAndRestartCertificates issues new certificate for the selected service (or for every enabled
+// service if "service" is empty) and restarts processes that use it. Services that are not enabled on this
+// cluster member are skipped.
+func reissueAndRestartCertificates(ctx context.Context, s state.State, service string) (*types.IssueCertificateResponse, error) {
 	activeServices, err := enabledOvnServices(ctx, s)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, service := range activeServices {
-		err = certificates.GenerateNewServiceCertificate(ctx, s, service, certificates.CertificateTypeServer)
+	if service != "" {
+		if !slices.Contains(activeServices, service) {
+			logger.Infof("Skipping certificate reissue for '%s', service is not enabled on this member", service)
+			return &types.IssueCertificateResponse{}, nil
+		}
+		activeServices = []string{service}
+	}
+
+	return reissueServiceCertificates(ctx, s, activeServices, true), nil
+}
+
+// reissueServiceCertificates issues new certificate for each of the "services" and, if "restart" is true,
+// restarts processes that use it. Services whose certificate could not be issued or whose processes could
+// not be restarted are reported as failed.
+func reissueServiceCertificates(ctx context.Context, s state.State, services []string, restart bool) *types.IssueCertificateResponse {
+	responseData := types.IssueCertificateResponse{}
+	for _, service := range services {
+		err := certificates.GenerateNewServiceCertificate(ctx, s, service, certificates.CertificateTypeServer)
 		if err != nil {
 			logger.Errorf("Failed to issue certificate for %s: %s", service, err)
 			responseData.Failed = append(responseData.Failed, service)
-		} else {
-			responseData.Success = append(responseData.Success, service)
+			continue
+		}
+
+		if restart {
+			err = restartCertificateService(ctx, s, service)
+			if err != nil {
+				logger.Errorf("Failed to restart %s after certificate reissue: %s", service, err)
+				responseData.Failed = append(responseData.Failed, service)
+				continue
+			}
 		}
+
+		responseData.Success = append(responseData.Success, service)
+	}
+
+	return &responseData
+}
+
+// restartCertificateService restarts snap service that uses certificate of the OVN service. If the
+// service serves OVN database, this function waits until the database is connected again.
+func restartCertificateService(ctx context.Context, s state.State, service string) error {
+	snapService, ok := certificateSnapServices[service]
+	if !ok {
+		return nil
+	}
+
+	logger.Infof("Restarting '%s' to apply new certificate", snapService)
+	err := snap.Restart(snapService)
+	if err != nil {
+		return err
+	}
+
+	dbType, ok := certificateDatabases[service]
+	if !ok {
+		return nil
+	}
+
+	dbSpec, err := ovnCmd.NewOvsdbSpec(dbType)
+	if err != nil {
+		return err
+	}
+
+	return ovnCmd.WaitForDBState(ctx, s, dbSpec, ovnCmd.OvsdbConnected, ovnCmd.DefaultDBConnectWait)
+}
+
+// reissueAllCertificates issues new certificates, using current CA, for every OVN service that is enabled
+// on this MicroOVN cluster member.
+func reissueAllCertificates(ctx context.Context, s state.State) (*types.IssueCertificateResponse, error) {
+	activeServices, err := enabledOvnServices(ctx, s)
+	if err != nil {
+		return nil, err
 	}
 
-	return &responseData, nil
+	return reissueServiceCertificates(ctx, s, activeServices, false), nil
 }
@@ -0,0 +1,110 @@
+package certificates
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/canonical/lxd/lxd/response"
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v2/client"
+	"github.com/canonical/microcluster/v2/rest"
+	"github.com/canonical/microcluster/v2/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	microovnClient "github.com/canonical/microovn/microovn/client"
+)
+
+// IssueCertificatesClusterEndpoint defines endpoint for /1.0/cluster/certificates
+var IssueCertificatesClusterEndpoint = rest.Endpoint{
+	Path: "cluster/certificates",
+	Put:  rest.EndpointAction{Handler: issueCertificatesClusterPut, AllowUntrusted: false, ProxyTarget: false},
+}
+
+// issueCertificatesClusterPut implements PUT method for /1.0/cluster/certificates endpoint. The function
+// issues new certificates for the requested OVN service (or for every enabled OVN service) and restarts
+// processes that use them.
+//
+// The member that initially receives the request reissues its own certificates first and then forwards the
+// request to the rest of the cluster members, one at a time. This ensures that the restarts of the
+// clustered OVN services are rolling. If any member fails to reissue its certificates, the request is
+// not forwarded to the remaining members.
+func issueCertificatesClusterPut(s state.State, r *http.Request) response.Response {
+	responseData := types.NewReissueClusterCertificatesResponse()
+
+	var request types.ReissueClusterCertificatesRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		logger.Errorf("Failed to decode certificate reissue request: %v", err)
+		return response.BadRequest(fmt.Errorf("failed to decode request: %w", err))
+	}
+
+	logger.Infof("Re-issuing local certificates as part of cluster-wide reissue (service: '%s')", request.Service)
+	localResult, err := reissueAndRestartCertificates(r.Context(), s, request.Service)
+	if err != nil {
+		logger.Errorf("Failed to reissue certificates: %v", err)
+		responseData.Errors = append(responseData.Errors, fmt.Sprintf("member %s: failed to reissue certificates", s.Name()))
+		return response.SyncResponse(false, &responseData)
+	}
+	responseData.ReissuedCertificates[s.Name()] = *localResult
+
+	// Only the initial recipient of the request notifies the rest of the cluster
+	if client.IsNotification(r) || len(localResult.Failed) != 0 {
+		return response.SyncResponse(true, &responseData)
+	}
+
+	clusterClient, err := s.Cluster(true)
+	if err != nil {
+		logger.Errorf("Failed to get a client for every cluster member: %v", err)
+		return response.SyncResponse(false, &responseData)
+	}
+
+	members := make([]memberReissuer, 0, len(clusterClient))
+	for _, c := range clusterClient {
+		clientURL := c.URL()
+		members = append(members, memberReissuer{
+			Address: clientURL.String(),
+			Reissue: func(ctx context.Context) (types.ReissueClusterCertificatesResponse, error) {
+				return microovnClient.ReissueClusterCertificates(ctx, &c, request.Service)
+			},
+		})
+	}
+
+	reissueOnMembers(r.Context(), members, responseData)
+	return response.SyncResponse(true, &responseData)
+}
+
+// memberReissuer requests a single remote cluster member to reissue its certificates.
+type memberReissuer struct {
+	Address string                                                                      // Address of the member
+	Reissue func(ctx context.Context) (types.ReissueClusterCertificatesResponse, error) // Sends the reissue request
+}
+
+// reissueOnMembers requests the "members" to reissue their certificates one at a time, to achieve rolling
+// restart of the services, and merges their results into the "responseData". If a member can't be contacted
+// or fails to reissue any of its certificates, the remaining members are skipped.
+func reissueOnMembers(ctx context.Context, members []memberReissuer, responseData *types.ReissueClusterCertificatesResponse) {
+	for _, member := range members {
+		logger.Infof("Requesting cluster member at '%s' to re-issue its OVN certificates", member.Address)
+		result, err := member.Reissue(ctx)
+		if err != nil {
+			errMsg := fmt.Sprintf("failed to contact cluster member with address %q: %s", member.Address, err)
+			responseData.Errors = append(responseData.Errors, errMsg)
+			return
+		}
+
+		for name, memberResult := range result.ReissuedCertificates {
+			responseData.ReissuedCertificates[name] = memberResult
+			if len(memberResult.Failed) != 0 {
+				err = fmt.Errorf("certificate reissue failed on member %s", name)
+			}
+		}
+		responseData.Errors = append(responseData.Errors, result.Errors...)
+
+		if err != nil {
+			responseData.Errors = append(responseData.Errors, fmt.Sprintf("%s. Remaining members were skipped.", err))
+			return
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package certificates
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/canonical/microovn/microovn/api/types"
+)
+
+// fakeMember returns memberReissuer that records the order in which members were contacted into "calls" and
+// responds with "result" and "err".
+func fakeMember(address string, calls *[]string, result types.ReissueClusterCertificatesResponse, err error) memberReissuer {
+	return memberReissuer{
+		Address: address,
+		Reissue: func(_ context.Context) (types.ReissueClusterCertificatesResponse, error) {
+			*calls = append(*calls, address)
+			return result, err
+		},
+	}
+}
+
+// memberResult returns response of a single member that issued "success" certificates and failed "failed" ones.
+func memberResult(member string, success []string, failed []string) types.ReissueClusterCertificatesResponse {
+	result := types.NewReissueClusterCertificatesResponse()
+	result.ReissuedCertificates[member] = types.IssueCertificateResponse{Success: success, Failed: failed}
+	return *result
+}
+
+func TestUnexported_reissueOnMembers(t *testing.T) {
+	var calls []string
+	members := []memberReissuer{
+		fakeMember("10.0.0.2", &calls, memberResult("micro2", []string{"ovnnb", "client"}, nil), nil),
+		fakeMember("10.0.0.3", &calls, memberResult("micro3", []string{"client"}, nil), nil),
+	}
+
+	responseData := types.NewReissueClusterCertificatesResponse()
+	reissueOnMembers(context.Background(), members, responseData)
+
+	if !slices.Equal(calls, []string{"10.0.0.2", "10.0.0.3"}) {
+		t.Errorf("Expected members to be contacted in order, got %v", calls)
+	}
+	if len(responseData.Errors) != 0 {
+		t.Errorf("Expected no errors, got %v", responseData.Errors)
+	}
+	if len(responseData.ReissuedCertificates) != 2 || len(responseData.ReissuedCertificates["micro2"].Success) != 2 {
+		t.Errorf("Expected results of both members, got %v", responseData.ReissuedCertificates)
+	}
+}
+
+func TestUnexported_reissueOnMembersErrors(t *testing.T) {
+	failedResult := memberResult("micro2", []string{"client"}, []string{"ovnnb"})
+	failedResult.Errors = []string{"member micro2: failed to restart ovn-ovsdb-server-nb"}
+
+	testCases := []struct {
+		name           string
+		result         types.ReissueClusterCertificatesResponse
+		err            error
+		expectedErrors []string
+		hasResult      bool
+	}{
+		{
+			name:           "unreachable member",
+			err:            errors.New("connection refused"),
+			expectedErrors: []string{`failed to contact cluster member with address "10.0.0.2": connection refused`},
+		},
+		{
+			name:   "failed certificate",
+			result: failedResult,
+			expectedErrors: []string{
+				"member micro2: failed to restart ovn-ovsdb-server-nb",
+				"certificate reissue failed on member micro2. Remaining members were skipped.",
+			},
+			hasResult: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		var calls []string
+		members := []memberReissuer{
+			fakeMember("10.0.0.2", &calls, tc.result, tc.err),
+			fakeMember("10.0.0.3", &calls, memberResult("micro3", []string{"client"}, nil), nil),
+		}
+
+		responseData := types.NewReissueClusterCertificatesResponse()
+		reissueOnMembers(context.Background(), members, responseData)
+
+		if !slices.Equal(calls, []string{"10.0.0.2"}) {
+			t.Errorf("%s: expected remaining members to be skipped, got calls %v", tc.name, calls)
+		}
+		if !slices.Equal(responseData.Errors, tc.expectedErrors) {
+			t.Errorf("%s: expected errors:\n%s\ngot:\n%s", tc.name, strings.Join(tc.expectedErrors, "\n"), strings.Join(responseData.Errors, "\n"))
+		}
+
+		_, hasResult := responseData.ReissuedCertificates["micro2"]
+		if hasResult != tc.hasResult {
+			t.Errorf("%s: expected result of the failed member to be reported: %t, got %v", tc.name, tc.hasResult, responseData.ReissuedCertificates)
+		}
+		if _, found := responseData.ReissuedCertificates["micro3"]; found {
+			t.Errorf("%s: expected no result of the skipped member, got %v", tc.name, responseData.ReissuedCertificates)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package certificates
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/canonical/lxd/lxd/response"
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v2/client"
+	"github.com/canonical/microcluster/v2/rest"
+	"github.com/canonical/microcluster/v2/state"
+	"github.com/gorilla/mux"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	microovnClient "github.com/canonical/microovn/microovn/client"
+	"github.com/canonical/microovn/microovn/ovn/certificates"
+)
+
+// TrustedCasEndpoint defines endpoint for /1.0/ca/trust
+var TrustedCasEndpoint = rest.Endpoint{
+	Path: "ca/trust",
+	Get:  rest.EndpointAction{Handler: trustedCasGet, AllowUntrusted: false, ProxyTarget: false},
+	Post: rest.EndpointAction{Handler: trustedCasPost, AllowUntrusted: false, ProxyTarget: false},
+	Put:  rest.EndpointAction{Handler: trustedCasPut, AllowUntrusted: false, ProxyTarget: true},
+}
+
+// TrustedCaEndpoint defines endpoint for /1.0/ca/trust/<fingerprint>
+var TrustedCaEndpoint = rest.Endpoint{
+	Path:   "ca/trust/{fingerprint}",
+	Delete: rest.EndpointAction{Handler: trustedCaDelete, AllowUntrusted: false, ProxyTarget: false},
+}
+
+// trustedCasGet implements GET method for /1.0/ca/trust endpoint. It lists additional CA certificates that
+// are trusted to issue client certificates for the OVN databases.
+func trustedCasGet(s state.State, r *http.Request) response.Response {
+	trustedCAs, err := certificates.ListTrustedCAs(r.Context(), s)
+	if err != nil {
+		logger.Errorf("Failed to list trusted client CA certificates: %v", err)
+		return response.InternalError(fmt.Errorf("failed to list trusted client CA certificates"))
+	}
+	return response.SyncResponse(true, trustedCAs)
+}
+
+// trustedCasPost implements POST method for /1.0/ca/trust endpoint. It adds CA certificates from the request
+// to the trust bundle and distributes the updated bundle to every MicroOVN cluster member.
+func trustedCasPost(s state.State, r *http.Request) response.Response {
+	var request types.TrustedCaRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		return response.BadRequest(fmt.Errorf("failed to decode request: %w", err))
+	}
+
+	added, err := certificates.AddTrustedCAs(r.Context(), s, request.Certificate)
+	if err != nil {
+		logger.Errorf("Failed to add trusted client CA certificates: %v", err)
+		return response.BadRequest(fmt.Errorf("failed to add trusted client CA certificates: %w", err))
+	}
+
+	responseData := types.TrustedCaResponse{Fingerprints: added, Errors: []string{}}
+	if len(added) == 0 {
+		logger.Info("Trusted client CA certificates do not need updating")
+		return response.SyncResponse(true, &responseData)
+	}
+
+	logger.Infof("Added trusted client CA certificates: %v", added)
+	logger.Warn("Trusted client CA certificates are also accepted by the OVN database servers on the RAFT ports")
+	responseData.Errors = distributeTrustBundle(r.Context(), s)
+	return response.SyncResponse(true, &responseData)
+}
+
+// trustedCaDelete implements DELETE method for /1.0/ca/trust/<fingerprint> endpoint. It removes the CA
+// certificate from the trust bundle and distributes the updated bundle to every MicroOVN cluster member.
+func trustedCaDelete(s state.State, r *http.Request) response.Response {
+	fingerprint, err := url.PathUnescape(mux.Vars(r)["fingerprint"])
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	removed, err := certificates.RemoveTrustedCA(r.Context(), s, fingerprint)
+	if err != nil {
+		logger.Errorf("Failed to remove trusted client CA certificate: %v", err)
+		return response.InternalError(fmt.Errorf("failed to remove trusted client CA certificate"))
+	}
+
+	if !removed {
+		return response.NotFound(fmt.Errorf("CA certificate '%s' is not trusted", fingerprint))
+	}
+
+	logger.Infof("Removed trusted client CA certificate %s", fingerprint)
+	responseData := types.TrustedCaResponse{
+		Fingerprints: []string{certificates.NormalizeFingerprint(fingerprint)},
+		Errors:       distributeTrustBundle(r.Context(), s),
+	}
+	return response.SyncResponse(true, &responseData)
+}
+
+// trustedCasPut implements PUT method for /1.0/ca/trust endpoint. It writes the current trust bundle from
+// the shared database to the local file used by the OVN database servers.
+func trustedCasPut(s state.State, r *http.Request) response.Response {
+	err := certificates.DumpCA(r.Context(), s)
+	if err != nil {
+		logger.Errorf("Failed to update client CA bundle: %v", err)
+		return response.InternalError(fmt.Errorf("failed to update client CA bundle"))
+	}
+	return response.EmptySyncResponse
+}
+
+// distributeTrustBundle writes the current trust bundle to the local file and requests every other MicroOVN
+// cluster member to do the same. OVN database servers pick up the changed file without restart. Errors that
+// prevented the update on some members are returned as a list of messages.
+func distributeTrustBundle(ctx context.Context, s state.State) []string {
+	errs := []string{}
+
+	err := certificates.DumpCA(ctx, s)
+	if err != nil {
+		logger.Errorf("Failed to update client CA bundle: %v", err)
+		errs = append(errs, fmt.Sprintf("member %s: failed to update client CA bundle", s.Name()))
+	}
+
+	clusterClient, err := s.Cluster(true)
+	if err != nil {
+		logger.Errorf("Failed to get a client for every cluster member: %v", err)
+		return append(errs, "failed to contact other cluster members")
+	}
+
+	var mutex sync.Mutex
+	_ = clusterClient.Query(ctx, true, func(ctx context.Context, c *client.Client) error {
+		err := microovnClient.RefreshTrustedCAs(ctx, c)
+		if err != nil {
+			clientURL := c.URL()
+			mutex.Lock()
+			errs = append(errs, fmt.Sprintf("failed to update client CA bundle on member with address %q: %s", clientURL.String(), err))
+			mutex.Unlock()
+		}
+		return nil
+	})
+
+	return errs
+}
@@ -6,15 +6,24 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"slices"
 	"strings"
 
 	"github.com/canonical/lxd/lxd/response"
 	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v2/client"
 	"github.com/canonical/microcluster/v2/rest"
 	"github.com/canonical/microcluster/v2/state"
 	"github.com/canonical/microovn/microovn/api/types"
 	microOvnClient "github.com/canonical/microovn/microovn/client"
 	"github.com/canonical/microovn/microovn/config"
+	"github.com/canonical/microovn/microovn/database"
+	"github.com/canonical/microovn/microovn/maintenance"
+	ovnCluster "github.com/canonical/microovn/microovn/ovn/cluster"
+	"github.com/canonical/microovn/microovn/ovn/copp"
+	"github.com/canonical/microovn/microovn/ovn/environment"
+	"github.com/canonical/microovn/microovn/ovn/vip"
+	"github.com/canonical/microovn/microovn/ovn/vswitch"
 )
 
 // ConfigEndpoint - /1.0/config endpoint.
@@ -31,36 +40,100 @@ type configHandler = func(ctx context.Context, s state.State, key string, value
 // configValidator is a signature of a function that will validate configuration option values.
 type configValidator = func(value string) error
 
+// configStateValidator is a signature of a function that will validate configuration option values
+// against the current state of the cluster.
+type configStateValidator = func(ctx context.Context, s state.State, value string) error
+
+// configScope determines whether the configuration option applies to the whole cluster or only
+// to a single cluster member.
+type configScope int
+
+const (
+	// scopeCluster - config option applies to every member of the cluster.
+	scopeCluster configScope = iota
+	// scopeMember  - config option applies only to the member that handles the request. Requests
+	// are routed to the correct member via "target" URL parameter.
+	scopeMember
+)
+
 // spec is a structure that defines a valid configuration option
 type spec struct {
-	Key       string          // Name of the config option
-	Handler   configHandler   // Optional function that will be executed on value change (may be nil)
-	Validator configValidator // Function that will validate user config
+	Key            string               // Name of the config option
+	Handler        configHandler        // Optional function that will be executed on value change (may be nil)
+	Validator      configValidator      // Function that will validate user config
+	StateValidator configStateValidator // Optional function that will validate user config against cluster state (may be nil)
+	Scope          configScope          // Whether the config option is cluster-wide or member specific
 }
 
 // AllowedConfigKeys is a list of all valid configuration options
 var AllowedConfigKeys = []spec{
-	{Key: "ovn.central-ips", Handler: ovnCentralIpsUpdated, Validator: validateOvnCentralIps},
+	{Key: "ovn.central-ips", Handler: ovnEnvironmentUpdated, Validator: validateOvnCentralIps},
+	{Key: "ovn.remote-policy", Handler: ovnEnvironmentUpdated, Validator: validateOvnRemotePolicy},
+	{Key: "ovn.zones", Handler: ovnEnvironmentUpdated, Validator: validateOvnZones},
+	{Key: environment.DualStackConfigKey, Handler: ovnEnvironmentUpdated, Validator: validateBool, StateValidator: validateOvnDualStack},
+	{Key: environment.RaftFamilyConfigKey, Handler: ovnEnvironmentUpdated, Validator: validateAddressFamily},
+	{Key: environment.SecondaryAddressConfigKey, Handler: ovnEnvironmentUpdated, Validator: validateIP, StateValidator: validateSecondaryAddress, Scope: scopeMember},
+	{Key: environment.PreferredFamilyConfigKey, Handler: ovnEnvironmentUpdated, Validator: validateAddressFamily, Scope: scopeMember},
+	{Key: copp.RatesConfigKey, Handler: coppUpdated, Validator: validateCoppRates},
+	{Key: copp.AllRoutersConfigKey, Handler: coppUpdated, Validator: validateBool},
+	{Key: vswitch.RemoteManagerConfigKey, Handler: switchRemoteManagerUpdated, Validator: validateSwitchRemoteManager, Scope: scopeMember},
+	{Key: vswitch.ConntrackLimitConfigKey, Handler: switchConntrackLimitsUpdated, Validator: validateConntrackLimit, Scope: scopeMember},
+	{Key: vswitch.ConntrackZoneLimitsConfigKey, Handler: switchConntrackLimitsUpdated, Validator: validateConntrackZoneLimits, Scope: scopeMember},
+	{Key: vswitch.EncapCsumConfigKey, Handler: encapOptionsUpdated, Validator: validateBool},
+	{Key: vswitch.EncapDfDefaultConfigKey, Handler: encapOptionsUpdated, Validator: validateBool},
+	{Key: vswitch.EncapGenevePortConfigKey, Handler: encapOptionsUpdated, Validator: validateEncapGenevePort},
+	{Key: vswitch.MemberEncapCsumConfigKey, Handler: switchEncapOptionsUpdated, Validator: validateBool, Scope: scopeMember},
+	{Key: vswitch.MemberEncapDfDefaultConfigKey, Handler: switchEncapOptionsUpdated, Validator: validateBool, Scope: scopeMember},
+	{Key: vswitch.MemberEncapGenevePortConfigKey, Handler: switchEncapOptionsUpdated, Validator: validateEncapGenevePort, Scope: scopeMember},
+	{Key: maintenance.WindowsConfigKey, Validator: validateMaintenanceWindows},
+	{Key: maintenance.UrgencyThresholdConfigKey, Validator: validateMaintenanceUrgencyThreshold},
+	{Key: vip.NBConfigKey, Handler: virtualIPUpdated, Validator: validateVirtualIP},
+	{Key: vip.SBConfigKey, Handler: virtualIPUpdated, Validator: validateVirtualIP},
+	{Key: vip.InterfaceConfigKey, Validator: validateInterfaceName, Scope: scopeMember},
+	{
+		Key:            ovnCluster.NBReadOnlyListenersConfigKey,
+		Handler:        readOnlyListenersUpdated,
+		Validator:      validateReadOnlyListeners,
+		StateValidator: readOnlyListenersStateValidator(ovnCluster.SBReadOnlyListenersConfigKey),
+	},
+	{
+		Key:            ovnCluster.SBReadOnlyListenersConfigKey,
+		Handler:        readOnlyListenersUpdated,
+		Validator:      validateReadOnlyListeners,
+		StateValidator: readOnlyListenersStateValidator(ovnCluster.NBReadOnlyListenersConfigKey),
+	},
 }
 
 // setConfig function handles configuration value changes submitted via POST request to config endpoint
 func setConfig(s state.State, r *http.Request) response.Response {
 	var configRequest types.SetConfigRequest
 	configResponse := types.SetConfigResponse{}
-	handler, err := parseConfigRequest(r, &configRequest)
+	keySpec, err := parseConfigRequest(r, &configRequest)
 	if err != nil {
 		configResponse.Error = err.Error()
 		return response.SyncResponse(false, &configResponse)
 	}
 
-	err = config.SetConfig(r.Context(), s, configRequest.Key, configRequest.Value)
+	if keySpec.StateValidator != nil {
+		err = keySpec.StateValidator(r.Context(), s, configRequest.Value)
+		if err != nil {
+			configResponse.Error = fmt.Sprintf("configuration for key '%s' not valid: %v", configRequest.Key, err)
+			return response.SyncResponse(false, &configResponse)
+		}
+	}
+
+	if keySpec.Scope == scopeMember {
+		err = config.SetMemberConfig(r.Context(), s, s.Name(), configRequest.Key, configRequest.Value)
+	} else {
+		err = config.SetConfig(r.Context(), s, configRequest.Key, configRequest.Value)
+	}
 	if err != nil {
 		configResponse.Error = fmt.Sprintf("Error occurred while setting config: %v", err)
 		return response.SyncResponse(false, &configResponse)
 	}
 
-	if handler != nil {
-		err = handler(r.Context(), s, configRequest.Key, configRequest.Value)
+	if keySpec.Handler != nil {
+		err = keySpec.Handler(r.Context(), s, configRequest.Key, configRequest.Value)
 		if err != nil {
 			logger.Errorf(err.Error())
 			configResponse.Error = fmt.Sprintf("Error occurred while handling config change: %v", err)
@@ -75,13 +148,22 @@ func setConfig(s state.State, r *http.Request) response.Response {
 func getConfig(s state.State, r *http.Request) response.Response {
 	var configRequest types.GetConfigRequest
 	configResponse := types.GetConfigResponse{}
-	_, err := parseConfigRequest(r, &configRequest)
+	keySpec, err := parseConfigRequest(r, &configRequest)
 	if err != nil {
 		configResponse.Error = err.Error()
 		return response.SyncResponse(false, &configResponse)
 	}
 
-	item, err := config.GetConfig(r.Context(), s, configRequest.Key)
+	var item *database.ConfigItem
+	if keySpec.Scope == scopeMember {
+		var memberItem *database.MemberConfigItem
+		memberItem, err = config.GetMemberConfig(r.Context(), s, s.Name(), configRequest.Key)
+		if memberItem != nil {
+			item = &database.ConfigItem{Key: memberItem.Key, Value: memberItem.Value}
+		}
+	} else {
+		item, err = config.GetConfig(r.Context(), s, configRequest.Key)
+	}
 	if err != nil {
 		configResponse.Error = fmt.Sprintf("Error occurred while getting config: %v", err)
 		return response.SyncResponse(false, &configResponse)
@@ -101,20 +183,24 @@ func getConfig(s state.State, r *http.Request) response.Response {
 func deleteConfig(s state.State, r *http.Request) response.Response {
 	var configRequest types.DeleteConfigRequest
 	configResponse := types.DeleteConfigResponse{}
-	handler, err := parseConfigRequest(r, &configRequest)
+	keySpec, err := parseConfigRequest(r, &configRequest)
 	if err != nil {
 		configResponse.Error = err.Error()
 		return response.SyncResponse(false, &configResponse)
 	}
 
-	err = config.DeleteConfig(r.Context(), s, configRequest.Key)
+	if keySpec.Scope == scopeMember {
+		err = config.DeleteMemberConfig(r.Context(), s, s.Name(), configRequest.Key)
+	} else {
+		err = config.DeleteConfig(r.Context(), s, configRequest.Key)
+	}
 	if err != nil {
 		configResponse.Error = fmt.Sprintf("Error occurred while deleting config: %v", err)
 		return response.SyncResponse(false, &configResponse)
 	}
 
-	if handler != nil {
-		err = handler(r.Context(), s, configRequest.Key, "")
+	if keySpec.Handler != nil {
+		err = keySpec.Handler(r.Context(), s, configRequest.Key, "")
 		if err != nil {
 			logger.Errorf(err.Error())
 			configResponse.Error = fmt.Sprintf("Error occurred while handling config change: %v", err)
@@ -125,10 +211,10 @@ func deleteConfig(s state.State, r *http.Request) response.Response {
 }
 
 // parseConfigRequest validates requests to the config endpoint. If the request is made for
-// a valid config option, it returns the handler function associated with it.
+// a valid config option, it returns the spec associated with it.
 // This function returns an error if it fails to parse the body of the request, if a request
 // is made for an unknown configuration option or if the configuration option input is not valid.
-func parseConfigRequest(r *http.Request, parsedData any) (configHandler, error) {
+func parseConfigRequest(r *http.Request, parsedData any) (*spec, error) {
 	err := json.NewDecoder(r.Body).Decode(&parsedData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode config request: %v", err)
@@ -151,8 +237,6 @@ func parseConfigRequest(r *http.Request, parsedData any) (configHandler, error)
 		return nil, fmt.Errorf("unknown config request type")
 	}
 
-	allowedKey := false
-	var handler configHandler
 	for _, keySpec := range AllowedConfigKeys {
 		if keySpec.Key == keyValue {
 			if toBeValidated {
@@ -163,22 +247,17 @@ func parseConfigRequest(r *http.Request, parsedData any) (configHandler, error)
 				}
 			}
 
-			allowedKey = true
-			handler = keySpec.Handler
-			break
+			return &keySpec, nil
 		}
 	}
 
-	if !allowedKey {
-		return nil, fmt.Errorf("config key '%s' is not a recognized config option", keyValue)
-	}
-
-	return handler, nil
+	return nil, fmt.Errorf("config key '%s' is not a recognized config option", keyValue)
 }
 
-// ovnCentralIpsUpdated is a handler for changes to the "ovn.central-ips" config option change. It triggers
-// microovn.api.RegenerateEnvEndpoint to refresh controller configuration on every cluster member.
-func ovnCentralIpsUpdated(ctx context.Context, s state.State, key string, _ string) error {
+// ovnEnvironmentUpdated is a handler for changes to config options that affect OVN environment, like
+// "ovn.central-ips" or "ovn.remote-policy". It triggers microovn.api.RegenerateEnvEndpoint to refresh
+// controller configuration on every cluster member.
+func ovnEnvironmentUpdated(ctx context.Context, s state.State, key string, _ string) error {
 	errMsgPrefix := fmt.Sprintf("handling of '%s' config failed.", key)
 
 	client, err := s.Leader()
@@ -224,3 +303,270 @@ func validateOvnCentralIps(value string) error {
 
 	return nil
 }
+
+// switchRemoteManagerUpdated is a handler for changes to the "switch.remote-manager" config option. It
+// (re)configures remote OVS manager listener on this cluster member.
+func switchRemoteManagerUpdated(ctx context.Context, s state.State, key string, _ string) error {
+	err := vswitch.UpdateRemoteManager(ctx, s)
+	if err != nil {
+		logger.Errorf("failed to update remote OVS manager: %v", err)
+		return fmt.Errorf("handling of '%s' config failed. Please see logs for more details", key)
+	}
+	return nil
+}
+
+// coppUpdated is a handler for changes to the "ovn.copp.*" config options. It updates the MicroOVN CoPP
+// profile in the OVN Northbound database and (re)attaches it to Logical Routers.
+func coppUpdated(ctx context.Context, s state.State, key string, _ string) error {
+	err := copp.Apply(ctx, s)
+	if err != nil {
+		logger.Errorf("failed to update CoPP profile: %v", err)
+		return fmt.Errorf("handling of '%s' config failed. Please see logs for more details", key)
+	}
+	return nil
+}
+
+// validateCoppRates validates that the value is a comma-separated list of "<protocol>=<rate>" pairs
+func validateCoppRates(value string) error {
+	_, err := copp.ParseRates(value)
+	return err
+}
+
+// switchConntrackLimitsUpdated is a handler for changes to the "switch.ct-limit" and "switch.ct-zone-limits"
+// config options. It applies conntrack limits to the datapath on this cluster member.
+func switchConntrackLimitsUpdated(ctx context.Context, s state.State, key string, _ string) error {
+	err := vswitch.UpdateConntrackLimits(ctx, s, true)
+	if err != nil {
+		logger.Errorf("failed to update conntrack limits: %v", err)
+		return fmt.Errorf("handling of '%s' config failed. Please see logs for more details", key)
+	}
+	return nil
+}
+
+// validateConntrackLimit validates that the value is a non-negative number
+func validateConntrackLimit(value string) error {
+	_, err := vswitch.ParseConntrackLimit(value)
+	return err
+}
+
+// validateConntrackZoneLimits validates that the value is a comma-separated list of "<zone>=<limit>" pairs
+func validateConntrackZoneLimits(value string) error {
+	_, err := vswitch.ParseConntrackZoneLimits(value)
+	return err
+}
+
+// encapOptionsUpdated is a handler for changes to the "ovn.encap-*" config options. It applies tunnel
+// encapsulation options on this cluster member and requests every other member to do the same.
+func encapOptionsUpdated(ctx context.Context, s state.State, key string, _ string) error {
+	errMsg := fmt.Errorf("handling of '%s' config failed. Please see logs for more details", key)
+
+	err := vswitch.UpdateEncapOptions(ctx, s)
+	if err != nil {
+		logger.Errorf("failed to update tunnel encapsulation options: %v", err)
+		return errMsg
+	}
+
+	clusterClient, err := s.Cluster(true)
+	if err != nil {
+		logger.Errorf("failed to get a client for every cluster member: %v", err)
+		return errMsg
+	}
+
+	return clusterClient.Query(ctx, true, func(ctx context.Context, c *client.Client) error {
+		err := microOvnClient.RefreshEncapOptions(ctx, c)
+		if err != nil {
+			clientURL := c.URL()
+			logger.Errorf("failed to update tunnel encapsulation options on member with address %q: %v", clientURL.String(), err)
+			return errMsg
+		}
+		return nil
+	})
+}
+
+// switchEncapOptionsUpdated is a handler for changes to the "switch.encap-*" config options. It applies
+// tunnel encapsulation options on this cluster member.
+func switchEncapOptionsUpdated(ctx context.Context, s state.State, key string, _ string) error {
+	err := vswitch.UpdateEncapOptions(ctx, s)
+	if err != nil {
+		logger.Errorf("failed to update tunnel encapsulation options: %v", err)
+		return fmt.Errorf("handling of '%s' config failed. Please see logs for more details", key)
+	}
+	return nil
+}
+
+// validateEncapGenevePort validates that the value is a valid UDP port number
+func validateEncapGenevePort(value string) error {
+	_, err := vswitch.ParseEncapGenevePort(value)
+	return err
+}
+
+// virtualIPUpdated is a handler for changes to the "ovn.nb.vip" and "ovn.sb.vip" config options. It re-issues
+// certificates of the affected OVN database on every cluster member, so that they include the new virtual IP.
+// The virtual IP itself is moved by the members' periodic reconciliation.
+func virtualIPUpdated(ctx context.Context, s state.State, key string, _ string) error {
+	service := "ovnnb"
+	if key == vip.SBConfigKey {
+		service = "ovnsb"
+	}
+
+	client, err := s.Leader()
+	if err != nil {
+		logger.Errorf("failed to get client for the cluster leader: %v", err)
+		return fmt.Errorf("handling of '%s' config failed. Please see logs for more details", key)
+	}
+
+	result, err := microOvnClient.ReissueClusterCertificates(ctx, client, service)
+	if err != nil || len(result.Errors) != 0 {
+		logger.Errorf("failed to reissue %s certificates: %v", service, err)
+		logger.Errorf(strings.Join(result.Errors, "\n"))
+		return fmt.Errorf("handling of '%s' config failed. Please see logs for more details", key)
+	}
+	return nil
+}
+
+// validateVirtualIP validates that the value is a unicast IP address with optional prefix length
+func validateVirtualIP(value string) error {
+	_, err := vip.Parse(value)
+	return err
+}
+
+// readOnlyListenersUpdated is a handler for changes to the "ovn.nb.read-only-listeners" and
+// "ovn.sb.read-only-listeners" config options. It updates read-only Connection records of the OVN databases,
+// which are shared by every central member.
+func readOnlyListenersUpdated(ctx context.Context, s state.State, key string, _ string) error {
+	err := ovnCluster.ApplyReadOnlyListeners(ctx, s)
+	if err != nil {
+		logger.Errorf("failed to update read-only listeners of OVN databases: %v", err)
+		return fmt.Errorf("handling of '%s' config failed. Please see logs for more details", key)
+	}
+	return nil
+}
+
+// validateReadOnlyListeners validates that the value is a comma-separated list of "[<address>:]<port>" items
+func validateReadOnlyListeners(value string) error {
+	_, err := ovnCluster.ParseReadOnlyListeners(value)
+	return err
+}
+
+// readOnlyListenersStateValidator returns validator that ensures that read-only listeners do not use ports of
+// the read-only listeners of the other OVN database, configured in the "otherKey" config option.
+func readOnlyListenersStateValidator(otherKey string) configStateValidator {
+	return func(ctx context.Context, s state.State, value string) error {
+		listeners, err := ovnCluster.ParseReadOnlyListeners(value)
+		if err != nil {
+			return err
+		}
+
+		otherItem, err := config.GetConfig(ctx, s, otherKey)
+		if err != nil || otherItem == nil {
+			return err
+		}
+
+		otherListeners, err := ovnCluster.ParseReadOnlyListeners(otherItem.Value)
+		if err != nil {
+			return fmt.Errorf("invalid value of '%s' config option: %w", otherKey, err)
+		}
+
+		for _, listener := range listeners {
+			for _, other := range otherListeners {
+				if listener.Port == other.Port {
+					return fmt.Errorf("port %d is already used by '%s'", listener.Port, otherKey)
+				}
+			}
+		}
+		return nil
+	}
+}
+
+// validateInterfaceName validates that the value is a valid name of a network interface
+func validateInterfaceName(value string) error {
+	if value == "" || len(value) > 15 || strings.ContainsAny(value, "/: \t\n") {
+		return fmt.Errorf("'%s' is not a valid interface name", value)
+	}
+
+	return nil
+}
+
+// validateMaintenanceWindows validates that the value is a semicolon-separated list of maintenance windows
+func validateMaintenanceWindows(value string) error {
+	_, err := maintenance.ParseWindows(value)
+	return err
+}
+
+// validateMaintenanceUrgencyThreshold validates that the value is a non-negative duration
+func validateMaintenanceUrgencyThreshold(value string) error {
+	_, err := maintenance.ParseUrgencyThreshold(value)
+	return err
+}
+
+// validateSwitchRemoteManager validates that the value is in the "[<address>:]<port>" format
+func validateSwitchRemoteManager(value string) error {
+	_, err := vswitch.ParseRemoteManager(value)
+	return err
+}
+
+// validateOvnRemotePolicy validates that the value is one of the supported "ovn-remote" ordering policies
+func validateOvnRemotePolicy(value string) error {
+	if !slices.Contains(ovnCluster.RemotePolicies, value) {
+		return fmt.Errorf("unknown policy '%s'. Supported policies are: %s", value, strings.Join(ovnCluster.RemotePolicies, ", "))
+	}
+
+	return nil
+}
+
+// validateOvnZones validates that the value is a comma-separated list of "<member>=<zone>" pairs
+func validateOvnZones(value string) error {
+	_, err := ovnCluster.ParseZones(value)
+	return err
+}
+
+// validateBool validates that the value is either "true" or "false"
+func validateBool(value string) error {
+	if value != "true" && value != "false" {
+		return fmt.Errorf("value must be either 'true' or 'false'")
+	}
+
+	return nil
+}
+
+// validateIP validates that the value is a single IPv4 or IPv6 address (not enclosed in brackets "[]")
+func validateIP(value string) error {
+	if net.ParseIP(value) == nil {
+		return fmt.Errorf("cannot parse IP address '%s'", value)
+	}
+
+	return nil
+}
+
+// validateAddressFamily validates that the value is a name of the supported address family
+func validateAddressFamily(value string) error {
+	if !slices.Contains(environment.AddressFamilies, value) {
+		return fmt.Errorf("unknown address family '%s'. Supported values are: %s", value, strings.Join(environment.AddressFamilies, ", "))
+	}
+
+	return nil
+}
+
+// validateOvnDualStack ensures that every OVN central node has addresses from both address families
+// before dual-stack is enabled
+func validateOvnDualStack(ctx context.Context, s state.State, value string) error {
+	if value != "true" {
+		return nil
+	}
+
+	return environment.ValidateDualStack(ctx, s)
+}
+
+// validateSecondaryAddress ensures that the secondary address is from different address family than
+// the MicroOVN cluster address of the member
+func validateSecondaryAddress(_ context.Context, s state.State, value string) error {
+	primaryAddr := s.Address().Hostname()
+	if environment.Family(value) == environment.Family(primaryAddr) {
+		return fmt.Errorf(
+			"secondary address '%s' must be from a different address family than the member's address '%s'",
+			value, primaryAddr,
+		)
+	}
+
+	return nil
+}
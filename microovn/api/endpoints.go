@@ -3,12 +3,18 @@ package api
 
 import (
 	"github.com/canonical/microcluster/v2/rest"
+	"github.com/canonical/microovn/microovn/api/bgp"
 	"github.com/canonical/microovn/microovn/api/config"
+	"github.com/canonical/microovn/microovn/api/facts"
+	"github.com/canonical/microovn/microovn/api/maintenance"
 	"github.com/canonical/microovn/microovn/api/ovsdb"
+	"github.com/canonical/microovn/microovn/api/realization"
 
 	"github.com/canonical/microovn/microovn/api/certificates"
 	"github.com/canonical/microovn/microovn/api/services"
+	"github.com/canonical/microovn/microovn/api/topology"
 	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/api/vswitch"
 )
 
 // Server is an extension to the default microcluster server, which serves the supplied endpoints over "/1.0"
@@ -25,11 +31,34 @@ var Server = map[string]rest.Server{
 					RegenerateEnvEndpoint,
 					certificates.IssueCertificatesEndpoint,
 					certificates.IssueCertificatesAllEndpoint,
+					certificates.IssueCertificatesClusterEndpoint,
 					certificates.RegenerateCaEndpoint,
+					certificates.TrustedCasEndpoint,
+					certificates.TrustedCaEndpoint,
 					ovsdb.ActiveSchemaVersion,
 					ovsdb.AllExpectedSchemaVersions,
 					ovsdb.ExpectedSchemaVersion,
+					ovsdb.SchemaDiff,
+					ovsdb.SbConnections,
+					ovsdb.AllSbConnections,
+					ovsdb.ConvertToCluster,
+					ovsdb.Check,
+					ovsdb.AllChecks,
+					ovsdb.Reseed,
 					config.ConfigEndoint,
+					bgp.RedirectInterfaces,
+					facts.MemberFacts,
+					vswitch.Inventory,
+					vswitch.DatapathStats,
+					vswitch.EncapOptions,
+					vswitch.HostPorts,
+					vswitch.HostPort,
+					maintenance.Check,
+					topology.Export,
+					topology.Import,
+					topology.Graph,
+					realization.Realization,
+					bgp.Routes,
 				},
 			},
 		},
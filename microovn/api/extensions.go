@@ -2,6 +2,27 @@ package api
 
 var extensions = []string{
 	"custom_encapsulation_ip",
+	"ovn_remote_policy",
+	"member_config",
+	"switch_remote_manager",
+	"cluster_certificates_reissue",
+	"ovn_dual_stack",
+	"ovn_standalone_database",
+	"bgp_redirect_interfaces",
+	"member_facts",
+	"switch_inventory",
+	"datapath_stats",
+	"maintenance_windows",
+	"topology_export_import",
+	"topology_graph",
+	"bgp_rpki",
+	"ovsdb_virtual_ip",
+	"ovsdb_schema_diff",
+	"ovsdb_check_reseed",
+	"ovn_realization",
+	"trusted_client_cas",
+	"tunnel_encap_options",
+	"host_ports",
 }
 
 // Extensions returns the list of MicroOVN extensions.
@@ -0,0 +1,31 @@
+// Package facts implements APIs that expose facts published by cluster members.
+package facts
+
+import (
+	"net/http"
+
+	"github.com/canonical/lxd/lxd/response"
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v2/rest"
+	"github.com/canonical/microcluster/v2/state"
+
+	"github.com/canonical/microovn/microovn/facts"
+)
+
+// MemberFacts defines endpoint for /1.0/facts
+var MemberFacts = rest.Endpoint{
+	Path: "facts",
+	Get:  rest.EndpointAction{Handler: getMemberFacts, AllowUntrusted: false, ProxyTarget: false},
+}
+
+// getMemberFacts implements GET method for /1.0/facts. It returns facts most recently published by every
+// cluster member, in the format of types.MemberFactsList.
+func getMemberFacts(s state.State, r *http.Request) response.Response {
+	memberFacts, err := facts.List(r.Context(), s)
+	if err != nil {
+		logger.Errorf("Failed to list member facts: %s", err)
+		return response.ErrorResponse(500, "internal server error")
+	}
+
+	return response.SyncResponse(true, memberFacts)
+}
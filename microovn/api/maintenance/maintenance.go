@@ -0,0 +1,45 @@
+// Package maintenance implements APIs that let disruptive jobs consult maintenance windows.
+package maintenance
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/canonical/lxd/lxd/response"
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v2/rest"
+	"github.com/canonical/microcluster/v2/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/maintenance"
+)
+
+// Check defines endpoint for /1.0/maintenance/check
+var Check = rest.Endpoint{
+	Path: "maintenance/check",
+	Post: rest.EndpointAction{Handler: checkMaintenance, AllowUntrusted: false, ProxyTarget: true},
+}
+
+// checkMaintenance implements POST method for /1.0/maintenance/check. It accepts types.MaintenanceCheck and
+// responds with types.MaintenanceCheckResult, telling the caller whether the job is allowed to run now. Jobs that
+// are not allowed to run are reported as deferred in the facts of the member that handled the request.
+func checkMaintenance(s state.State, r *http.Request) response.Response {
+	var request types.MaintenanceCheck
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		return response.BadRequest(fmt.Errorf("failed to decode maintenance check request: %w", err))
+	}
+
+	if request.Job == "" {
+		return response.BadRequest(fmt.Errorf("job name is required"))
+	}
+
+	allowed, nextWindow, err := maintenance.Allowed(r.Context(), s, request.Job, request.Deadline)
+	if err != nil {
+		logger.Errorf("Failed to check maintenance windows for job '%s': %s", request.Job, err)
+		return response.ErrorResponse(500, "internal server error")
+	}
+
+	return response.SyncResponse(true, types.MaintenanceCheckResult{Allowed: allowed, NextWindow: nextWindow})
+}
@@ -0,0 +1,151 @@
+package ovsdb
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/canonical/lxd/lxd/response"
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v2/client"
+	"github.com/canonical/microcluster/v2/rest"
+	"github.com/canonical/microcluster/v2/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	microovnClient "github.com/canonical/microovn/microovn/client"
+	"github.com/canonical/microovn/microovn/node"
+)
+
+// Check defines endpoint for /1.0/ovsdb/check
+var Check = rest.Endpoint{
+	Path: "ovsdb/check",
+	Get:  rest.EndpointAction{Handler: getCheck, AllowUntrusted: false, ProxyTarget: false},
+}
+
+// AllChecks defines endpoint for /1.0/ovsdb/check/all
+var AllChecks = rest.Endpoint{
+	Path: "ovsdb/check/all",
+	Get:  rest.EndpointAction{Handler: getAllChecks, AllowUntrusted: false, ProxyTarget: false},
+}
+
+// Reseed defines endpoint for /1.0/ovsdb/reseed
+var Reseed = rest.Endpoint{
+	Path: "ovsdb/reseed",
+	Put:  rest.EndpointAction{Handler: reseedPut, AllowUntrusted: false, ProxyTarget: true},
+}
+
+// getCheck implements GET method for /1.0/ovsdb/check. It returns results of the integrity checks of the
+// OVN Northbound and Southbound databases on this node. Optional "ignore-peer" query parameter holds address
+// of a member whose missing RAFT connection is not reported as a problem.
+// The response is in the format of types.OvsdbCheckReport
+func getCheck(s state.State, r *http.Request) response.Response {
+	hasCentral, err := node.HasServiceActive(r.Context(), s, types.SrvCentral)
+	if err != nil {
+		logger.Errorf("Failed to check if central is active on this node: %s", err)
+		return response.ErrorResponse(500, "internal server error")
+	}
+
+	if !hasCentral {
+		return response.BadRequest(fmt.Errorf("this node does not run 'central' service"))
+	}
+
+	report, err := node.CheckCentral(r.Context(), s, r.URL.Query().Get("ignore-peer"))
+	if err != nil {
+		logger.Errorf("Failed to check OVN databases: %s", err)
+		return response.ErrorResponse(500, "internal server error")
+	}
+
+	return response.SyncResponse(true, &report)
+}
+
+// getAllChecks implements GET method for /1.0/ovsdb/check/all. It returns results of the integrity checks
+// of the OVN Northbound and Southbound databases on each member that runs "central" services.
+// The response is in the format of types.OvsdbCheckReport
+func getAllChecks(s state.State, r *http.Request) response.Response {
+	centralNodes, err := node.FindService(r.Context(), s, types.SrvCentral)
+	if err != nil {
+		logger.Errorf("Failed to find central nodes: %s", err)
+		return response.ErrorResponse(500, "internal server error")
+	}
+
+	localCentral := false
+	centralNames := make(map[string]string, len(centralNodes))
+	for _, centralNode := range centralNodes {
+		centralNames[centralNode.Address] = centralNode.Name
+		if centralNode.Name == s.Name() {
+			localCentral = true
+		}
+	}
+
+	responseData := types.OvsdbCheckReport{}
+
+	// Check local databases if this node runs "central" services
+	if localCentral {
+		report, err := node.CheckCentral(r.Context(), s, "")
+		if err != nil {
+			logger.Errorf("Failed to check OVN databases: %s", err)
+			report = failedCheck(s.Name())
+		}
+		responseData = append(responseData, report...)
+	}
+
+	// Get clients for each member in the cluster
+	clusterClient, err := s.Cluster(false)
+	if err != nil {
+		logger.Errorf("Failed to get a client for every cluster member: %s", err)
+		return response.ErrorResponse(500, "internal server error")
+	}
+
+	// Fetch check results from each cluster member that runs "central" services.
+	var lock sync.Mutex
+	_ = clusterClient.Query(r.Context(), true, func(ctx context.Context, c *client.Client) error {
+		clientURL := c.URL()
+		memberName, ok := centralNames[net.JoinHostPort(clientURL.Hostname(), clientURL.Port())]
+		if !ok {
+			return nil
+		}
+
+		logger.Debugf("Checking OVN databases on '%s'", clientURL.String())
+		report, err := microovnClient.GetOvsdbCheck(ctx, c, "")
+		if err != nil {
+			logger.Errorf("Failed to check OVN databases on '%s': %s", memberName, err)
+			report = failedCheck(memberName)
+		}
+
+		lock.Lock()
+		responseData = append(responseData, report...)
+		lock.Unlock()
+		return nil
+	})
+
+	return response.SyncResponse(true, &responseData)
+}
+
+// failedCheck returns check results for a member on which the checks could not be run at all.
+func failedCheck(member string) types.OvsdbCheckReport {
+	report := types.OvsdbCheckReport{}
+	for _, db := range []string{"nb", "sb"} {
+		report = append(report, types.OvsdbCheckResult{
+			Member:   member,
+			Database: db,
+			Problems: []string{"failed to run database checks"},
+		})
+	}
+	return report
+}
+
+// reseedPut implements PUT method for /1.0/ovsdb/reseed. It replaces OVN Northbound and Southbound
+// databases on this node with fresh copies received from healthy cluster peers and waits until they
+// catch up with the cluster. The reseed runs with a context that is not cancelled when the client
+// disconnects, as interrupting it half-way could leave the node without databases.
+func reseedPut(s state.State, r *http.Request) response.Response {
+	err := node.ReseedCentral(context.WithoutCancel(r.Context()), s)
+	if err != nil {
+		logger.Errorf("Failed to reseed OVN databases: %s", err)
+		return response.InternalError(fmt.Errorf("failed to reseed OVN databases: %w", err))
+	}
+
+	return response.EmptySyncResponse
+}
@@ -0,0 +1,120 @@
+package ovsdb
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/canonical/lxd/lxd/response"
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v2/client"
+	"github.com/canonical/microcluster/v2/rest"
+	"github.com/canonical/microcluster/v2/state"
+	"github.com/canonical/microovn/microovn/api/types"
+	microovnClient "github.com/canonical/microovn/microovn/client"
+	"github.com/canonical/microovn/microovn/node"
+	ovnCluster "github.com/canonical/microovn/microovn/ovn/cluster"
+)
+
+// SbConnections defines endpoint for /1.0/ovsdb/sb/connections
+var SbConnections = rest.Endpoint{
+	Path: "ovsdb/sb/connections",
+	Get:  rest.EndpointAction{Handler: getSbConnections, AllowUntrusted: false, ProxyTarget: false},
+}
+
+// AllSbConnections defines endpoint for /1.0/ovsdb/sb/connections/all
+var AllSbConnections = rest.Endpoint{
+	Path: "ovsdb/sb/connections/all",
+	Get:  rest.EndpointAction{Handler: getAllSbConnections, AllowUntrusted: false, ProxyTarget: false},
+}
+
+// getSbConnections implements GET method for /1.0/ovsdb/sb/connections. It returns number of clients
+// connected to the OVN Southbound database server running on this node.
+func getSbConnections(s state.State, r *http.Request) response.Response {
+	hasCentral, err := node.HasServiceActive(r.Context(), s, types.SrvCentral)
+	if err != nil {
+		logger.Errorf("Failed to check if central is active on this node: %s", err)
+		return response.ErrorResponse(500, "internal server error")
+	}
+
+	if !hasCentral {
+		return response.BadRequest(fmt.Errorf("this node does not run 'central' service"))
+	}
+
+	sessions, err := ovnCluster.SouthboundSessions(r.Context(), s)
+	if err != nil {
+		logger.Errorf("Failed to get number of OVN Southbound database connections: %s", err)
+		return response.ErrorResponse(500, "internal server error")
+	}
+
+	return response.SyncResponse(true, sessions)
+}
+
+// getAllSbConnections implements GET method for /1.0/ovsdb/sb/connections/all. It returns number
+// of clients connected to each OVN Southbound database server in the deployment. This can be used to
+// verify how are chassis distributed among the servers.
+// The response is in the format of types.SbConnectionsReport
+func getAllSbConnections(s state.State, r *http.Request) response.Response {
+	centralNodes, err := node.FindService(r.Context(), s, types.SrvCentral)
+	if err != nil {
+		logger.Errorf("Failed to find central nodes: %s", err)
+		return response.ErrorResponse(500, "internal server error")
+	}
+
+	localCentral := false
+	centralNames := make(map[string]string, len(centralNodes))
+	for _, centralNode := range centralNodes {
+		centralNames[centralNode.Address] = centralNode.Name
+		if centralNode.Name == s.Name() {
+			localCentral = true
+		}
+	}
+
+	responseData := types.SbConnectionsReport{}
+
+	// Get local connection count if this node runs "central" services
+	if localCentral {
+		result := types.SbConnectionsResult{Member: s.Name()}
+		result.Connections, err = ovnCluster.SouthboundSessions(r.Context(), s)
+		if err != nil {
+			logger.Errorf("Failed to get number of OVN Southbound database connections: %s", err)
+			result.Error = "failed to query Southbound database server"
+		}
+		responseData = append(responseData, result)
+	}
+
+	// Get clients for each member in the cluster
+	clusterClient, err := s.Cluster(false)
+	if err != nil {
+		logger.Errorf("Failed to get a client for every cluster member: %s", err)
+		return response.ErrorResponse(500, "internal server error")
+	}
+
+	// Fetch connection counts from each cluster member that runs "central" services.
+	var mutex sync.Mutex
+	_ = clusterClient.Query(r.Context(), true, func(ctx context.Context, c *client.Client) error {
+		clientURL := c.URL()
+		memberName, ok := centralNames[net.JoinHostPort(clientURL.Hostname(), clientURL.Port())]
+		if !ok {
+			return nil
+		}
+
+		logger.Debugf("Fetching OVN Southbound database connections from '%s'", clientURL.String())
+		result := types.SbConnectionsResult{Member: memberName}
+		connections, err := microovnClient.GetSbConnections(ctx, c)
+		if err != nil {
+			logger.Errorf("Failed to get OVN Southbound database connections from '%s': %s", memberName, err)
+			result.Error = "failed to query Southbound database server"
+		}
+		result.Connections = connections
+
+		mutex.Lock()
+		responseData = append(responseData, result)
+		mutex.Unlock()
+		return nil
+	})
+
+	return response.SyncResponse(true, &responseData)
+}
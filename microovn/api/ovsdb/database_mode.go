@@ -0,0 +1,44 @@
+package ovsdb
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/canonical/lxd/lxd/response"
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v2/rest"
+	"github.com/canonical/microcluster/v2/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/node"
+	ovnCluster "github.com/canonical/microovn/microovn/ovn/cluster"
+)
+
+// ConvertToCluster defines endpoint for /1.0/ovsdb/convert-to-cluster
+var ConvertToCluster = rest.Endpoint{
+	Path: "ovsdb/convert-to-cluster",
+	Put:  rest.EndpointAction{Handler: convertToClusterPut, AllowUntrusted: false, ProxyTarget: false},
+}
+
+// convertToClusterPut implements PUT method for /1.0/ovsdb/convert-to-cluster. It converts standalone
+// OVN Northbound and Southbound databases running on this node to clustered databases, so that other
+// central members can join them. Request has no effect if the databases are already clustered.
+func convertToClusterPut(s state.State, r *http.Request) response.Response {
+	hasCentral, err := node.HasServiceActive(r.Context(), s, types.SrvCentral)
+	if err != nil {
+		logger.Errorf("Failed to check if central is active on this node: %s", err)
+		return response.ErrorResponse(500, "internal server error")
+	}
+
+	if !hasCentral {
+		return response.BadRequest(fmt.Errorf("this node does not run 'central' service"))
+	}
+
+	err = ovnCluster.ConvertStandaloneDatabases(r.Context(), s)
+	if err != nil {
+		logger.Errorf("Failed to convert standalone OVN databases: %s", err)
+		return response.InternalError(fmt.Errorf("failed to convert standalone OVN databases: %w", err))
+	}
+
+	return response.EmptySyncResponse
+}
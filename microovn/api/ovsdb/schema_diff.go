@@ -0,0 +1,92 @@
+package ovsdb
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/canonical/lxd/lxd/response"
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v2/rest"
+	"github.com/canonical/microcluster/v2/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	microovnClient "github.com/canonical/microovn/microovn/client"
+	"github.com/canonical/microovn/microovn/node"
+	ovnCmd "github.com/canonical/microovn/microovn/ovn/cmd"
+	"github.com/canonical/microovn/microovn/ovn/ovsdb"
+)
+
+// SchemaDiff defines endpoints for /1.0/ovsdb/schema/<db-name>/diff
+var SchemaDiff = rest.Endpoint{
+	Path: "ovsdb/schema/{db}/diff",
+	Get:  rest.EndpointAction{Handler: getSchemaDiff, AllowUntrusted: false, ProxyTarget: false},
+}
+
+// getSchemaDiff implements GET method for /1.0/ovsdb/schema/<db-name>/diff. It returns differences between
+// the schema of the running database specified by <db-name> and the schema shipped with OVN/OVS packages on
+// this node. The response is in the format of types.OvsdbSchemaDiff.
+//
+// If the node receives request for Northbound or Southbound database, but it does not run central services,
+// the request will be forwarded to a node that does run them.
+func getSchemaDiff(s state.State, r *http.Request) response.Response {
+	dbSpec, errResponse := parseDbSpec(r)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	hasCentral, err := node.HasServiceActive(r.Context(), s, types.SrvCentral)
+	if err != nil {
+		logger.Errorf("Failed to check if central is active on this node: %s", err)
+		return response.ErrorResponse(500, "internal server error")
+	}
+
+	if dbSpec.IsCentral && !hasCentral {
+		logger.Info("This node does not run 'central' service. Request will be forwarded.")
+		return forwardSchemaDiff(s, r, dbSpec)
+	}
+
+	diff, err := ovsdb.SchemaDiff(r.Context(), s, dbSpec)
+	if err != nil {
+		logger.Errorf("Failed to compare schemas of '%s' database: %s", dbSpec.FriendlyName, err)
+		return response.InternalError(fmt.Errorf("failed to compare schemas of %s database: %w", dbSpec.FriendlyName, err))
+	}
+
+	return response.SyncResponse(true, &diff)
+}
+
+// forwardSchemaDiff forwards request for the OVSDB schema diff to a host that runs "central" services. Each
+// host that is registered with "central" service is queried until one of them returns non-error response.
+func forwardSchemaDiff(s state.State, r *http.Request, dbSpec *ovnCmd.OvsdbSpec) response.Response {
+	centralNodes, err := node.FindService(r.Context(), s, types.SrvCentral)
+	if err != nil {
+		logger.Errorf("Failed to find central node: %s", err)
+		return response.ErrorResponse(500, "internal server error")
+	}
+
+	clusterClients, err := s.Cluster(false)
+	if err != nil {
+		logger.Errorf("Failed to get cluster clients: %v", err)
+		return response.ErrorResponse(500, "internal server error")
+	}
+
+	for _, _client := range clusterClients {
+		for _, _node := range centralNodes {
+			clientURL := _client.URL()
+			clientAddr := fmt.Sprintf("%s:%s", clientURL.Hostname(), clientURL.Port())
+			if clientAddr != _node.Address {
+				continue
+			}
+
+			logger.Infof("Forwarding request '%s' for %s schema diff to %s", r.URL, dbSpec.FriendlyName, _node.Name)
+			diff, err := microovnClient.GetOvsdbSchemaDiff(r.Context(), &_client, dbSpec.ShortName)
+			if err != nil {
+				logger.Errorf("Failed to forward request for %s schema diff to node %s: %s", dbSpec.FriendlyName, _node.Name, err)
+				continue
+			}
+			return response.SyncResponse(true, &diff)
+		}
+	}
+
+	logger.Error("None of the central nodes responded to the forwarded query")
+	return response.ErrorResponse(500, "internal server error")
+}
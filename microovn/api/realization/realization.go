@@ -0,0 +1,56 @@
+// Package realization implements API for tracking realization of the changes in the OVN Northbound database.
+package realization
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/canonical/lxd/lxd/response"
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v2/rest"
+	"github.com/canonical/microcluster/v2/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/ovn/realization"
+)
+
+// Realization defines endpoint for /1.0/ovn/realization
+var Realization = rest.Endpoint{
+	Path: "ovn/realization",
+	Put:  rest.EndpointAction{Handler: waitForRealization, AllowUntrusted: false, ProxyTarget: false},
+}
+
+// waitForRealization implements PUT method for /1.0/ovn/realization. It accepts types.RealizationRequest,
+// increments the "nb_cfg" sequence number in the OVN Northbound database and waits until all changes made
+// so far are realized at the requested level. It responds with types.RealizationStatus, which lists lagging
+// chassis if the changes were not realized within the timeout. Failures to determine the realization status
+// are reported as errors.
+func waitForRealization(s state.State, r *http.Request) response.Response {
+	request := types.RealizationRequest{Level: types.RealizationHV, Timeout: types.DefaultRealizationTimeout}
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		return response.BadRequest(fmt.Errorf("failed to decode request: %w", err))
+	}
+
+	err = types.ValidateRealizationLevel(request.Level)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	if request.Timeout <= 0 {
+		return response.BadRequest(fmt.Errorf("timeout must be a positive number of seconds"))
+	}
+
+	status, err := realization.Wait(r.Context(), s, request.Level, time.Duration(request.Timeout)*time.Second)
+	if errors.Is(err, realization.ErrNotRealized) {
+		logger.Warnf("OVN Northbound changes were not realized: %s", err)
+	} else if err != nil {
+		logger.Errorf("Failed to wait for realization of OVN Northbound changes: %s", err)
+		return response.InternalError(fmt.Errorf("failed to wait for realization of OVN changes: %w", err))
+	}
+
+	return response.SyncResponse(true, &status)
+}
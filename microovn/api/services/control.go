@@ -15,6 +15,8 @@ import (
 
 	"github.com/canonical/microovn/microovn/api/types"
 	"github.com/canonical/microovn/microovn/node"
+	"github.com/canonical/microovn/microovn/ovn/hostport"
+	"github.com/canonical/microovn/microovn/ovn/vswitch"
 )
 
 // ServiceControlCmd - /1.0/services/service endpoint.
@@ -50,6 +52,15 @@ func enableService(s state.State, r *http.Request) response.Response {
 		return response.InternalError(err)
 	}
 
+	// Tunnel encapsulation options are otherwise applied only on join, start and config change
+	if requestedService == types.SrvChassis {
+		err = vswitch.UpdateEncapOptions(r.Context(), s)
+		if err != nil {
+			logger.Errorf("Failed to apply tunnel encapsulation options: %s", err)
+			return response.InternalError(fmt.Errorf("chassis enabled, but failed to apply tunnel encapsulation options: %w", err))
+		}
+	}
+
 	scr := types.ServiceControlResponse{}
 	scr.Warnings, err = node.ServiceWarnings(r.Context(), s)
 	if err != nil {
@@ -88,6 +99,14 @@ func disableService(s state.State, r *http.Request) response.Response {
 		return response.InternalError(err)
 	}
 
+	// Host ports can't work without chassis. Ports that fail to be removed here are removed on the next start.
+	if requestedService == types.SrvChassis {
+		err = hostport.RemoveAll(r.Context(), s)
+		if err != nil {
+			logger.Warnf("Failed to remove host ports: %v", err)
+		}
+	}
+
 	scr := types.ServiceControlResponse{}
 	scr.Warnings, err = node.ServiceWarnings(r.Context(), s)
 	if err != nil {
@@ -0,0 +1,101 @@
+// Package topology implements APIs for export and import of logical topology from the OVN Northbound database.
+package topology
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/canonical/lxd/lxd/response"
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v2/rest"
+	"github.com/canonical/microcluster/v2/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/ovn/topology"
+)
+
+// Export defines endpoint for /1.0/ovn/export
+var Export = rest.Endpoint{
+	Path: "ovn/export",
+	Post: rest.EndpointAction{Handler: exportTopology, AllowUntrusted: false, ProxyTarget: false},
+}
+
+// Import defines endpoint for /1.0/ovn/import
+var Import = rest.Endpoint{
+	Path: "ovn/import",
+	Post: rest.EndpointAction{Handler: importTopology, AllowUntrusted: false, ProxyTarget: false},
+}
+
+// Graph defines endpoint for /1.0/ovn/topology
+var Graph = rest.Endpoint{
+	Path: "ovn/topology",
+	Get:  rest.EndpointAction{Handler: getTopologyGraph, AllowUntrusted: false, ProxyTarget: false},
+}
+
+// exportTopology implements POST method for /1.0/ovn/export. It accepts types.TopologyExportRequest and responds
+// with types.TopologyDocument that contains selected logical objects from the OVN Northbound database.
+func exportTopology(s state.State, r *http.Request) response.Response {
+	var request types.TopologyExportRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		return response.BadRequest(fmt.Errorf("failed to decode export request: %w", err))
+	}
+
+	document, err := topology.Export(r.Context(), s, request)
+	if err != nil {
+		logger.Errorf("Failed to export OVN topology: %s", err)
+		return response.ErrorResponse(500, "internal server error")
+	}
+
+	return response.SyncResponse(true, document)
+}
+
+// importTopology implements POST method for /1.0/ovn/import. It accepts types.TopologyDocument, creates all of its
+// records in the OVN Northbound database and responds with types.TopologyImportResult.
+func importTopology(s state.State, r *http.Request) response.Response {
+	// Numbers are kept in their original representation, so that integers are not converted to floats
+	var document types.TopologyDocument
+	decoder := json.NewDecoder(r.Body)
+	decoder.UseNumber()
+	err := decoder.Decode(&document)
+	if err != nil {
+		return response.BadRequest(fmt.Errorf("failed to decode topology document: %w", err))
+	}
+
+	result, err := topology.Import(r.Context(), s, document)
+	if err != nil {
+		logger.Errorf("Failed to import OVN topology: %s", err)
+		return response.BadRequest(err)
+	}
+
+	return response.SyncResponse(true, result)
+}
+
+// getTopologyGraph implements GET method for /1.0/ovn/topology. It responds with types.TopologyGraph built from
+// the OVN Northbound and Southbound databases. Optional query parameters "router" and "depth" limit the graph to
+// the neighborhood of a single Logical Router.
+func getTopologyGraph(s state.State, r *http.Request) response.Response {
+	routerName := r.URL.Query().Get("router")
+	depth := topology.DefaultGraphDepth
+	if rawDepth := r.URL.Query().Get("depth"); rawDepth != "" {
+		var err error
+		depth, err = strconv.Atoi(rawDepth)
+		if err != nil || depth < 1 {
+			return response.BadRequest(fmt.Errorf("depth '%s' is not a positive number", rawDepth))
+		}
+	}
+
+	graph, err := topology.Graph(r.Context(), s, routerName, depth)
+	if errors.Is(err, topology.ErrRouterNotFound) {
+		return response.NotFound(err)
+	}
+	if err != nil {
+		logger.Errorf("Failed to build OVN topology graph: %s", err)
+		return response.ErrorResponse(500, "internal server error")
+	}
+
+	return response.SyncResponse(true, graph)
+}
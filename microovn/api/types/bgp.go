@@ -0,0 +1,59 @@
+package types
+
+// BgpRedirectInterface holds names of the system interfaces that are used on a MicroOVN cluster member
+// to redirect BGP traffic from an external interface.
+type BgpRedirectInterface struct {
+	Member   string `json:"member"`   // Name of the MicroOVN cluster member
+	Iface    string `json:"iface"`    // Name of the external interface
+	Redirect string `json:"redirect"` // Name of the interface on which the BGP daemon listens
+	Peer     string `json:"peer"`     // Name of the interface plugged into the OVN integration bridge
+}
+
+// BgpRedirectInterfaces is a list of BgpRedirectInterface records
+type BgpRedirectInterfaces []BgpRedirectInterface
+
+const (
+	// RouteValidationValid - route origin is authorized by a ROA
+	RouteValidationValid = "valid"
+	// RouteValidationInvalid - route origin contradicts a ROA covering the route prefix
+	RouteValidationInvalid = "invalid"
+	// RouteValidationUnknown - no ROA covers the route prefix
+	RouteValidationUnknown = "unknown"
+)
+
+// BgpRoute describes a route learned from a BGP peer on the external connection.
+type BgpRoute struct {
+	// Prefix is the destination network of the route
+	Prefix string `json:"prefix" yaml:"prefix"`
+	// Protocol is a name of the Bird BGP protocol (session) from which the route was learned
+	Protocol string `json:"protocol" yaml:"protocol"`
+	// OriginAS is the last AS number in the AS path of the route
+	OriginAS string `json:"originAs" yaml:"originAs"`
+	// Preferred is true if the route is the preferred route for the prefix
+	Preferred bool `json:"preferred" yaml:"preferred"`
+	// Filtered is true if the route was rejected by the import filter and is kept only for inspection
+	Filtered bool `json:"filtered" yaml:"filtered"`
+	// Validation is a RPKI origin validation state of the route. It's empty if RPKI is not configured
+	Validation string `json:"validation,omitempty" yaml:"validation,omitempty"`
+}
+
+// BgpRpkiCacheStatus describes state of the connection to the RTR cache server.
+type BgpRpkiCacheStatus struct {
+	// Name is a name of the Bird RPKI protocol that connects to the cache server
+	Name string `json:"name" yaml:"name"`
+	// State is a state of the Bird RPKI protocol (e.g. "up")
+	State string `json:"state" yaml:"state"`
+	// Info is additional information about the connection (e.g. "Established")
+	Info string `json:"info" yaml:"info"`
+}
+
+// BgpRouteStatus holds routes learned by the BGP daemon on a MicroOVN cluster member, together with their
+// RPKI origin validation state.
+type BgpRouteStatus struct {
+	// Member is a name of the MicroOVN cluster member
+	Member string `json:"member" yaml:"member"`
+	// RpkiCaches lists connections to the RTR cache servers. It's empty if RPKI is not configured
+	RpkiCaches []BgpRpkiCacheStatus `json:"rpkiCaches,omitempty" yaml:"rpkiCaches,omitempty"`
+	// Routes lists routes learned from BGP peers
+	Routes []BgpRoute `json:"routes" yaml:"routes"`
+}
@@ -1,7 +1,11 @@
 // Package types provides shared types and structs.
 package types
 
-import "fmt"
+import (
+	"fmt"
+	"sort"
+	"time"
+)
 
 // IssueCertificateResponse is a structure that models response to requests for issuance
 // of OVN certificates.
@@ -98,3 +102,85 @@ type CustomCaRequest struct {
 	Certificate string `json:"certificate"`
 	PrivateKey  string `json:"private_key"`
 }
+
+// ReissueClusterCertificatesRequest is a request to PUT /1.0/cluster/certificates
+type ReissueClusterCertificatesRequest struct {
+	Service string `json:"service" yaml:"service"` // Reissue certificate only for this service. Empty for every service.
+}
+
+// ReissueClusterCertificatesResponse is a structure that models response to requests for cluster-wide
+// reissue of OVN certificates.
+type ReissueClusterCertificatesResponse struct {
+	ReissuedCertificates map[string]IssueCertificateResponse `json:"reissuedCertificates" yaml:"reissuedCertificates"` // map of members and service certificates they issued
+	Errors               []string                            `json:"errors" yaml:"errors"`                             // Errors that prevented reissue on some members
+}
+
+// NewReissueClusterCertificatesResponse returns pointer to initialized ReissueClusterCertificatesResponse object
+func NewReissueClusterCertificatesResponse() *ReissueClusterCertificatesResponse {
+	return &ReissueClusterCertificatesResponse{
+		ReissuedCertificates: make(map[string]IssueCertificateResponse),
+		Errors:               make([]string, 0),
+	}
+}
+
+// PrettyPrint method formats and prints contents of ReissueClusterCertificatesResponse object
+func (r *ReissueClusterCertificatesResponse) PrettyPrint() {
+	members := make([]string, 0, len(r.ReissuedCertificates))
+	for member := range r.ReissuedCertificates {
+		members = append(members, member)
+	}
+	sort.Strings(members)
+
+	fmt.Print("Service certificate re-issued for following services:")
+
+	anyFailure := false
+	for _, member := range members {
+		certificates := r.ReissuedCertificates[member]
+		fmt.Printf("\n[Member %s]\n", member)
+		if len(certificates.Success) == 0 && len(certificates.Failed) == 0 {
+			fmt.Println("None")
+		}
+		for _, service := range certificates.Success {
+			fmt.Printf("%s: Success\n", service)
+		}
+		for _, service := range certificates.Failed {
+			fmt.Printf("%s: Failed!\n", service)
+			anyFailure = true
+		}
+	}
+
+	if len(r.Errors) != 0 {
+		anyFailure = true
+		fmt.Println("\n[Errors]")
+		for _, errMsg := range r.Errors {
+			fmt.Println(errMsg)
+		}
+	}
+
+	if anyFailure {
+		fmt.Println(
+			"\n Some of the service certificates failed to be re-issued. You can inspect logs and " +
+				"attempt to re-issue these certificates using microovn CLI on affected cluster members.",
+		)
+	}
+}
+
+// TrustedCa describes additional CA certificate that is trusted to issue client certificates for the OVN
+// Northbound and Southbound databases.
+type TrustedCa struct {
+	Fingerprint string    `json:"fingerprint" yaml:"fingerprint"` // SHA-256 fingerprint of the CA certificate
+	Subject     string    `json:"subject" yaml:"subject"`         // Subject of the CA certificate
+	NotAfter    time.Time `json:"notAfter" yaml:"notAfter"`       // Expiration time of the CA certificate
+}
+
+// TrustedCaRequest is a request to POST /1.0/ca/trust
+type TrustedCaRequest struct {
+	Certificate string `json:"certificate" yaml:"certificate"` // One or more PEM encoded CA certificates
+}
+
+// TrustedCaResponse is a structure that models response to requests that change the bundle of trusted
+// client CA certificates.
+type TrustedCaResponse struct {
+	Fingerprints []string `json:"fingerprints" yaml:"fingerprints"` // Fingerprints of added or removed CA certificates
+	Errors       []string `json:"errors" yaml:"errors"`             // Errors that prevented update of the bundle on some members
+}
@@ -0,0 +1,84 @@
+package types
+
+import (
+	"time"
+)
+
+// MemberFactsList is a list of facts published by every cluster member.
+type MemberFactsList []MemberFacts
+
+// MemberFacts holds information about OVN services running on a single cluster member. Every member
+// periodically collects these facts and publishes them into the shared database.
+type MemberFacts struct {
+	// Member is a name of the cluster member that published the facts
+	Member string `json:"member" yaml:"member"`
+	// Updated is a time when the facts were published
+	Updated time.Time `json:"updated" yaml:"updated"`
+	// MicroOvnVersion is a version of MicroOVN running on the member
+	MicroOvnVersion string `json:"microovnVersion" yaml:"microovnVersion"`
+	// OvnVersion is a version of OVN running on the member
+	OvnVersion string `json:"ovnVersion" yaml:"ovnVersion"`
+	// OvsVersion is a version of Open vSwitch running on the member
+	OvsVersion string `json:"ovsVersion" yaml:"ovsVersion"`
+	// Services maps names of the snap services to their runtime state ("active" or "inactive")
+	Services map[string]string `json:"services,omitempty" yaml:"services,omitempty"`
+	// EncapIP is an IP address used by the chassis for tunnel encapsulation
+	EncapIP string `json:"encapIp,omitempty" yaml:"encapIp,omitempty"`
+	// EncapOptions maps names of the tunnel encapsulation options set on the chassis to their values
+	EncapOptions map[string]string `json:"encapOptions,omitempty" yaml:"encapOptions,omitempty"`
+	// RaftRoles maps names of the OVN central databases ("nb", "sb") to the member's RAFT role
+	RaftRoles map[string]string `json:"raftRoles,omitempty" yaml:"raftRoles,omitempty"`
+	// ExpectedSchemas maps names of the OVN central databases ("nb", "sb") to the schema versions shipped
+	// with OVN packages on the member
+	ExpectedSchemas map[string]string `json:"expectedSchemas,omitempty" yaml:"expectedSchemas,omitempty"`
+	// ActiveSchemas maps names of the OVN central databases ("nb", "sb") to the schema versions currently
+	// used by the databases. It's reported only by members with the "central" service
+	ActiveSchemas map[string]string `json:"activeSchemas,omitempty" yaml:"activeSchemas,omitempty"`
+	// VirtualIPs maps names of the OVN central databases ("nb", "sb") to the virtual IPs held by the member
+	VirtualIPs map[string]string `json:"virtualIps,omitempty" yaml:"virtualIps,omitempty"`
+	// ReadOnlyListeners maps names of the OVN central databases ("nb", "sb") to the targets of their
+	// read-only listeners
+	ReadOnlyListeners map[string][]string `json:"readOnlyListeners,omitempty" yaml:"readOnlyListeners,omitempty"`
+	// CertificateExpiry maps names of the services to expiration time of their certificates
+	CertificateExpiry map[string]time.Time `json:"certificateExpiry,omitempty" yaml:"certificateExpiry,omitempty"`
+	// BgpSessions summarizes state of BGP sessions on the member
+	BgpSessions *BgpSessionSummary `json:"bgpSessions,omitempty" yaml:"bgpSessions,omitempty"`
+	// DeferredJobs lists disruptive jobs that are waiting for a maintenance window
+	DeferredJobs []DeferredJob `json:"deferredJobs,omitempty" yaml:"deferredJobs,omitempty"`
+}
+
+// BgpSessionSummary holds number of BGP sessions configured on a member, number of sessions
+// that are established and number of sessions that hit one of their prefix limits.
+type BgpSessionSummary struct {
+	Total       int `json:"total" yaml:"total"`
+	Established int `json:"established" yaml:"established"`
+	LimitHit    int `json:"limitHit" yaml:"limitHit"`
+}
+
+// DeferredJob describes a disruptive job that is waiting for a maintenance window.
+type DeferredJob struct {
+	// Job is a name of the deferred job
+	Job string `json:"job" yaml:"job"`
+	// Since is a time when the job was deferred for the first time
+	Since time.Time `json:"since" yaml:"since"`
+	// NextWindow is a start of the next maintenance window
+	NextWindow time.Time `json:"nextWindow" yaml:"nextWindow"`
+	// Deadline is a time after which the job can't be deferred any further (zero if the job never becomes urgent)
+	Deadline time.Time `json:"deadline" yaml:"deadline"`
+}
+
+// MaintenanceCheck is a request to check whether a disruptive job is allowed to run now.
+type MaintenanceCheck struct {
+	// Job is a name of the disruptive job
+	Job string `json:"job" yaml:"job"`
+	// Deadline is a time after which the job can't be deferred any further (zero if the job never becomes urgent)
+	Deadline time.Time `json:"deadline" yaml:"deadline"`
+}
+
+// MaintenanceCheckResult is a response to the MaintenanceCheck request.
+type MaintenanceCheckResult struct {
+	// Allowed is true if the job is allowed to run now
+	Allowed bool `json:"allowed" yaml:"allowed"`
+	// NextWindow is a start of the next maintenance window if the job is not allowed to run
+	NextWindow time.Time `json:"nextWindow" yaml:"nextWindow"`
+}
@@ -25,3 +25,67 @@ type OvsdbSchemaVersionResult struct {
 	SchemaVersion string                `json:"schemaVersion"`
 	Error         OvsdbSchemaFetchError `json:"error"`
 }
+
+// SbConnectionsReport is a collection of SbConnectionsResult structs, one for each OVN Southbound
+// database server in the cluster.
+type SbConnectionsReport = []SbConnectionsResult
+
+// SbConnectionsResult represents number of client connections established with the OVN Southbound
+// database server running on a single MicroOVN cluster member.
+type SbConnectionsResult struct {
+	Member      string `json:"member" yaml:"member"`
+	Connections int    `json:"connections" yaml:"connections"`
+	Error       string `json:"error" yaml:"error"`
+}
+
+// Kinds of changes between two OVSDB schemas.
+const (
+	SchemaChangeAdded   = "added"
+	SchemaChangeRemoved = "removed"
+	SchemaChangeChanged = "changed"
+)
+
+// OvsdbSchemaDiff describes differences between the schema of a running OVSDB database and the schema
+// shipped with the OVN/OVS packages, that would be applied by the schema conversion.
+type OvsdbSchemaDiff struct {
+	Database       string              `json:"database" yaml:"database"`
+	RunningVersion string              `json:"runningVersion" yaml:"runningVersion"`
+	TargetVersion  string              `json:"targetVersion" yaml:"targetVersion"`
+	Changes        []OvsdbSchemaChange `json:"changes" yaml:"changes"`
+}
+
+// HasBreakingChanges returns true if any of the schema changes can break existing clients of the database.
+func (d *OvsdbSchemaDiff) HasBreakingChanges() bool {
+	for _, change := range d.Changes {
+		if change.Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+// OvsdbSchemaChange is a single change of a table, column or table constraint between two OVSDB schemas.
+// Column is empty for changes that apply to the whole table.
+type OvsdbSchemaChange struct {
+	Kind     string `json:"kind" yaml:"kind"`
+	Table    string `json:"table" yaml:"table"`
+	Column   string `json:"column,omitempty" yaml:"column,omitempty"`
+	Detail   string `json:"detail,omitempty" yaml:"detail,omitempty"`
+	Breaking bool   `json:"breaking" yaml:"breaking"`
+}
+
+// OvsdbCheckReport is a collection of OvsdbCheckResult structs, one for each OVN central database
+// server in the cluster.
+type OvsdbCheckReport = []OvsdbCheckResult
+
+// OvsdbCheckResult holds result of the integrity check of the OVN Northbound or Southbound database
+// files and of the RAFT cluster membership of the database server running on a single member.
+type OvsdbCheckResult struct {
+	Member   string   `json:"member" yaml:"member"`
+	Database string   `json:"database" yaml:"database"`
+	Mode     string   `json:"mode" yaml:"mode"`
+	Status   string   `json:"status" yaml:"status"`
+	Role     string   `json:"role" yaml:"role"`
+	Healthy  bool     `json:"healthy" yaml:"healthy"`
+	Problems []string `json:"problems" yaml:"problems"`
+}
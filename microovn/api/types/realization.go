@@ -0,0 +1,61 @@
+package types
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Levels of configuration realization that can be awaited.
+const (
+	// RealizationSB - changes were translated by ovn-northd into the OVN Southbound database.
+	RealizationSB = "sb"
+	// RealizationHV - changes were applied by ovn-controller on every chassis.
+	RealizationHV = "hv"
+)
+
+// RealizationLevels is a list of all valid realization levels.
+var RealizationLevels = []string{RealizationSB, RealizationHV}
+
+// DefaultRealizationTimeout is a default number of seconds to wait for the realization of changes.
+const DefaultRealizationTimeout = 60
+
+// ValidateRealizationLevel returns error if "level" is not a valid realization level.
+func ValidateRealizationLevel(level string) error {
+	if !slices.Contains(RealizationLevels, level) {
+		return fmt.Errorf("unknown realization level '%s'. Supported values are: %s", level, strings.Join(RealizationLevels, ", "))
+	}
+	return nil
+}
+
+// RealizationRequest is a request to PUT /1.0/ovn/realization. It bumps the OVN Northbound
+// configuration sequence number and waits until the changes are realized at the requested level.
+type RealizationRequest struct {
+	// Level of realization to wait for ("sb" or "hv")
+	Level string `json:"level" yaml:"level"`
+	// Timeout in seconds
+	Timeout int `json:"timeout" yaml:"timeout"`
+}
+
+// RealizationStatus describes how far were the OVN Northbound changes, up to the configuration sequence
+// number "Target", realized.
+type RealizationStatus struct {
+	// Target is the configuration sequence number ("nb_cfg") that is awaited
+	Target int `json:"target" yaml:"target"`
+	// SbCfg is the sequence number of changes translated into the OVN Southbound database
+	SbCfg int `json:"sbCfg" yaml:"sbCfg"`
+	// HvCfg is the sequence number of changes applied on every chassis
+	HvCfg int `json:"hvCfg" yaml:"hvCfg"`
+	// Realized is true if the changes were realized at the requested level
+	Realized bool `json:"realized" yaml:"realized"`
+	// Lagging lists chassis that did not apply the changes yet
+	Lagging []ChassisRealization `json:"lagging" yaml:"lagging"`
+}
+
+// ChassisRealization holds sequence number of the OVN Northbound changes applied by a chassis.
+type ChassisRealization struct {
+	// Chassis is the name of the chassis
+	Chassis string `json:"chassis" yaml:"chassis"`
+	// NbCfg is the sequence number of changes applied by the chassis
+	NbCfg int `json:"nbCfg" yaml:"nbCfg"`
+}
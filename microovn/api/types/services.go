@@ -4,6 +4,10 @@ package types
 import (
 	"fmt"
 	"log"
+	"math"
+	"net"
+	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 )
@@ -129,31 +133,177 @@ type ExtraBgpConfig struct {
 	Vrf string `json:"vrf,omitempty" yaml:"vrf,omitempty"`
 	// Asn is an Autonomous System Number that will be used to set up BGP daemon
 	Asn string `json:"asn,omitempty" yaml:"asn,omitempty"`
+	// RpkiCaches is comma separated list of <host>[:<port>] values. Each value is an address of the RTR
+	// cache server that provides RPKI data for origin validation of the routes learned by the BGP daemon
+	RpkiCaches string `json:"rpki_caches,omitempty" yaml:"rpki_caches,omitempty"`
+	// RpkiPolicy determines what happens with routes that fail RPKI origin validation. Valid values are
+	// "reject", "deprioritize" and "accept". Defaults to "reject"
+	RpkiPolicy string `json:"rpki_policy,omitempty" yaml:"rpki_policy,omitempty"`
+	// ReceiveLimit is a maximum number of prefixes that each BGP session can receive from its peer, per
+	// address family
+	ReceiveLimit string `json:"receive_limit,omitempty" yaml:"receive_limit,omitempty"`
+	// ExportLimit is a maximum number of prefixes that each BGP session can export to its peer, per
+	// address family
+	ExportLimit string `json:"export_limit,omitempty" yaml:"export_limit,omitempty"`
+	// LimitAction determines what happens when a prefix limit is hit. Valid values are "warn", "block" and
+	// "restart". Defaults to "block"
+	LimitAction string `json:"limit_action,omitempty" yaml:"limit_action,omitempty"`
+	// LimitRestartDelay is a number of seconds after which the BGP session is restarted when the "restart"
+	// limit action is used
+	LimitRestartDelay string `json:"limit_restart_delay,omitempty" yaml:"limit_restart_delay,omitempty"`
+	// TTLSecurity enables Generalized TTL Security Mechanism (RFC 5082) on BGP sessions. Valid values are
+	// "true" and "false"
+	TTLSecurity string `json:"ttl_security,omitempty" yaml:"ttl_security,omitempty"`
+	// HoldTime is a BGP hold time in seconds
+	HoldTime string `json:"hold_time,omitempty" yaml:"hold_time,omitempty"`
+	// KeepaliveTime is a BGP keepalive time in seconds
+	KeepaliveTime string `json:"keepalive_time,omitempty" yaml:"keepalive_time,omitempty"`
+	// ConnectionOverrides holds settings of individual external connections that override the options
+	// above. Keys are in the "<iface_name>.<option>" format, where "option" is one of ConnectionOverrideOptions
+	ConnectionOverrides map[string]string `json:"connection_overrides,omitempty" yaml:"connection_overrides,omitempty"`
 }
 
-// BgpExternalConnection represents a parsed structure from ExtraBgpConfig.ExternalConnection string.
+const (
+	// OverrideLocalAsn - per-connection override of the ExtraBgpConfig.Asn option
+	OverrideLocalAsn = "local_asn"
+	// OverrideDescription - description of the BGP sessions on the external connection
+	OverrideDescription = "description"
+	// OverrideReceiveLimit - per-connection override of the ExtraBgpConfig.ReceiveLimit option
+	OverrideReceiveLimit = "receive_limit"
+	// OverrideExportLimit - per-connection override of the ExtraBgpConfig.ExportLimit option
+	OverrideExportLimit = "export_limit"
+	// OverrideLimitAction - per-connection override of the ExtraBgpConfig.LimitAction option
+	OverrideLimitAction = "limit_action"
+	// OverrideLimitRestartDelay - per-connection override of the ExtraBgpConfig.LimitRestartDelay option
+	OverrideLimitRestartDelay = "limit_restart_delay"
+	// OverrideTTLSecurity - per-connection override of the ExtraBgpConfig.TTLSecurity option
+	OverrideTTLSecurity = "ttl_security"
+	// OverrideHoldTime - per-connection override of the ExtraBgpConfig.HoldTime option
+	OverrideHoldTime = "hold_time"
+	// OverrideKeepaliveTime - per-connection override of the ExtraBgpConfig.KeepaliveTime option
+	OverrideKeepaliveTime = "keepalive_time"
+	// OverridePasswordFile - path to a file with the password that authenticates BGP sessions on the
+	// external connection
+	OverridePasswordFile = "password_file"
+)
+
+// ConnectionOverrideOptions - list of all options that can be set for individual external connections
+var ConnectionOverrideOptions = []string{
+	OverrideLocalAsn, OverrideDescription, OverrideReceiveLimit, OverrideExportLimit, OverrideLimitAction,
+	OverrideLimitRestartDelay, OverrideTTLSecurity, OverrideHoldTime, OverrideKeepaliveTime, OverridePasswordFile,
+}
+
+const (
+	// LimitActionWarn - only a warning is logged when a prefix limit is hit
+	LimitActionWarn = "warn"
+	// LimitActionBlock - prefixes over the limit are ignored
+	LimitActionBlock = "block"
+	// LimitActionRestart - BGP session is restarted when a prefix limit is hit
+	LimitActionRestart = "restart"
+)
+
+// LimitActions - list of all valid values of the ExtraBgpConfig.LimitAction option
+var LimitActions = []string{LimitActionWarn, LimitActionBlock, LimitActionRestart}
+
+// BgpSessionOptions represents parsed safeguards and timers of BGP sessions on an external connection. Zero
+// values mean that the option is not set and the BGP daemon's default is used.
+type BgpSessionOptions struct {
+	// ReceiveLimit is a maximum number of prefixes received from the peer, per address family
+	ReceiveLimit int
+	// ExportLimit is a maximum number of prefixes exported to the peer, per address family
+	ExportLimit int
+	// LimitAction is an action taken when a prefix limit is hit
+	LimitAction string
+	// LimitRestartDelay is a number of seconds after which the session is restarted when a prefix limit is hit
+	LimitRestartDelay int
+	// TTLSecurity enables Generalized TTL Security Mechanism
+	TTLSecurity bool
+	// HoldTime is a BGP hold time in seconds
+	HoldTime int
+	// KeepaliveTime is a BGP keepalive time in seconds
+	KeepaliveTime int
+}
+
+const (
+	// RpkiPolicyReject - routes that fail RPKI origin validation are rejected
+	RpkiPolicyReject = "reject"
+	// RpkiPolicyDeprioritize - routes that fail RPKI origin validation are used only if there's no other route
+	RpkiPolicyDeprioritize = "deprioritize"
+	// RpkiPolicyAccept - routes that fail RPKI origin validation are accepted, their state is only reported
+	RpkiPolicyAccept = "accept"
+)
+
+// RpkiPolicies - list of all valid values of the ExtraBgpConfig.RpkiPolicy option
+var RpkiPolicies = []string{RpkiPolicyReject, RpkiPolicyDeprioritize, RpkiPolicyAccept}
+
+// DefaultRpkiPort - default TCP port of the RTR cache servers
+const DefaultRpkiPort = 323
+
+// BgpRpkiCache represents a parsed structure from ExtraBgpConfig.RpkiCaches string.
+type BgpRpkiCache struct {
+	// Host is a hostname or an IP address of the RTR cache server
+	Host string
+	// Port is a TCP port of the RTR cache server
+	Port int
+}
+
+// BgpExternalConnection represents a parsed structure from ExtraBgpConfig.ExternalConnection string,
+// together with the settings from ExtraBgpConfig.ConnectionOverrides. Zero values of LocalAsn and
+// Description mean that the defaults from ExtraBgpConfig are used. Session options are already resolved
+// from the ExtraBgpConfig defaults and the per-connection overrides.
 type BgpExternalConnection struct {
 	// Iface is a name of the physical interface that provides external connectivity
 	Iface string
+	// LocalAsn is an Autonomous System Number used by BGP sessions on this connection
+	LocalAsn string
+	// Description is a description of BGP sessions on this connection
+	Description string
+	// PasswordFile is a path to a file with the password that authenticates BGP sessions on this connection
+	PasswordFile string
+	// BgpSessionOptions are safeguards and timers of BGP sessions on this connection
+	BgpSessionOptions
 }
 
 // FromMap initializes ExtraBgpConfig structure from the provided map of string keys and string values.
 // This functions also validates the resulting structure and returns error if the validation fails.
 func (bgpConf *ExtraBgpConfig) FromMap(rawConfig map[string]string) error {
 	for key, value := range rawConfig {
-		if key == "ext_connection" {
+		switch key {
+		case "ext_connection":
 			bgpConf.ExternalConnection = value
-			continue
-		}
-		if key == "vrf" {
+		case "vrf":
 			bgpConf.Vrf = value
-			continue
-		}
-		if key == "asn" {
+		case "asn":
 			bgpConf.Asn = value
-			continue
+		case "rpki_caches":
+			bgpConf.RpkiCaches = value
+		case "rpki_policy":
+			bgpConf.RpkiPolicy = value
+		case "receive_limit":
+			bgpConf.ReceiveLimit = value
+		case "export_limit":
+			bgpConf.ExportLimit = value
+		case "limit_action":
+			bgpConf.LimitAction = value
+		case "limit_restart_delay":
+			bgpConf.LimitRestartDelay = value
+		case "ttl_security":
+			bgpConf.TTLSecurity = value
+		case "hold_time":
+			bgpConf.HoldTime = value
+		case "keepalive_time":
+			bgpConf.KeepaliveTime = value
+		default:
+			_, option, found := strings.Cut(key, ".")
+			if !found || !slices.Contains(ConnectionOverrideOptions, option) {
+				return fmt.Errorf("unknown BGP config option: %s", key)
+			}
+
+			if bgpConf.ConnectionOverrides == nil {
+				bgpConf.ConnectionOverrides = make(map[string]string)
+			}
+			bgpConf.ConnectionOverrides[key] = value
 		}
-		return fmt.Errorf("unknown BGP config option: %s", key)
 	}
 	return bgpConf.Validate()
 }
@@ -175,6 +325,11 @@ func (bgpConf *ExtraBgpConfig) Validate() error {
 		return fmt.Errorf("option 'asn' is not a number: %s", bgpConf.Asn)
 	}
 
+	_, err = bgpConf.ParseSessionOptions()
+	if err != nil {
+		return err
+	}
+
 	extConnections, err := bgpConf.ParseExternalConnection()
 	if err != nil {
 		return fmt.Errorf("failed to parse connection string option: %s", err)
@@ -184,22 +339,272 @@ func (bgpConf *ExtraBgpConfig) Validate() error {
 		return fmt.Errorf("external connections have to be set")
 	}
 
+	if bgpConf.RpkiCaches != "" && bgpConf.Asn == "" {
+		return fmt.Errorf("option 'rpki_caches' requires option 'asn' to be set")
+	}
+
+	_, err = bgpConf.ParseRpkiCaches()
+	if err != nil {
+		return fmt.Errorf("failed to parse RPKI caches option: %s", err)
+	}
+
+	_, err = bgpConf.ParseRpkiPolicy()
+	if err != nil {
+		return err
+	}
+
+	sessionOptions := []string{
+		bgpConf.ReceiveLimit, bgpConf.ExportLimit, bgpConf.LimitAction, bgpConf.LimitRestartDelay,
+		bgpConf.TTLSecurity, bgpConf.HoldTime, bgpConf.KeepaliveTime,
+	}
+	if bgpConf.Asn == "" && slices.ContainsFunc(sessionOptions, func(value string) bool { return value != "" }) {
+		return fmt.Errorf("BGP session options require option 'asn' to be set")
+	}
+
+	if bgpConf.Asn == "" && len(bgpConf.ConnectionOverrides) > 0 {
+		return fmt.Errorf("external connection overrides require option 'asn' to be set")
+	}
+
 	return nil
 }
 
 // ParseExternalConnection parses ExtraBgpConfig.ExternalConnection string into list of BgpExternalConnection
-// instances.
+// instances. Session options of each connection default to the ones parsed by ParseSessionOptions, and
+// ExtraBgpConfig.ConnectionOverrides are applied on top of them.
 func (bgpConf *ExtraBgpConfig) ParseExternalConnection() ([]BgpExternalConnection, error) {
+	defaults, err := bgpConf.ParseSessionOptions()
+	if err != nil {
+		return nil, err
+	}
+
 	parsedConnections := make([]BgpExternalConnection, 0)
 	for _, extConn := range strings.Split(bgpConf.ExternalConnection, ",") {
 		parsedConnections = append(parsedConnections, BgpExternalConnection{
-			Iface: extConn,
+			Iface:             extConn,
+			BgpSessionOptions: defaults,
 		})
 	}
 
+	// Apply the overrides in a stable order, so that the first invalid option is always reported
+	overrideKeys := make([]string, 0, len(bgpConf.ConnectionOverrides))
+	for key := range bgpConf.ConnectionOverrides {
+		overrideKeys = append(overrideKeys, key)
+	}
+	slices.Sort(overrideKeys)
+
+	for _, key := range overrideKeys {
+		value := bgpConf.ConnectionOverrides[key]
+		iface, option, _ := strings.Cut(key, ".")
+		index := slices.IndexFunc(parsedConnections, func(conn BgpExternalConnection) bool { return conn.Iface == iface })
+		if index < 0 {
+			return nil, fmt.Errorf("option '%s' refers to interface '%s' that is not an external connection", key, iface)
+		}
+
+		err := parsedConnections[index].applyOverride(key, option, value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = bgpConf.validateSessionOptions(parsedConnections)
+	if err != nil {
+		return nil, err
+	}
+
 	return parsedConnections, nil
 }
 
+// validateSessionOptions checks that the resolved session options of the external connections are consistent
+// and that the limit options are not set where they would have no effect.
+func (bgpConf *ExtraBgpConfig) validateSessionOptions(connections []BgpExternalConnection) error {
+	hasLimit := func(conn BgpExternalConnection) bool { return conn.ReceiveLimit != 0 || conn.ExportLimit != 0 }
+	restarts := func(conn BgpExternalConnection) bool { return conn.LimitAction == LimitActionRestart }
+
+	if bgpConf.LimitAction != "" && !slices.ContainsFunc(connections, hasLimit) {
+		return fmt.Errorf("option 'limit_action' requires option 'receive_limit' or 'export_limit' to be set")
+	}
+
+	if bgpConf.LimitRestartDelay != "" && !slices.ContainsFunc(connections, restarts) {
+		return fmt.Errorf("option 'limit_restart_delay' requires option 'limit_action' to be '%s'", LimitActionRestart)
+	}
+
+	for _, conn := range connections {
+		_, found := bgpConf.ConnectionOverrides[conn.Iface+"."+OverrideLimitAction]
+		if found && !hasLimit(conn) {
+			return fmt.Errorf("option '%s.%s' requires a prefix limit on the same interface", conn.Iface, OverrideLimitAction)
+		}
+
+		_, found = bgpConf.ConnectionOverrides[conn.Iface+"."+OverrideLimitRestartDelay]
+		if found && !restarts(conn) {
+			return fmt.Errorf("option '%s.%s' requires limit action '%s' on the same interface", conn.Iface, OverrideLimitRestartDelay, LimitActionRestart)
+		}
+
+		if conn.KeepaliveTime != 0 && conn.HoldTime != 0 && conn.KeepaliveTime >= conn.HoldTime {
+			return fmt.Errorf("keepalive time of the BGP sessions on interface '%s' must be lower than their hold time", conn.Iface)
+		}
+	}
+
+	return nil
+}
+
+// applyOverride validates value of the per-connection option "key" and sets it on the external connection.
+func (conn *BgpExternalConnection) applyOverride(key string, option string, value string) error {
+	switch option {
+	case OverrideLocalAsn:
+		_, err := strconv.ParseUint(value, 10, 32)
+		if err != nil || value == "0" {
+			return fmt.Errorf("option '%s' is not a valid AS number: %s", key, value)
+		}
+		conn.LocalAsn = value
+	case OverrideDescription:
+		if value == "" || strings.ContainsAny(value, "\"\\\n") {
+			return fmt.Errorf("option '%s' must be a non-empty string without quotes, backslashes and newlines", key)
+		}
+		conn.Description = value
+	case OverridePasswordFile:
+		if !filepath.IsAbs(value) {
+			return fmt.Errorf("option '%s' must be an absolute path: %s", key, value)
+		}
+		conn.PasswordFile = value
+	default:
+		if value == "" {
+			return fmt.Errorf("option '%s' must not be empty", key)
+		}
+		return conn.BgpSessionOptions.set(key, option, value)
+	}
+	return nil
+}
+
+// ParseRpkiCaches parses ExtraBgpConfig.RpkiCaches string into list of BgpRpkiCache instances. Port of the
+// cache server is optional and defaults to DefaultRpkiPort. IPv6 addresses with port need to be enclosed in
+// square brackets (e.g. "[2001:db8::1]:3323").
+func (bgpConf *ExtraBgpConfig) ParseRpkiCaches() ([]BgpRpkiCache, error) {
+	parsedCaches := make([]BgpRpkiCache, 0)
+	if bgpConf.RpkiCaches == "" {
+		return parsedCaches, nil
+	}
+
+	for _, rawCache := range strings.Split(bgpConf.RpkiCaches, ",") {
+		cache := BgpRpkiCache{Host: rawCache, Port: DefaultRpkiPort}
+
+		host, rawPort, err := net.SplitHostPort(rawCache)
+		if err == nil {
+			cache.Host = host
+			cache.Port, err = strconv.Atoi(rawPort)
+			if err != nil || cache.Port < 1 || cache.Port > 65535 {
+				return nil, fmt.Errorf("cache '%s' has invalid port '%s'", rawCache, rawPort)
+			}
+		} else if strings.HasPrefix(rawCache, "[") && strings.HasSuffix(rawCache, "]") {
+			cache.Host = strings.Trim(rawCache, "[]")
+		}
+
+		if cache.Host == "" || strings.ContainsAny(cache.Host, "\"[] \t") {
+			return nil, fmt.Errorf("cache '%s' is not a valid <host>[:<port>] value", rawCache)
+		}
+
+		// IPv6 addresses without port are accepted without brackets as well
+		if strings.Contains(cache.Host, ":") && net.ParseIP(cache.Host) == nil {
+			return nil, fmt.Errorf("cache '%s' is not a valid <host>[:<port>] value", rawCache)
+		}
+
+		parsedCaches = append(parsedCaches, cache)
+	}
+
+	return parsedCaches, nil
+}
+
+// ParseRpkiPolicy returns the value of ExtraBgpConfig.RpkiPolicy, or RpkiPolicyReject if the option is not set.
+func (bgpConf *ExtraBgpConfig) ParseRpkiPolicy() (string, error) {
+	if bgpConf.RpkiPolicy == "" {
+		return RpkiPolicyReject, nil
+	}
+
+	for _, policy := range RpkiPolicies {
+		if bgpConf.RpkiPolicy == policy {
+			return policy, nil
+		}
+	}
+
+	return "", fmt.Errorf("option 'rpki_policy' must be one of: %s", strings.Join(RpkiPolicies, ", "))
+}
+
+// parseIntOption parses value of the numeric BGP config option "key" and ensures that it's within the range
+// from "minValue" to "maxValue". Zero is returned if the option is not set.
+func parseIntOption(key string, value string, minValue int, maxValue int) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil || number < minValue || number > maxValue {
+		return 0, fmt.Errorf("option '%s' must be a number between %d and %d: %s", key, minValue, maxValue, value)
+	}
+	return number, nil
+}
+
+// ParseSessionOptions parses and validates prefix limits, TTL security and timers of BGP sessions. These are
+// the defaults of all external connections.
+func (bgpConf *ExtraBgpConfig) ParseSessionOptions() (BgpSessionOptions, error) {
+	options := BgpSessionOptions{LimitAction: LimitActionBlock}
+	rawOptions := []struct {
+		option string
+		value  string
+	}{
+		{OverrideReceiveLimit, bgpConf.ReceiveLimit},
+		{OverrideExportLimit, bgpConf.ExportLimit},
+		{OverrideLimitAction, bgpConf.LimitAction},
+		{OverrideLimitRestartDelay, bgpConf.LimitRestartDelay},
+		{OverrideTTLSecurity, bgpConf.TTLSecurity},
+		{OverrideHoldTime, bgpConf.HoldTime},
+		{OverrideKeepaliveTime, bgpConf.KeepaliveTime},
+	}
+
+	for _, raw := range rawOptions {
+		if raw.value == "" {
+			continue
+		}
+
+		err := options.set(raw.option, raw.option, raw.value)
+		if err != nil {
+			return options, err
+		}
+	}
+
+	return options, nil
+}
+
+// set validates value of the session option "option", configured by the BGP config option "key", and sets it.
+func (options *BgpSessionOptions) set(key string, option string, value string) error {
+	var err error
+	switch option {
+	case OverrideReceiveLimit:
+		options.ReceiveLimit, err = parseIntOption(key, value, 1, math.MaxInt32)
+	case OverrideExportLimit:
+		options.ExportLimit, err = parseIntOption(key, value, 1, math.MaxInt32)
+	case OverrideLimitAction:
+		if !slices.Contains(LimitActions, value) {
+			return fmt.Errorf("option '%s' must be one of: %s", key, strings.Join(LimitActions, ", "))
+		}
+		options.LimitAction = value
+	case OverrideLimitRestartDelay:
+		options.LimitRestartDelay, err = parseIntOption(key, value, 1, 86400)
+	case OverrideTTLSecurity:
+		options.TTLSecurity, err = strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("option '%s' is not a boolean: %s", key, value)
+		}
+	case OverrideHoldTime:
+		// RFC 4271 requires hold time to be either zero or at least three seconds. Zero (no keepalives) is
+		// not allowed, as it would prevent detection of dead peers.
+		options.HoldTime, err = parseIntOption(key, value, 3, 65535)
+	case OverrideKeepaliveTime:
+		options.KeepaliveTime, err = parseIntOption(key, value, 1, 65535)
+	default:
+		return fmt.Errorf("unknown BGP config option: %s", key)
+	}
+	return err
+}
+
 // CheckValidService - checks whether the string in "service" is in fact a
 // known and valid service name.
 func CheckValidService(service string) bool {
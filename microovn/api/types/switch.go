@@ -0,0 +1,81 @@
+package types
+
+// SwitchInventory describes configuration of the Open vSwitch running on a single cluster member.
+type SwitchInventory struct {
+	Member         string            `json:"member"`         // Name of the MicroOVN cluster member
+	Bridges        []SwitchBridge    `json:"bridges"`        // List of OVS bridges
+	BridgeMappings map[string]string `json:"bridgeMappings"` // Mapping of OVN physical network names to OVS bridges
+	ExternalIDs    map[string]string `json:"externalIds"`    // External IDs of the Open_vSwitch table
+}
+
+// SwitchBridge describes a single OVS bridge.
+type SwitchBridge struct {
+	Name        string            `json:"name"`        // Name of the bridge
+	Managed     bool              `json:"managed"`     // True if the bridge is managed by MicroOVN
+	ExternalIDs map[string]string `json:"externalIds"` // External IDs of the bridge
+	Ports       []SwitchPort      `json:"ports"`       // List of ports in the bridge
+}
+
+// SwitchPort describes a single OVS port.
+type SwitchPort struct {
+	Name        string            `json:"name"`        // Name of the port
+	Managed     bool              `json:"managed"`     // True if the port is managed by MicroOVN
+	ExternalIDs map[string]string `json:"externalIds"` // External IDs of the port
+	Interfaces  []SwitchInterface `json:"interfaces"`  // List of interfaces in the port
+}
+
+// SwitchInterface describes a single OVS interface.
+type SwitchInterface struct {
+	Name        string            `json:"name"`        // Name of the interface
+	Type        string            `json:"type"`        // Type of the interface (empty for system interfaces)
+	OfPort      int               `json:"ofport"`      // OpenFlow port number (-1 if the interface couldn't be created)
+	MTU         int               `json:"mtu"`         // MTU of the interface
+	AdminState  string            `json:"adminState"`  // Administrative state of the interface
+	LinkState   string            `json:"linkState"`   // Link state of the interface
+	IfaceID     string            `json:"ifaceId"`     // Name of the OVN Logical Switch Port bound to the interface
+	Managed     bool              `json:"managed"`     // True if the interface is managed by MicroOVN
+	ExternalIDs map[string]string `json:"externalIds"` // External IDs of the interface
+}
+
+// DatapathStats holds datapath flow and conntrack statistics of the Open vSwitch running on a single
+// cluster member.
+type DatapathStats struct {
+	Member                string          `json:"member"`                // Name of the MicroOVN cluster member
+	Datapaths             []DatapathStat  `json:"datapaths"`             // Flow statistics of each datapath
+	ConntrackDefaultLimit int             `json:"conntrackDefaultLimit"` // Default conntrack limit per zone (0 means unlimited)
+	ConntrackZones        []ConntrackZone `json:"conntrackZones"`        // Conntrack usage of each zone
+	Warnings              []string        `json:"warnings"`              // Warnings about resources approaching their limits
+}
+
+// DatapathStat holds flow statistics of a single datapath.
+type DatapathStat struct {
+	Name   string `json:"name"`   // Name of the datapath (e.g. "system@ovs-system")
+	Hits   uint64 `json:"hits"`   // Number of packets that matched existing datapath flows
+	Misses uint64 `json:"misses"` // Number of packets that didn't match any datapath flow
+	Lost   uint64 `json:"lost"`   // Number of packets dropped before they could be processed in userspace
+	Flows  uint64 `json:"flows"`  // Number of flows currently installed in the datapath
+}
+
+// ConntrackZone holds conntrack usage of a single conntrack zone.
+type ConntrackZone struct {
+	Zone  int      `json:"zone"`  // Conntrack zone ID
+	Names []string `json:"names"` // Names of the OVN objects (logical ports, routers) using the zone
+	Limit int      `json:"limit"` // Maximum number of connections in the zone (0 means unlimited)
+	Count int      `json:"count"` // Current number of connections in the zone
+}
+
+// HostPort describes a host management port that connects a cluster member to an OVN Logical Switch.
+type HostPort struct {
+	Member        string   `json:"member"`        // Name of the MicroOVN cluster member
+	LogicalSwitch string   `json:"logicalSwitch"` // Name of the OVN Logical Switch
+	LogicalPort   string   `json:"logicalPort"`   // Name of the OVN Logical Switch Port bound to the host port
+	Iface         string   `json:"iface"`         // Name of the internal OVS interface on the host
+	Mac           string   `json:"mac"`           // MAC address of the interface
+	Addresses     []string `json:"addresses"`     // IP addresses (in CIDR notation) configured on the interface
+}
+
+// HostPortRequest is a request to create a host management port.
+type HostPortRequest struct {
+	LogicalSwitch string   `json:"logicalSwitch"` // Name of the OVN Logical Switch
+	Addresses     []string `json:"addresses"`     // IP addresses (in CIDR notation) to configure on the interface
+}
@@ -0,0 +1,72 @@
+package types
+
+// TopologyDocument is a portable representation of logical objects from the OVN Northbound database.
+// References between objects are expressed by IDs of the records instead of database UUIDs, so that
+// the document can be imported into a different OVN deployment.
+type TopologyDocument struct {
+	// Version is a version of the document format
+	Version int `json:"version" yaml:"version"`
+	// Tables maps names of the OVN Northbound tables to their exported records
+	Tables map[string][]TopologyRecord `json:"tables" yaml:"tables"`
+	// Warnings lists references to objects that could not be exported. They are ignored on import
+	Warnings []string `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+}
+
+// TopologyRecord is a single exported record from the OVN Northbound database.
+type TopologyRecord struct {
+	// ID identifies the record within its table in the document. Records with a name use their name as the ID
+	ID string `json:"id" yaml:"id"`
+	// Columns maps names of the columns to their values. Sets are represented as lists, maps as objects and
+	// references to other records as their IDs
+	Columns map[string]any `json:"columns" yaml:"columns"`
+}
+
+// TopologyExportRequest selects top-level objects (Logical Switches, Logical Routers, Load Balancers and
+// Port Groups) that should be exported. Objects referenced by the selected objects are exported as well.
+// If no selector is specified, all objects are exported.
+type TopologyExportRequest struct {
+	// Names of the objects to export
+	Names []string `json:"names" yaml:"names"`
+	// ExternalIDs that the exported objects must have
+	ExternalIDs map[string]string `json:"externalIds" yaml:"externalIds"`
+}
+
+// TopologyImportResult summarizes objects created by the import of the TopologyDocument.
+type TopologyImportResult struct {
+	// Created maps names of the OVN Northbound tables to number of records created in them
+	Created map[string]int `json:"created" yaml:"created"`
+}
+
+// TopologyGraph is a graph of logical and physical objects from the OVN Northbound and Southbound databases.
+type TopologyGraph struct {
+	// Nodes of the graph (routers, switches, ports, chassis, ...)
+	Nodes []TopologyNode `json:"nodes" yaml:"nodes"`
+	// Edges between the nodes
+	Edges []TopologyEdge `json:"edges" yaml:"edges"`
+}
+
+// TopologyNode is a single node of the TopologyGraph.
+type TopologyNode struct {
+	// ID uniquely identifies the node in the graph
+	ID string `json:"id" yaml:"id"`
+	// Kind of the node (e.g. "router", "switch" or "chassis")
+	Kind string `json:"kind" yaml:"kind"`
+	// Name of the object represented by the node
+	Name string `json:"name" yaml:"name"`
+	// Managed is true if the object is managed by MicroOVN BGP integration
+	Managed bool `json:"managed" yaml:"managed"`
+	// Attributes holds additional information about the object (e.g. hostname of a chassis)
+	Attributes map[string]string `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+}
+
+// TopologyEdge is a single edge of the TopologyGraph.
+type TopologyEdge struct {
+	// From is an ID of the node where the edge starts
+	From string `json:"from" yaml:"from"`
+	// To is an ID of the node where the edge ends
+	To string `json:"to" yaml:"to"`
+	// Kind of the edge (e.g. "router-port" or "binding")
+	Kind string `json:"kind" yaml:"kind"`
+	// Label describes the edge (e.g. name of the router port that connects the nodes)
+	Label string `json:"label,omitempty" yaml:"label,omitempty"`
+}
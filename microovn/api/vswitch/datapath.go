@@ -0,0 +1,47 @@
+package vswitch
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/canonical/lxd/lxd/response"
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v2/rest"
+	"github.com/canonical/microcluster/v2/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/node"
+	"github.com/canonical/microovn/microovn/ovn/vswitch"
+)
+
+// DatapathStats defines endpoint for /1.0/switch/datapath
+var DatapathStats = rest.Endpoint{
+	Path: "switch/datapath",
+	Get:  rest.EndpointAction{Handler: getDatapathStats, AllowUntrusted: false, ProxyTarget: true},
+}
+
+// getDatapathStats implements GET method for /1.0/switch/datapath. It returns flow statistics of the datapath
+// on this member and conntrack usage of each zone, in the format of types.DatapathStats.
+func getDatapathStats(s state.State, r *http.Request) response.Response {
+	hasSwitch, err := node.HasServiceActive(r.Context(), s, types.SrvSwitch)
+	if err != nil {
+		logger.Errorf("Failed to check if switch is active on this node: %s", err)
+		return response.ErrorResponse(500, "internal server error")
+	}
+
+	if !hasSwitch {
+		return response.BadRequest(fmt.Errorf("this node does not run 'switch' service"))
+	}
+
+	stats, err := vswitch.GetDatapathStats(r.Context(), s)
+	if err != nil {
+		logger.Errorf("Failed to get datapath statistics: %s", err)
+		return response.ErrorResponse(500, "internal server error")
+	}
+
+	for _, warning := range stats.Warnings {
+		logger.Warnf("Datapath capacity: %s", warning)
+	}
+
+	return response.SyncResponse(true, stats)
+}
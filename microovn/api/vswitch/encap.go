@@ -0,0 +1,30 @@
+package vswitch
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/canonical/lxd/lxd/response"
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v2/rest"
+	"github.com/canonical/microcluster/v2/state"
+
+	"github.com/canonical/microovn/microovn/ovn/vswitch"
+)
+
+// EncapOptions defines endpoint for /1.0/switch/encap
+var EncapOptions = rest.Endpoint{
+	Path: "switch/encap",
+	Put:  rest.EndpointAction{Handler: putEncapOptions, AllowUntrusted: false, ProxyTarget: true},
+}
+
+// putEncapOptions implements PUT method for /1.0/switch/encap. It applies tunnel encapsulation options
+// configured for this member to the local Open vSwitch.
+func putEncapOptions(s state.State, r *http.Request) response.Response {
+	err := vswitch.UpdateEncapOptions(r.Context(), s)
+	if err != nil {
+		logger.Errorf("Failed to update tunnel encapsulation options: %v", err)
+		return response.InternalError(fmt.Errorf("failed to update tunnel encapsulation options"))
+	}
+	return response.EmptySyncResponse
+}
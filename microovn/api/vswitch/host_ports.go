@@ -0,0 +1,94 @@
+package vswitch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/canonical/lxd/lxd/response"
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v2/rest"
+	"github.com/canonical/microcluster/v2/state"
+	"github.com/gorilla/mux"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/ovn/hostport"
+)
+
+// HostPorts defines endpoint for /1.0/host-ports
+var HostPorts = rest.Endpoint{
+	Path: "host-ports",
+	Get:  rest.EndpointAction{Handler: getHostPorts, AllowUntrusted: false, ProxyTarget: true},
+	Post: rest.EndpointAction{Handler: postHostPorts, AllowUntrusted: false, ProxyTarget: true},
+}
+
+// HostPort defines endpoint for /1.0/host-ports/<logical-switch>
+var HostPort = rest.Endpoint{
+	Path:   "host-ports/{switch}",
+	Delete: rest.EndpointAction{Handler: deleteHostPort, AllowUntrusted: false, ProxyTarget: true},
+}
+
+// getHostPorts implements GET method for /1.0/host-ports. It lists host management ports of this member.
+func getHostPorts(s state.State, r *http.Request) response.Response {
+	hostPorts, err := hostport.List(r.Context(), s)
+	if err != nil {
+		logger.Errorf("Failed to list host ports: %v", err)
+		return response.InternalError(fmt.Errorf("failed to list host ports"))
+	}
+	return response.SyncResponse(true, hostPorts)
+}
+
+// postHostPorts implements POST method for /1.0/host-ports. It creates host management port of this member
+// on the Logical Switch from the request.
+func postHostPorts(s state.State, r *http.Request) response.Response {
+	var request types.HostPortRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		return response.BadRequest(fmt.Errorf("failed to decode request: %w", err))
+	}
+
+	if request.LogicalSwitch == "" {
+		return response.BadRequest(fmt.Errorf("logical switch name is required"))
+	}
+
+	_, err = hostport.ParseAddresses(request.Addresses)
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	hostPort, err := hostport.Add(r.Context(), s, request.LogicalSwitch, request.Addresses)
+	if errors.Is(err, hostport.ErrExists) {
+		return response.Conflict(fmt.Errorf("host port on Logical Switch '%s' already exists", request.LogicalSwitch))
+	}
+	if errors.Is(err, hostport.ErrSwitchNotFound) {
+		return response.NotFound(fmt.Errorf("Logical Switch '%s' does not exist", request.LogicalSwitch))
+	}
+	if err != nil {
+		logger.Errorf("Failed to add host port: %v", err)
+		return response.InternalError(err)
+	}
+
+	return response.SyncResponse(true, hostPort)
+}
+
+// deleteHostPort implements DELETE method for /1.0/host-ports/<logical-switch>. It removes host management
+// port of this member from the Logical Switch.
+func deleteHostPort(s state.State, r *http.Request) response.Response {
+	logicalSwitch, err := url.PathUnescape(mux.Vars(r)["switch"])
+	if err != nil {
+		return response.BadRequest(err)
+	}
+
+	err = hostport.Remove(r.Context(), s, logicalSwitch)
+	if errors.Is(err, hostport.ErrNotFound) {
+		return response.NotFound(fmt.Errorf("host port on Logical Switch '%s' does not exist", logicalSwitch))
+	}
+	if err != nil {
+		logger.Errorf("Failed to remove host port: %v", err)
+		return response.InternalError(err)
+	}
+
+	return response.EmptySyncResponse
+}
@@ -0,0 +1,44 @@
+// Package vswitch implements APIs related to the Open vSwitch managed by MicroOVN.
+package vswitch
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/canonical/lxd/lxd/response"
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v2/rest"
+	"github.com/canonical/microcluster/v2/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/node"
+	"github.com/canonical/microovn/microovn/ovn/vswitch"
+)
+
+// Inventory defines endpoint for /1.0/switch/inventory
+var Inventory = rest.Endpoint{
+	Path: "switch/inventory",
+	Get:  rest.EndpointAction{Handler: getInventory, AllowUntrusted: false, ProxyTarget: true},
+}
+
+// getInventory implements GET method for /1.0/switch/inventory. It returns bridges, ports and interfaces
+// configured in the Open vSwitch running on this member, in the format of types.SwitchInventory.
+func getInventory(s state.State, r *http.Request) response.Response {
+	hasSwitch, err := node.HasServiceActive(r.Context(), s, types.SrvSwitch)
+	if err != nil {
+		logger.Errorf("Failed to check if switch is active on this node: %s", err)
+		return response.ErrorResponse(500, "internal server error")
+	}
+
+	if !hasSwitch {
+		return response.BadRequest(fmt.Errorf("this node does not run 'switch' service"))
+	}
+
+	inventory, err := vswitch.GetInventory(r.Context(), s)
+	if err != nil {
+		logger.Errorf("Failed to get OVS inventory: %s", err)
+		return response.ErrorResponse(500, "internal server error")
+	}
+
+	return response.SyncResponse(true, inventory)
+}
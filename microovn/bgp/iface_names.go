@@ -0,0 +1,158 @@
+package bgp
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"hash/fnv"
+
+	"github.com/canonical/microcluster/v2/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/database"
+)
+
+// ifaceNameMaxLen - maximum length of the Linux interface name (IFNAMSIZ without the trailing null byte).
+const ifaceNameMaxLen = 15
+
+const (
+	redirectIfaceSuffix = "-bgp" // suffix of the interface on which BGP daemon listens
+	peerIfaceSuffix     = "-brg" // suffix of the interface plugged into the OVN integration bridge
+)
+
+// redirectIfaces holds names of the veth pair that is used to redirect BGP traffic from a single
+// external interface.
+type redirectIfaces struct {
+	Redirect string // Interface placed in the VRF, BGP daemon listens on it
+	Peer     string // Peer of the redirect interface, plugged into the OVN integration bridge
+}
+
+// getBgpVethName returns base name of the veth pair used for BGP redirect from the "externalIface".
+// The base name is derived directly from the external interface name if the resulting interface names
+// fit into the kernel limit. Otherwise, a short hash of the external interface name is used instead.
+func getBgpVethName(externalIface string) string {
+	vethName := fmt.Sprintf("v%s", externalIface)
+	if len(vethName)+len(redirectIfaceSuffix) <= ifaceNameMaxLen {
+		return vethName
+	}
+
+	nameHash := fnv.New32a()
+	_, _ = nameHash.Write([]byte(externalIface))
+	return fmt.Sprintf("v%08x", nameHash.Sum32())
+}
+
+// generateRedirectIfaces returns names of the veth pair that should be used for BGP redirect from
+// the "externalIface". Returned names are always same for given interface name.
+func generateRedirectIfaces(externalIface string) redirectIfaces {
+	vethName := getBgpVethName(externalIface)
+	return redirectIfaces{
+		Redirect: vethName + redirectIfaceSuffix,
+		Peer:     vethName + peerIfaceSuffix,
+	}
+}
+
+// resolveRedirectIfaces returns names of the veth pairs used for BGP redirect from each external connection,
+// keyed by the name of the external interface. Names are looked up in the database first. Names for interfaces
+// that don't have a record yet are generated and stored in the database, so that they stay consistent for
+// the whole lifetime of the BGP service.
+func resolveRedirectIfaces(ctx context.Context, s state.State, extConnections []types.BgpExternalConnection) (map[string]redirectIfaces, error) {
+	ifaces := make(map[string]redirectIfaces, len(extConnections))
+	err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		memberName := s.Name()
+		records, err := database.GetBgpInterfaces(ctx, tx, database.BgpInterfaceFilter{Member: &memberName})
+		if err != nil {
+			return err
+		}
+
+		usedNames := make(map[string]string, len(records))
+		for _, record := range records {
+			ifaces[record.Iface] = redirectIfaces{Redirect: record.Redirect, Peer: record.Peer}
+			usedNames[record.Redirect] = record.Iface
+		}
+
+		for _, extConnection := range extConnections {
+			if _, ok := ifaces[extConnection.Iface]; ok {
+				continue
+			}
+
+			names := generateRedirectIfaces(extConnection.Iface)
+			if owner, ok := usedNames[names.Redirect]; ok {
+				return fmt.Errorf(
+					"BGP redirect interface name '%s' for '%s' conflicts with interface '%s'",
+					names.Redirect, extConnection.Iface, owner,
+				)
+			}
+
+			_, err = database.CreateBgpInterface(ctx, tx, database.BgpInterface{
+				Member:   memberName,
+				Iface:    extConnection.Iface,
+				Redirect: names.Redirect,
+				Peer:     names.Peer,
+			})
+			if err != nil {
+				return err
+			}
+
+			ifaces[extConnection.Iface] = names
+			usedNames[names.Redirect] = extConnection.Iface
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve BGP redirect interface names: %w", err)
+	}
+
+	return ifaces, nil
+}
+
+// lookupRedirectIfaces returns names of all veth pairs used for BGP redirect on the local member, as they
+// are recorded in the database. The result is keyed by the name of the external interface.
+func lookupRedirectIfaces(ctx context.Context, s state.State) (map[string]redirectIfaces, error) {
+	ifaces := make(map[string]redirectIfaces)
+	err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		memberName := s.Name()
+		records, err := database.GetBgpInterfaces(ctx, tx, database.BgpInterfaceFilter{Member: &memberName})
+		if err != nil {
+			return err
+		}
+
+		for _, record := range records {
+			ifaces[record.Iface] = redirectIfaces{Redirect: record.Redirect, Peer: record.Peer}
+		}
+		return nil
+	})
+
+	return ifaces, err
+}
+
+// forgetRedirectIfaces removes records about veth pairs used for BGP redirect on the local member.
+func forgetRedirectIfaces(ctx context.Context, s state.State) error {
+	return s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		return database.DeleteBgpInterfaces(ctx, tx, s.Name())
+	})
+}
+
+// ListRedirectInterfaces returns names of the system interfaces used for BGP redirect on every
+// cluster member.
+func ListRedirectInterfaces(ctx context.Context, s state.State) (types.BgpRedirectInterfaces, error) {
+	result := types.BgpRedirectInterfaces{}
+	err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		records, err := database.GetBgpInterfaces(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		for _, record := range records {
+			result = append(result, types.BgpRedirectInterface{
+				Member:   record.Member,
+				Iface:    record.Iface,
+				Redirect: record.Redirect,
+				Peer:     record.Peer,
+			})
+		}
+		return nil
+	})
+
+	return result, err
+}
@@ -0,0 +1,40 @@
+package bgp
+
+import (
+	"testing"
+)
+
+func TestUnexported_generateRedirectIfaces(t *testing.T) {
+	// Short interface names are used directly
+	ifaces := generateRedirectIfaces("eth1")
+	if ifaces.Redirect != "veth1-bgp" || ifaces.Peer != "veth1-brg" {
+		t.Errorf("Unexpected interface names for 'eth1': %+v", ifaces)
+	}
+
+	// Longest interface name that still fits the limit
+	ifaces = generateRedirectIfaces("enp129s0f0")
+	if ifaces.Redirect != "venp129s0f0-bgp" {
+		t.Errorf("Unexpected interface names for 'enp129s0f0': %+v", ifaces)
+	}
+
+	// Long interface names are hashed, and the result is stable
+	longNames := []string{"enp129s0f0np0", "enp129s0f1np1", "very-long-interface-name"}
+	seen := make(map[string]string)
+	for _, name := range longNames {
+		ifaces = generateRedirectIfaces(name)
+		for _, ifaceName := range []string{ifaces.Redirect, ifaces.Peer} {
+			if len(ifaceName) > ifaceNameMaxLen {
+				t.Errorf("Interface name '%s' generated for '%s' exceeds %d characters", ifaceName, name, ifaceNameMaxLen)
+			}
+		}
+
+		if ifaces != generateRedirectIfaces(name) {
+			t.Errorf("Interface names generated for '%s' are not stable", name)
+		}
+
+		if other, ok := seen[ifaces.Redirect]; ok {
+			t.Errorf("Interface names generated for '%s' and '%s' collide", name, other)
+		}
+		seen[ifaces.Redirect] = name
+	}
+}
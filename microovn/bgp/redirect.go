@@ -16,6 +16,7 @@ import (
 	"github.com/canonical/microovn/microovn/api/types"
 	"github.com/canonical/microovn/microovn/netplan"
 	ovnCmd "github.com/canonical/microovn/microovn/ovn/cmd"
+	"github.com/canonical/microovn/microovn/ovn/copp"
 	"github.com/canonical/microovn/microovn/ovn/paths"
 )
 
@@ -136,21 +137,6 @@ func getVrfName(tableID string) string {
 	return fmt.Sprintf("ovnvrf%s", tableID)
 }
 
-func getBgpVethName(externalIface string) string {
-	return fmt.Sprintf("v%s", externalIface)
-}
-
-// getBgpRedirectIfaceName returns name of the system interface to which all BGP
-// traffic from externalIface network is redirected.
-func getBgpRedirectIfaceName(externalIface string) string {
-	return fmt.Sprintf("%s-bgp", getBgpVethName(externalIface))
-}
-
-// getBgpRedirectIfacePeerName returns name of the peer to the bgp iface
-func getBgpRedirectIfacePeerName(externalIface string) string {
-	return fmt.Sprintf("%s-brg", getBgpVethName(externalIface))
-}
-
 // parseOvnFind parses STDOUT string of OVN/OVS "find" commands with "--bare"
 // formatting. Returned value is a list of strings with each element containing
 // single, non-empty, line of the "find" result.
@@ -224,6 +210,13 @@ func createExternalNetworks(ctx context.Context, s state.State, extConnections [
 		logger.Errorf("Failed to create OVN Logical Router for external connectivity: %v", err)
 		return err
 	}
+
+	// Protect ovn-controller from excessive control plane traffic punted by the router
+	err = copp.AttachToRouter(ctx, s, lrName)
+	if err != nil {
+		logger.Warnf("Failed to set up Control Plane Protection for Logical Router '%s': %v", lrName, err)
+	}
+
 	for _, extConnection := range extConnections {
 		lsName := getLsName(s, extConnection.Iface)
 		lspName := fmt.Sprintf("lsp-%s-%s", s.Name(), extConnection.Iface)
@@ -274,7 +267,7 @@ func createExternalNetworks(ctx context.Context, s state.State, extConnections [
 // createVrf instructs OVN to set up VRF to redistribute NAT and Load Balancer addresses for each Logical Router Port
 // that's associated with external connections defined in "extConnections" argument. Only one VRF is set up with table
 // ID specified by "tableID" argument. All LRPs redistribute their addresses to this VRF.
-func createVrf(ctx context.Context, s state.State, extConnections []types.BgpExternalConnection, tableID string) error {
+func createVrf(ctx context.Context, s state.State, extConnections []types.BgpExternalConnection, ifaces map[string]redirectIfaces, tableID string) error {
 	lrName := getLrName(s)
 
 	_, err := ovnCmd.NBCtlCluster(ctx,
@@ -294,8 +287,7 @@ func createVrf(ctx context.Context, s state.State, extConnections []types.BgpExt
 			"lrp-set-options", lrpName,
 			"dynamic-routing-maintain-vrf=true",
 			"dynamic-routing-redistribute=nat,lb",
-			fmt.Sprintf("dynamic-routing-port-name=%s",
-				getBgpRedirectIfaceName(extConnection.Iface)),
+			fmt.Sprintf("dynamic-routing-port-name=%s", ifaces[extConnection.Iface].Redirect),
 		)
 		if err != nil {
 			return fmt.Errorf("failed to enable vrf for LRP '%s': %v", lrpName, err)
@@ -304,7 +296,9 @@ func createVrf(ctx context.Context, s state.State, extConnections []types.BgpExt
 	return nil
 }
 
-func generateVeth(ctx context.Context, s state.State, extConnections []types.BgpExternalConnection, tableID string) error {
+// generateVeth writes and applies netplan configuration that creates veth pair for each external connection. One
+// side of the pair is placed in the VRF specified by "tableID" and the other is plugged into the OVN integration bridge.
+func generateVeth(ctx context.Context, s state.State, extConnections []types.BgpExternalConnection, ifaces map[string]redirectIfaces, tableID string) error {
 
 	vrfName := getVrfName(tableID)
 
@@ -319,8 +313,8 @@ func generateVeth(ctx context.Context, s state.State, extConnections []types.Bgp
 	var drPortMapping strings.Builder
 
 	for _, extConnection := range extConnections {
-		bgpInterface := getBgpRedirectIfaceName(extConnection.Iface)
-		brgInterface := getBgpRedirectIfacePeerName(extConnection.Iface)
+		bgpInterface := ifaces[extConnection.Iface].Redirect
+		brgInterface := ifaces[extConnection.Iface].Peer
 		mac := generateLrpMac(getLrpName(s, extConnection.Iface))
 
 		// Add to virtual ethernet
@@ -358,10 +352,10 @@ func generateVeth(ctx context.Context, s state.State, extConnections []types.Bgp
 
 // redirectBgp creates a port in OVS, moves it to the VRF specified by "tableID" and configures OVN to redirect
 // BGP+BFD traffic from the associated Logical Router Ports to the newly created ports.
-func redirectBgp(ctx context.Context, s state.State, extConnections []types.BgpExternalConnection, tableID string) error {
+func redirectBgp(ctx context.Context, s state.State, extConnections []types.BgpExternalConnection, ifaces map[string]redirectIfaces, tableID string) error {
 	vrfName := getVrfName(tableID)
 
-	err := generateVeth(ctx, s, extConnections, tableID)
+	err := generateVeth(ctx, s, extConnections, ifaces, tableID)
 	if err != nil {
 		return err
 	}
@@ -369,8 +363,8 @@ func redirectBgp(ctx context.Context, s state.State, extConnections []types.BgpE
 	for _, extConnection := range extConnections {
 		lsName := getLsName(s, extConnection.Iface)
 		lrpName := getLrpName(s, extConnection.Iface)
-		brgIface := getBgpRedirectIfacePeerName(extConnection.Iface)
-		bgpIface := getBgpRedirectIfaceName(extConnection.Iface)
+		brgIface := ifaces[extConnection.Iface].Peer
+		bgpIface := ifaces[extConnection.Iface].Redirect
 		bgpLsp := fmt.Sprintf("lsp-%s-%s", s.Name(), bgpIface)
 
 		// Create Logical Switch Port to which the BGP+BFD traffic will be redirected
@@ -472,6 +466,21 @@ func teardownAll(ctx context.Context, s state.State) error {
 		}
 	}
 
+	// Remove OVS ports of BGP redirect interfaces recorded in the database. These ports are
+	// normally removed together with the rest of the tagged ports below, this covers ports
+	// that lost their tag.
+	redirectIfaces, err := lookupRedirectIfaces(ctx, s)
+	if err != nil {
+		allErrors = errors.Join(allErrors, fmt.Errorf("failed to lookup BGP redirect interfaces: %v", err))
+	} else {
+		for _, ifaces := range redirectIfaces {
+			_, err = ovnCmd.VSCtl(ctx, s, "--if-exists", "del-port", ifaces.Peer)
+			if err != nil {
+				allErrors = errors.Join(allErrors, fmt.Errorf("failed to delete OVS Port '%s': %v", ifaces.Peer, err))
+			}
+		}
+	}
+
 	// Find and remove OVS ports used for BGP redirect
 	ports, err := ovnCmd.VSCtl(ctx, s, "--bare", "--columns", "name",
 		"find", "port", fmt.Sprintf("external-ids:%s=true", BgpManagedTag),
@@ -532,6 +541,12 @@ func teardownAll(ctx context.Context, s state.State) error {
 		allErrors = errors.Join(allErrors, fmt.Errorf("failed to remove %s: %v", BgpBridgeMapping, err))
 	}
 
+	// Forget names of BGP redirect interfaces
+	err = forgetRedirectIfaces(ctx, s)
+	if err != nil {
+		allErrors = errors.Join(allErrors, fmt.Errorf("failed to remove BGP redirect interface records: %v", err))
+	}
+
 	// Backup and reset Bird's config
 	backupConfig := fmt.Sprintf("%s_%d", paths.BirdConfigFile(), time.Now().Unix())
 	_, err = shared.RunCommandContext(ctx, "cp", paths.BirdConfigFile(), backupConfig)
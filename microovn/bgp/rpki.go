@@ -0,0 +1,143 @@
+package bgp
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"path/filepath"
+	"strings"
+
+	"github.com/canonical/lxd/shared"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/ovn/paths"
+)
+
+// rpkiProtocolPrefix - prefix of the names of RPKI protocols that connect Bird to the RTR cache servers.
+const rpkiProtocolPrefix = "microovn_rpki_"
+
+// roaTables maps names of the Bird routing tables to the ROA tables used for origin validation of their routes.
+var roaTables = map[string]string{
+	"master4": "microovn_roa4",
+	"master6": "microovn_roa6",
+}
+
+// roaStates maps Bird ROA check results to the route validation states.
+var roaStates = map[string]string{
+	"ROA_VALID":   types.RouteValidationValid,
+	"ROA_INVALID": types.RouteValidationInvalid,
+	"ROA_UNKNOWN": types.RouteValidationUnknown,
+}
+
+// runBirdc runs the "birdc" command with the provided arguments.
+func runBirdc(ctx context.Context, args ...string) (string, error) {
+	return shared.RunCommandContext(ctx, filepath.Join(paths.Wrappers(), "birdc"), args...)
+}
+
+// RouteStatus queries the local Bird daemon and returns routes learned from BGP peers on the external
+// connections. If RPKI is configured, the status also contains RPKI origin validation state of every
+// route (including routes rejected by the import filter) and state of the connections to the RTR cache servers.
+func RouteStatus(ctx context.Context) (types.BgpRouteStatus, error) {
+	status := types.BgpRouteStatus{Routes: []types.BgpRoute{}}
+
+	out, err := runBirdc(ctx, "show", "protocols")
+	if err != nil {
+		return status, fmt.Errorf("failed to query Bird protocols: %w", err)
+	}
+	status.RpkiCaches = parseRpkiCaches(out)
+
+	for _, table := range []string{"master4", "master6"} {
+		if len(status.RpkiCaches) == 0 {
+			out, err = runBirdc(ctx, "show", "route", "table", table, "where", "source = RTS_BGP")
+			if err != nil {
+				return status, fmt.Errorf("failed to query Bird routes in table %s: %w", table, err)
+			}
+			status.Routes = append(status.Routes, parseRoutes(out, "", false)...)
+			continue
+		}
+
+		for _, roaState := range []string{"ROA_VALID", "ROA_INVALID", "ROA_UNKNOWN"} {
+			filter := fmt.Sprintf("source = RTS_BGP && roa_check(%s, net, bgp_path.last) = %s", roaTables[table], roaState)
+			out, err = runBirdc(ctx, "show", "route", "table", table, "where", filter)
+			if err != nil {
+				return status, fmt.Errorf("failed to query Bird routes in table %s: %w", table, err)
+			}
+			status.Routes = append(status.Routes, parseRoutes(out, roaStates[roaState], false)...)
+		}
+
+		// Invalid routes rejected by the import filter are kept in the table only for inspection
+		filter := fmt.Sprintf("roa_check(%s, net, bgp_path.last) = ROA_INVALID", roaTables[table])
+		out, err = runBirdc(ctx, "show", "route", "table", table, "filtered", "where", filter)
+		if err != nil {
+			return status, fmt.Errorf("failed to query filtered Bird routes in table %s: %w", table, err)
+		}
+		status.Routes = append(status.Routes, parseRoutes(out, types.RouteValidationInvalid, true)...)
+	}
+
+	return status, nil
+}
+
+// parseRpkiCaches returns state of the MicroOVN RPKI protocols found in the output of "birdc show protocols"
+// command.
+func parseRpkiCaches(output string) []types.BgpRpkiCacheStatus {
+	caches := []types.BgpRpkiCacheStatus{}
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 || !strings.HasPrefix(fields[0], rpkiProtocolPrefix) || fields[1] != "RPKI" {
+			continue
+		}
+
+		cache := types.BgpRpkiCacheStatus{Name: fields[0], State: fields[3]}
+		if len(fields) > 5 {
+			cache.Info = strings.Join(fields[5:], " ")
+		}
+		caches = append(caches, cache)
+	}
+
+	return caches
+}
+
+// parseRoutes parses output of the "birdc show route" command and returns MicroOVN BGP routes with the
+// "validation" state. Routes learned from other protocols are ignored. The "filtered" argument marks the
+// routes as rejected by the import filter.
+//
+// Each route is printed on a line that starts with the network prefix. Alternative routes for the same
+// prefix are printed on the following lines without the prefix.
+func parseRoutes(output string, validation string, filtered bool) []types.BgpRoute {
+	routes := []types.BgpRoute{}
+	prefix := ""
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+			if _, err := netip.ParsePrefix(fields[0]); err != nil {
+				prefix = ""
+				continue
+			}
+			prefix = fields[0]
+			fields = fields[1:]
+		}
+
+		route := types.BgpRoute{Prefix: prefix, Validation: validation, Filtered: filtered}
+		for _, field := range fields {
+			switch {
+			case strings.HasPrefix(field, "[AS"):
+				route.OriginAS = strings.TrimRight(strings.TrimPrefix(field, "[AS"), "ie?]")
+			case strings.HasPrefix(field, "[") && route.Protocol == "":
+				route.Protocol = strings.TrimPrefix(field, "[")
+			case field == "*":
+				route.Preferred = true
+			}
+		}
+
+		if prefix == "" || !strings.HasPrefix(route.Protocol, dynamicProtocolPrefix) {
+			continue
+		}
+		routes = append(routes, route)
+	}
+
+	return routes
+}
@@ -0,0 +1,73 @@
+package bgp
+
+import (
+	"testing"
+
+	"github.com/canonical/microovn/microovn/api/types"
+)
+
+func TestUnexported_parseRpkiCaches(t *testing.T) {
+	output := `BIRD 2.15.1 ready.
+Name       Proto      Table      State  Since         Info
+device1    Device     ---        up     10:21:12.531
+microovn_rpki_0 RPKI  ---        up     10:21:12.531  Established
+microovn_rpki_1 RPKI  ---        start  10:21:12.531  Transport-Error
+dyn_microovn_eth1_1 BGP ---      up     10:21:15.102  Established
+`
+	caches := parseRpkiCaches(output)
+	expected := []types.BgpRpkiCacheStatus{
+		{Name: "microovn_rpki_0", State: "up", Info: "Established"},
+		{Name: "microovn_rpki_1", State: "start", Info: "Transport-Error"},
+	}
+	if len(caches) != len(expected) {
+		t.Fatalf("Expected %d RPKI caches, got %+v", len(expected), caches)
+	}
+	for i := range expected {
+		if caches[i] != expected[i] {
+			t.Errorf("Expected RPKI cache %+v, got %+v", expected[i], caches[i])
+		}
+	}
+
+	if len(parseRpkiCaches("")) != 0 {
+		t.Errorf("Expected no RPKI caches for empty output")
+	}
+}
+
+func TestUnexported_parseRoutes(t *testing.T) {
+	output := `BIRD 2.15.1 ready.
+Table master4:
+198.51.100.0/24      unicast [dyn_microovn_eth1_1 10:21:15.102] * (100) [AS64500i]
+	via 169.254.0.1 on veth1-bgp
+                     unicast [dyn_microovn_eth2_1 10:21:16.215] (100) [AS64501i]
+	via 169.254.0.2 on veth2-bgp
+203.0.113.0/24       unicast [kernel4 10:21:12.531] * (10)
+	dev eth0
+2001:db8::/32        unicast [dyn_microovn_eth1_1 10:21:15.102] * (100) [AS64502?]
+	via fe80::1 on veth1-bgp
+`
+	routes := parseRoutes(output, types.RouteValidationValid, false)
+	expected := []types.BgpRoute{
+		{Prefix: "198.51.100.0/24", Protocol: "dyn_microovn_eth1_1", OriginAS: "64500", Preferred: true, Validation: "valid"},
+		{Prefix: "198.51.100.0/24", Protocol: "dyn_microovn_eth2_1", OriginAS: "64501", Validation: "valid"},
+		{Prefix: "2001:db8::/32", Protocol: "dyn_microovn_eth1_1", OriginAS: "64502", Preferred: true, Validation: "valid"},
+	}
+	if len(routes) != len(expected) {
+		t.Fatalf("Expected %d routes, got %+v", len(expected), routes)
+	}
+	for i := range expected {
+		if routes[i] != expected[i] {
+			t.Errorf("Expected route %+v, got %+v", expected[i], routes[i])
+		}
+	}
+
+	routes = parseRoutes(output, types.RouteValidationInvalid, true)
+	for _, route := range routes {
+		if !route.Filtered || route.Validation != types.RouteValidationInvalid {
+			t.Errorf("Expected filtered invalid route, got %+v", route)
+		}
+	}
+
+	if len(parseRoutes("BIRD 2.15.1 ready.\n", "", false)) != 0 {
+		t.Errorf("Expected no routes for empty output")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"text/template"
 
 	"github.com/canonical/lxd/shared"
@@ -25,14 +26,17 @@ type birdTemplateInput struct {
 	VrfName        string
 	RouterID       string
 	ExtConnections []types.BgpExternalConnection
+	RedirectIfaces map[string]redirectIfaces
 	ASN            string
+	RpkiCaches     []types.BgpRpkiCache
+	RpkiPolicy     string
+	Passwords      map[string]string
 }
 
 // birdConfTemplate - a template of a Bird configuration file that enables BGP daemon in dynamic
 // mode on specified interfaces.
 var birdConfTemplate = template.Must(
 	template.New("bird.conf").
-		Funcs(template.FuncMap{"ifaceName": getBgpRedirectIfaceName}).
 		Parse(`
 log syslog all;
 protocol device {};
@@ -84,24 +88,101 @@ filter no_default_v6 {
 	if net = ::/0 then reject;
 	accept;
 }
-{{ range .ExtConnections }}
+{{ if .RpkiCaches }}
+roa4 table microovn_roa4;
+roa6 table microovn_roa6;
+{{ range $index, $cache := .RpkiCaches }}
+protocol rpki microovn_rpki_{{ $index }} {
+	roa4 { table microovn_roa4; };
+	roa6 { table microovn_roa6; };
+	remote "{{ $cache.Host }}" port {{ $cache.Port }};
+	retry keep 90;
+	refresh keep 900;
+	expire keep 172800;
+}
+{{ end }}
+filter rpki_import_v4 {
+{{- if eq .RpkiPolicy "reject" }}
+	if roa_check(microovn_roa4, net, bgp_path.last) = ROA_INVALID then reject;
+{{- else if eq .RpkiPolicy "deprioritize" }}
+	if roa_check(microovn_roa4, net, bgp_path.last) = ROA_INVALID then {
+		preference = 10;
+		bgp_local_pref = 10;
+	}
+{{- end }}
+	accept;
+}
+
+filter rpki_import_v6 {
+{{- if eq .RpkiPolicy "reject" }}
+	if roa_check(microovn_roa6, net, bgp_path.last) = ROA_INVALID then reject;
+{{- else if eq .RpkiPolicy "deprioritize" }}
+	if roa_check(microovn_roa6, net, bgp_path.last) = ROA_INVALID then {
+		preference = 10;
+		bgp_local_pref = 10;
+	}
+{{- end }}
+	accept;
+}
+{{ end }}
+{{- range .ExtConnections }}
 protocol bgp microovn_{{ .Iface }} {
 	router id {{ $.RouterID }};
-	interface "{{ ifaceName .Iface }}";
+	interface "{{ (index $.RedirectIfaces .Iface).Redirect }}";
 	vrf "{{ $.VrfName }}";
-	local as {{ $.ASN }};
+	local as {{ or .LocalAsn $.ASN }};
 	neighbor range fe80::/10 external;
 	dynamic name "dyn_microovn_{{ .Iface }}_";
+{{- if .Description }}
+	description "{{ .Description }}";
+{{- end }}
+{{- with index $.Passwords .Iface }}
+	password "{{ . }}";
+{{- end }}
+{{- if .HoldTime }}
+	hold time {{ .HoldTime }};
+{{- end }}
+{{- if .KeepaliveTime }}
+	keepalive time {{ .KeepaliveTime }};
+{{- end }}
+{{- if .TTLSecurity }}
+	ttl security on;
+{{- end }}
+{{- if and .LimitRestartDelay (eq .LimitAction "restart") }}
+	error wait time {{ .LimitRestartDelay }},{{ .LimitRestartDelay }};
+{{- end }}
 	ipv4 {
 		next hop self ebgp;
 		extended next hop on;
 		require extended next hop on;
+{{- if $.RpkiCaches }}
+		import filter rpki_import_v4;
+		import keep filtered on;
+{{- else }}
 		import all;
+{{- end }}
 		export filter no_default_v4;
+{{- if .ReceiveLimit }}
+		receive limit {{ .ReceiveLimit }} action {{ .LimitAction }};
+{{- end }}
+{{- if .ExportLimit }}
+		export limit {{ .ExportLimit }} action {{ .LimitAction }};
+{{- end }}
 	};
 	ipv6 {
+{{- if $.RpkiCaches }}
+		import filter rpki_import_v6;
+		import keep filtered on;
+{{- else }}
 		import all;
+{{- end }}
 		export filter no_default_v6;
+{{- if .ReceiveLimit }}
+		receive limit {{ .ReceiveLimit }} action {{ .LimitAction }};
+{{- end }}
+{{- if .ExportLimit }}
+		export limit {{ .ExportLimit }} action {{ .LimitAction }};
+{{- end }}
 	};
 	bfd {
 		# We only want to use BFD for liveness and failure detection if
@@ -139,6 +220,11 @@ func EnableService(ctx context.Context, s state.State, extraConfig *types.ExtraB
 		logging.Errorf("Failed to parse external connections: %v", err)
 	}
 
+	redirectIfaces, err := resolveRedirectIfaces(ctx, s, extConnections)
+	if err != nil {
+		return errors.Join(err, DisableService(ctx, s))
+	}
+
 	err = createExternalBridges(ctx, s, extConnections)
 	if err != nil {
 		return errors.Join(err, DisableService(ctx, s))
@@ -149,18 +235,18 @@ func EnableService(ctx context.Context, s state.State, extraConfig *types.ExtraB
 		return errors.Join(err, DisableService(ctx, s))
 	}
 
-	err = createVrf(ctx, s, extConnections, extraConfig.Vrf)
+	err = createVrf(ctx, s, extConnections, redirectIfaces, extraConfig.Vrf)
 	if err != nil {
 		return errors.Join(err, DisableService(ctx, s))
 	}
 
-	err = redirectBgp(ctx, s, extConnections, extraConfig.Vrf)
+	err = redirectBgp(ctx, s, extConnections, redirectIfaces, extraConfig.Vrf)
 	if err != nil {
 		return errors.Join(err, DisableService(ctx, s))
 	}
 
 	if extraConfig.Asn != "" {
-		err = configureBirdBgp(ctx, s, extConnections, extraConfig.Vrf, extraConfig.Asn)
+		err = configureBirdBgp(ctx, s, extraConfig, extConnections, redirectIfaces)
 		if err != nil {
 			return errors.Join(err, DisableService(ctx, s))
 		}
@@ -189,23 +275,54 @@ func DisableService(ctx context.Context, s state.State) error {
 
 // configureBirdBgp configures the Bird Routing Daemon to start BGP processes listening on each interface in
 // extConnections.
-// Each BGP daemon is connected to the VRF table specified by "tableID". It will announce routes from the VRF
-// to its peers, and it will insert routes announced by its peers into the same VRF.
-// All BGP daemons will be configured with the provided local ASN.
-func configureBirdBgp(ctx context.Context, s state.State, extConnections []types.BgpExternalConnection, tableID string, asn string) error {
+// Each BGP daemon is connected to the VRF table specified by the "vrf" option of the "extraConfig". It will
+// announce routes from the VRF to its peers, and it will insert routes announced by its peers into the same VRF.
+// All BGP daemons will be configured with the local ASN from the "extraConfig", unless the external connection
+// overrides it, and with the session safeguards and timers resolved for their external connection.
+// If RPKI caches are configured, routes learned by the BGP daemons are subject to RPKI origin validation
+// against ROAs received from these caches, and invalid routes are handled according to the RPKI policy.
+func configureBirdBgp(ctx context.Context, s state.State, extraConfig *types.ExtraBgpConfig, extConnections []types.BgpExternalConnection, ifaces map[string]redirectIfaces) error {
+	rpkiCaches, err := extraConfig.ParseRpkiCaches()
+	if err != nil {
+		return err
+	}
+
+	rpkiPolicy, err := extraConfig.ParseRpkiPolicy()
+	if err != nil {
+		return err
+	}
+
+	passwords, err := readBgpPasswords(extConnections)
+	if err != nil {
+		return err
+	}
+
+	tableID := extraConfig.Vrf
 	vrfName := getVrfName(tableID)
 
-	configFile, err := os.OpenFile(paths.BirdConfigFile(), os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	// The configuration contains passwords of BGP sessions, it must be readable only by its owner
+	configFile, err := os.OpenFile(paths.BirdConfigFile(), os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
 	if err != nil {
 		return fmt.Errorf("failed to open Bird configuration file for writing: %w", err)
 	}
+	defer configFile.Close()
+
+	// Mode of the file is not changed by OpenFile if it already existed
+	err = configFile.Chmod(0600)
+	if err != nil {
+		return fmt.Errorf("failed to restrict permissions of Bird configuration file: %w", err)
+	}
 
 	err = birdConfTemplate.Execute(configFile, birdTemplateInput{
 		VrfTableID:     tableID,
 		VrfName:        vrfName,
 		RouterID:       generateBGPRouterID(getLrpName(s, extConnections[0].Iface)),
 		ExtConnections: extConnections,
-		ASN:            asn,
+		RedirectIfaces: ifaces,
+		ASN:            extraConfig.Asn,
+		RpkiCaches:     rpkiCaches,
+		RpkiPolicy:     rpkiPolicy,
+		Passwords:      passwords,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to render Bird configuration template: %w", err)
@@ -217,3 +334,28 @@ func configureBirdBgp(ctx context.Context, s state.State, extConnections []types
 	}
 	return err
 }
+
+// readBgpPasswords reads passwords of BGP sessions from the password files of the external connections
+// and returns them keyed by the interface names. Connections without password file are omitted.
+func readBgpPasswords(extConnections []types.BgpExternalConnection) (map[string]string, error) {
+	passwords := make(map[string]string)
+	for _, conn := range extConnections {
+		if conn.PasswordFile == "" {
+			continue
+		}
+
+		content, err := os.ReadFile(conn.PasswordFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read BGP password of the interface '%s': %w", conn.Iface, err)
+		}
+
+		password := strings.TrimRight(string(content), "\r\n")
+		if password == "" || strings.ContainsAny(password, "\"\\\n") {
+			return nil, fmt.Errorf(
+				"BGP password file '%s' must contain a single line without quotes and backslashes", conn.PasswordFile,
+			)
+		}
+		passwords[conn.Iface] = password
+	}
+	return passwords, nil
+}
@@ -0,0 +1,170 @@
+package bgp
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/canonical/microovn/microovn/api/types"
+)
+
+// renderBirdConf renders Bird configuration for a single external connection "eth1" with the provided
+// RPKI settings and session options.
+func renderBirdConf(t *testing.T, caches []types.BgpRpkiCache, policy string, session types.BgpSessionOptions) string {
+	var buffer bytes.Buffer
+	err := birdConfTemplate.Execute(&buffer, birdTemplateInput{
+		VrfTableID:     "10",
+		VrfName:        "ovnvrf10",
+		RouterID:       "192.0.2.10",
+		ExtConnections: []types.BgpExternalConnection{{Iface: "eth1", BgpSessionOptions: session}},
+		RedirectIfaces: map[string]redirectIfaces{"eth1": {Redirect: "veth1-bgp"}},
+		ASN:            "4210000000",
+		RpkiCaches:     caches,
+		RpkiPolicy:     policy,
+	})
+	if err != nil {
+		t.Fatalf("Failed to render Bird configuration: %v", err)
+	}
+	return buffer.String()
+}
+
+func TestUnexported_birdConfTemplateRpki(t *testing.T) {
+	config := renderBirdConf(t, nil, "", types.BgpSessionOptions{})
+	for _, unexpected := range []string{"rpki", "roa"} {
+		if strings.Contains(config, unexpected) {
+			t.Errorf("Bird configuration without RPKI caches contains '%s':\n%s", unexpected, config)
+		}
+	}
+	if strings.Count(config, "import all;") != 2 {
+		t.Errorf("Bird configuration without RPKI caches doesn't import all routes:\n%s", config)
+	}
+
+	// RTR cache stand-ins listening locally
+	caches := []types.BgpRpkiCache{{Host: "127.0.0.1", Port: 3323}, {Host: "::1", Port: 323}}
+	testCases := map[string][]string{
+		types.RpkiPolicyReject: {
+			"then reject;",
+		},
+		types.RpkiPolicyDeprioritize: {
+			"preference = 10;",
+			"bgp_local_pref = 10;",
+		},
+		types.RpkiPolicyAccept: {},
+	}
+
+	for policy, expectedActions := range testCases {
+		config = renderBirdConf(t, caches, policy, types.BgpSessionOptions{})
+		expected := append([]string{
+			"roa4 table microovn_roa4;",
+			"roa6 table microovn_roa6;",
+			"protocol rpki microovn_rpki_0 {",
+			`remote "127.0.0.1" port 3323;`,
+			"protocol rpki microovn_rpki_1 {",
+			`remote "::1" port 323;`,
+			"import filter rpki_import_v4;",
+			"import filter rpki_import_v6;",
+			"import keep filtered on;",
+		}, expectedActions...)
+
+		for _, line := range expected {
+			if !strings.Contains(config, line) {
+				t.Errorf("Bird configuration with '%s' RPKI policy is missing '%s':\n%s", policy, line, config)
+			}
+		}
+
+		if strings.Contains(config, "import all;") {
+			t.Errorf("Bird configuration with '%s' RPKI policy imports all routes:\n%s", policy, config)
+		}
+
+		if policy == types.RpkiPolicyAccept && strings.Contains(config, "ROA_INVALID") {
+			t.Errorf("Bird configuration with '%s' RPKI policy acts on invalid routes:\n%s", policy, config)
+		}
+	}
+}
+
+func TestUnexported_birdConfTemplateSessionOptions(t *testing.T) {
+	config := renderBirdConf(t, nil, "", types.BgpSessionOptions{LimitAction: types.LimitActionBlock})
+	for _, unexpected := range []string{"limit", "hold time", "keepalive time", "ttl security", "error wait time"} {
+		if strings.Contains(config, unexpected) {
+			t.Errorf("Bird configuration without session options contains '%s':\n%s", unexpected, config)
+		}
+	}
+
+	config = renderBirdConf(t, nil, "", types.BgpSessionOptions{
+		ReceiveLimit:      1000,
+		ExportLimit:       10,
+		LimitAction:       types.LimitActionRestart,
+		LimitRestartDelay: 120,
+		TTLSecurity:       true,
+		HoldTime:          9,
+		KeepaliveTime:     3,
+	})
+	expected := map[string]int{
+		"receive limit 1000 action restart;": 2,
+		"export limit 10 action restart;":    2,
+		"error wait time 120,120;":           1,
+		"ttl security on;":                   1,
+		"hold time 9;":                       1,
+		"keepalive time 3;":                  1,
+	}
+	for line, count := range expected {
+		if strings.Count(config, line) != count {
+			t.Errorf("Expected Bird configuration to contain '%s' %d times:\n%s", line, count, config)
+		}
+	}
+}
+
+func TestUnexported_birdConfTemplateConnectionOverrides(t *testing.T) {
+	var buffer bytes.Buffer
+	err := birdConfTemplate.Execute(&buffer, birdTemplateInput{
+		VrfTableID: "10",
+		VrfName:    "ovnvrf10",
+		RouterID:   "192.0.2.10",
+		ExtConnections: []types.BgpExternalConnection{
+			{
+				Iface:       "eth1",
+				LocalAsn:    "65001",
+				Description: "Provider A",
+				BgpSessionOptions: types.BgpSessionOptions{
+					ReceiveLimit: 1000,
+					LimitAction:  types.LimitActionRestart,
+					HoldTime:     30,
+				},
+			},
+			{Iface: "eth2", BgpSessionOptions: types.BgpSessionOptions{LimitAction: types.LimitActionBlock, HoldTime: 9, TTLSecurity: true}},
+		},
+		RedirectIfaces: map[string]redirectIfaces{"eth1": {Redirect: "veth1-bgp"}, "eth2": {Redirect: "veth2-bgp"}},
+		ASN:            "4210000000",
+		Passwords:      map[string]string{"eth1": "secret"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to render Bird configuration: %v", err)
+	}
+
+	protocols := strings.Split(buffer.String(), "protocol bgp ")
+	if len(protocols) != 3 {
+		t.Fatalf("Expected Bird configuration with two BGP protocols:\n%s", buffer.String())
+	}
+
+	expected := map[string][]string{
+		"microovn_eth1": {"local as 65001;", `description "Provider A";`, `password "secret";`, "hold time 30;", "receive limit 1000 action restart;"},
+		"microovn_eth2": {"local as 4210000000;", "hold time 9;", "ttl security on;"},
+	}
+	for _, protocol := range protocols[1:] {
+		name := strings.Fields(protocol)[0]
+		for _, line := range expected[name] {
+			if !strings.Contains(protocol, line) {
+				t.Errorf("BGP protocol %s is missing '%s':\n%s", name, line, protocol)
+			}
+		}
+	}
+
+	if strings.Contains(protocols[1], "ttl security") {
+		t.Errorf("BGP protocol without TTL security enables it:\n%s", protocols[1])
+	}
+
+	if strings.Count(protocols[2], "description") != 0 || strings.Count(protocols[2], "password") != 0 ||
+		strings.Count(protocols[2], "limit") != 0 {
+		t.Errorf("BGP protocol without overrides contains settings of other connection:\n%s", protocols[2])
+	}
+}
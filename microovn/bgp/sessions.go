@@ -0,0 +1,68 @@
+package bgp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/canonical/microovn/microovn/api/types"
+)
+
+// dynamicProtocolPrefix - prefix of the names of BGP protocols that Bird spawns for each peer that
+// connects to one of the MicroOVN managed BGP listeners.
+const dynamicProtocolPrefix = "dyn_microovn_"
+
+// SessionSummary queries the local Bird daemon and returns the number of BGP sessions with peers on
+// the external connections, the number of those sessions that are established and the number of
+// sessions that hit one of their prefix limits.
+func SessionSummary(ctx context.Context) (*types.BgpSessionSummary, error) {
+	out, err := runBirdc(ctx, "show", "protocols", "all")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Bird protocols: %w", err)
+	}
+
+	return parseSessionSummary(out), nil
+}
+
+// parseSessionSummary counts MicroOVN BGP sessions in the output of "birdc show protocols [all]" command.
+//
+// A session hit its prefix limit if it was shut down because of the limit (with "restart" limit action),
+// or if one of its channels reports the limit as hit (with "block" limit action).
+func parseSessionSummary(output string) *types.BgpSessionSummary {
+	summary := &types.BgpSessionSummary{}
+	inSession := false
+	limitHit := false
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		// Details of the protocols are indented below the protocol's line
+		if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+			if inSession && !limitHit && strings.Contains(line, "limit:") && strings.Contains(line, "[HIT]") {
+				limitHit = true
+				summary.LimitHit++
+			}
+			continue
+		}
+
+		inSession = len(fields) >= 2 && strings.HasPrefix(fields[0], dynamicProtocolPrefix) && fields[1] == "BGP"
+		limitHit = false
+		if !inSession {
+			continue
+		}
+
+		summary.Total++
+		if fields[len(fields)-1] == "Established" {
+			summary.Established++
+		}
+
+		if strings.Contains(strings.ToLower(line), "limit exceeded") {
+			limitHit = true
+			summary.LimitHit++
+		}
+	}
+
+	return summary
+}
@@ -0,0 +1,58 @@
+package bgp
+
+import (
+	"testing"
+)
+
+func TestUnexported_parseSessionSummary(t *testing.T) {
+	output := `BIRD 2.15.1 ready.
+Name       Proto      Table      State  Since         Info
+device1    Device     ---        up     10:21:12.531
+kernel4    Kernel     master4    up     10:21:12.531
+microovn_eth1 BGP     ---        start  10:21:12.531  Passive
+dyn_microovn_eth1_1 BGP ---      up     10:21:15.102  Established
+dyn_microovn_eth2_1 BGP ---      start  10:21:15.102  Connect
+`
+	summary := parseSessionSummary(output)
+	if summary.Total != 2 || summary.Established != 1 {
+		t.Errorf("Unexpected BGP session summary: %+v", summary)
+	}
+
+	summary = parseSessionSummary("")
+	if summary.Total != 0 || summary.Established != 0 {
+		t.Errorf("Unexpected BGP session summary for empty output: %+v", summary)
+	}
+}
+
+func TestUnexported_parseSessionSummaryLimits(t *testing.T) {
+	output := `BIRD 2.15.1 ready.
+Name       Proto      Table      State  Since         Info
+microovn_eth1 BGP     ---        start  10:21:12.531  Passive
+  BGP state:          Passive
+  Channel ipv4
+    Receive limit:  100 [HIT]
+      Action:       block
+dyn_microovn_eth1_1 BGP ---      up     10:21:15.102  Established
+  BGP state:          Established
+  Channel ipv4
+    State:          UP
+    Receive limit:  100 [HIT]
+      Action:       block
+    Routes:         100 imported, 0 filtered, 2 exported, 100 preferred
+  Channel ipv6
+    State:          UP
+    Receive limit:  100 [HIT]
+      Action:       block
+dyn_microovn_eth2_1 BGP ---      start  10:21:15.102  Idle          Automatic shutdown: Route limit exceeded
+  BGP state:          Idle
+dyn_microovn_eth3_1 BGP ---      up     10:21:15.102  Established
+  BGP state:          Established
+  Channel ipv4
+    Receive limit:  100
+      Action:       block
+`
+	summary := parseSessionSummary(output)
+	if summary.Total != 3 || summary.Established != 2 || summary.LimitHit != 2 {
+		t.Errorf("Unexpected BGP session summary: %+v", summary)
+	}
+}
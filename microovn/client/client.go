@@ -5,7 +5,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/canonical/lxd/shared/api"
@@ -113,6 +116,83 @@ func GetCaInfo(ctx context.Context, c *client.Client) (types.CaInfo, error) {
 	return response, err
 }
 
+// GetTrustedCAs returns additional CA certificates that are trusted to issue client certificates for the OVN
+// Northbound and Southbound databases.
+func GetTrustedCAs(ctx context.Context, c *client.Client) ([]types.TrustedCa, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	response := []types.TrustedCa{}
+
+	err := c.Query(queryCtx, "GET", types.APIVersion, api.NewURL().Path("ca", "trust"), nil, &response)
+	if err != nil {
+		return response, fmt.Errorf("failed to get trusted client CA certificates: %w", err)
+	}
+
+	return response, nil
+}
+
+// AddTrustedCAs sends request to add PEM encoded CA certificates to the bundle of trusted client CAs. The
+// updated bundle is distributed to all MicroOVN cluster members.
+func AddTrustedCAs(ctx context.Context, c *client.Client, certPEM string) (types.TrustedCaResponse, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	response := types.TrustedCaResponse{}
+	request := types.TrustedCaRequest{Certificate: certPEM}
+
+	err := c.Query(queryCtx, "POST", types.APIVersion, api.NewURL().Path("ca", "trust"), request, &response)
+	if err != nil {
+		return response, fmt.Errorf("failed to add trusted client CA certificates: %w", err)
+	}
+
+	return response, nil
+}
+
+// RemoveTrustedCA sends request to remove CA certificate with the "fingerprint" from the bundle of trusted
+// client CAs. The updated bundle is distributed to all MicroOVN cluster members.
+func RemoveTrustedCA(ctx context.Context, c *client.Client, fingerprint string) (types.TrustedCaResponse, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	response := types.TrustedCaResponse{}
+
+	err := c.Query(queryCtx, "DELETE", types.APIVersion, api.NewURL().Path("ca", "trust", fingerprint), nil, &response)
+	if err != nil {
+		return response, fmt.Errorf("failed to remove trusted client CA certificate: %w", err)
+	}
+
+	return response, nil
+}
+
+// RefreshTrustedCAs requests MicroOVN cluster member to write the current bundle of trusted client CAs to
+// the file used by its OVN database servers.
+func RefreshTrustedCAs(ctx context.Context, c *client.Client) error {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	err := c.Query(queryCtx, "PUT", types.APIVersion, api.NewURL().Path("ca", "trust"), nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to refresh trusted client CA certificates: %w", err)
+	}
+
+	return nil
+}
+
+// RefreshEncapOptions requests MicroOVN cluster member to apply its tunnel encapsulation options to the
+// local Open vSwitch.
+func RefreshEncapOptions(ctx context.Context, c *client.Client) error {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	err := c.Query(queryCtx, "PUT", types.APIVersion, api.NewURL().Path("switch", "encap"), nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to update tunnel encapsulation options: %w", err)
+	}
+
+	return nil
+}
+
 // GetExpectedOvsdbSchemaVersion queries given MicroOVN node and returns an expected schema version for the specified
 // database. This is not necessarily the schema version that's being used by currently running OVN/OVS processes on the
 // node. Rather it's a version of a schema that was supplied with currently installed OVN/OVS packages on the node.
@@ -145,6 +225,22 @@ func GetActiveOvsdbSchemaVersion(ctx context.Context, c *client.Client, dbSpec *
 	return getOvsdbSchemaVersion(ctx, c, dbSpec, "active")
 }
 
+// GetOvsdbSchemaDiff returns differences between the schema of the running database "db" ("nb", "sb" or
+// "switch") and the schema shipped with OVN/OVS packages.
+func GetOvsdbSchemaDiff(ctx context.Context, c *client.Client, db string) (types.OvsdbSchemaDiff, error) {
+	var response types.OvsdbSchemaDiff
+
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	err := c.Query(queryCtx, "GET", types.APIVersion, api.NewURL().Path("ovsdb", "schema", db, "diff"), nil, &response)
+	if err != nil {
+		return response, fmt.Errorf("failed to get %s schema diff: %w", db, err)
+	}
+
+	return response, nil
+}
+
 // getOvsdbSchemaVersion is a general function that is used to fetch OVSDB schema version via MicroOVN API. It targets
 // /1.0/ovsdb/schema/<db-name>/<target> endpoints, where <db-name> is ovnCmd.OvsdbSpec.ShortName and <target> is
 // either "active", "expected", or other variations that MicroOVN API supports.
@@ -230,37 +326,380 @@ func RegenerateEnvironment(ctx context.Context, c *client.Client) (types.Regener
 }
 
 // SetConfig sends a request to the MicroOVN server that sets or updates a value of a configuration option.
-func SetConfig(ctx context.Context, c *client.Client, key string, value string) (types.SetConfigResponse, error) {
+// Argument "target" selects the cluster member that handles member specific options (empty for local member).
+func SetConfig(ctx context.Context, c *client.Client, key string, value string, target string) (types.SetConfigResponse, error) {
 	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
 	defer cancel()
 
 	requestData := types.SetConfigRequest{Key: key, Value: value}
 	responseData := types.SetConfigResponse{}
-	err := c.Query(queryCtx, "POST", types.APIVersion, api.NewURL().Path("config"), requestData, &responseData)
+	err := c.Query(queryCtx, "POST", types.APIVersion, api.NewURL().Path("config").Target(target), requestData, &responseData)
 
 	return responseData, err
 }
 
 // GetConfig sends a request to the MicroOVN server that retrieves the current value of a configuration option.
-func GetConfig(ctx context.Context, c *client.Client, key string) (types.GetConfigResponse, error) {
+// Argument "target" selects the cluster member that handles member specific options (empty for local member).
+func GetConfig(ctx context.Context, c *client.Client, key string, target string) (types.GetConfigResponse, error) {
 	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
 	defer cancel()
 
 	requestData := types.GetConfigRequest{Key: key}
 	responseData := types.GetConfigResponse{}
-	err := c.Query(queryCtx, "GET", types.APIVersion, api.NewURL().Path("config"), requestData, &responseData)
+	err := c.Query(queryCtx, "GET", types.APIVersion, api.NewURL().Path("config").Target(target), requestData, &responseData)
 
 	return responseData, err
 }
 
 // DeleteConfig sends a request to the MicroOVN server that completely removes a configuration option and its value.
-func DeleteConfig(ctx context.Context, c *client.Client, key string) (types.DeleteConfigResponse, error) {
+// Argument "target" selects the cluster member that handles member specific options (empty for local member).
+func DeleteConfig(ctx context.Context, c *client.Client, key string, target string) (types.DeleteConfigResponse, error) {
 	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
 	defer cancel()
 
 	requestData := types.DeleteConfigRequest{Key: key}
 	responseData := types.DeleteConfigResponse{}
-	err := c.Query(queryCtx, "DELETE", types.APIVersion, api.NewURL().Path("config"), requestData, &responseData)
+	err := c.Query(queryCtx, "DELETE", types.APIVersion, api.NewURL().Path("config").Target(target), requestData, &responseData)
 
 	return responseData, err
 }
+
+// GetSbConnections queries given MicroOVN node and returns number of clients connected to the OVN Southbound
+// database server running on that node.
+func GetSbConnections(ctx context.Context, c *client.Client) (int, error) {
+	var response int
+
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	err := c.Query(queryCtx, "GET", types.APIVersion, api.NewURL().Path("ovsdb", "sb", "connections"), nil, &response)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get number of OVN Southbound database connections: %w", err)
+	}
+
+	return response, nil
+}
+
+// GetAllSbConnections returns types.SbConnectionsReport. It is a list containing every OVN Southbound
+// database server in the MicroOVN deployment and number of clients connected to it.
+func GetAllSbConnections(ctx context.Context, c *client.Client) (types.SbConnectionsReport, error) {
+	var response types.SbConnectionsReport
+
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	err := c.Query(queryCtx, "GET", types.APIVersion, api.NewURL().Path("ovsdb", "sb", "connections", "all"), nil, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get OVN Southbound database connections from cluster: %w", err)
+	}
+
+	return response, nil
+}
+
+// GetOvsdbCheck queries given MicroOVN node and returns results of the integrity checks of the OVN
+// Northbound and Southbound databases running on that node. If "ignoredPeer" is not empty, missing RAFT
+// connection to the member with this address is not reported as a problem.
+func GetOvsdbCheck(ctx context.Context, c *client.Client, ignoredPeer string) (types.OvsdbCheckReport, error) {
+	var response types.OvsdbCheckReport
+
+	queryCtx, cancel := context.WithTimeout(ctx, time.Minute*2)
+	defer cancel()
+
+	url := api.NewURL().Path("ovsdb", "check")
+	if ignoredPeer != "" {
+		url = url.WithQuery("ignore-peer", ignoredPeer)
+	}
+
+	err := c.Query(queryCtx, "GET", types.APIVersion, url, nil, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check OVN databases: %w", err)
+	}
+
+	return response, nil
+}
+
+// GetAllOvsdbChecks returns types.OvsdbCheckReport. It contains results of the integrity checks of the OVN
+// Northbound and Southbound databases on every MicroOVN cluster member that runs them.
+func GetAllOvsdbChecks(ctx context.Context, c *client.Client) (types.OvsdbCheckReport, error) {
+	var response types.OvsdbCheckReport
+
+	queryCtx, cancel := context.WithTimeout(ctx, time.Minute*3)
+	defer cancel()
+
+	err := c.Query(queryCtx, "GET", types.APIVersion, api.NewURL().Path("ovsdb", "check", "all"), nil, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check OVN databases in the cluster: %w", err)
+	}
+
+	return response, nil
+}
+
+// ReseedOvsdb requests the "target" member to replace its OVN Northbound and Southbound databases with
+// fresh copies from healthy cluster peers. It returns once the databases caught up with the cluster.
+func ReseedOvsdb(ctx context.Context, c *client.Client, target string) error {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Minute*10)
+	defer cancel()
+
+	err := c.Query(queryCtx, "PUT", types.APIVersion, api.NewURL().Path("ovsdb", "reseed").Target(target), nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to reseed OVN databases on '%s': %w", target, err)
+	}
+
+	return nil
+}
+
+// ReissueClusterCertificates sends request to re-issue certificates on every MicroOVN cluster member. If
+// "service" is not empty, only certificates for that service are re-issued. Services that use re-issued
+// certificates are restarted in a rolling manner.
+func ReissueClusterCertificates(ctx context.Context, c *client.Client, service string) (types.ReissueClusterCertificatesResponse, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Minute*10)
+	defer cancel()
+
+	request := types.ReissueClusterCertificatesRequest{Service: service}
+	response := types.NewReissueClusterCertificatesResponse()
+
+	err := c.Query(queryCtx, "PUT", types.APIVersion, api.NewURL().Path("cluster", "certificates"), request, &response)
+	if err != nil {
+		return *response, fmt.Errorf("failed to reissue certificates in the cluster: %w", err)
+	}
+
+	return *response, nil
+}
+
+// ConvertStandaloneDatabases requests given MicroOVN node to convert its standalone OVN Northbound and
+// Southbound databases to clustered databases.
+func ConvertStandaloneDatabases(ctx context.Context, c *client.Client) error {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Minute*2)
+	defer cancel()
+
+	err := c.Query(queryCtx, "PUT", types.APIVersion, api.NewURL().Path("ovsdb", "convert-to-cluster"), nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to convert standalone OVN databases: %w", err)
+	}
+
+	return nil
+}
+
+// GetBgpRedirectInterfaces returns names of the system interfaces used for BGP redirect on every
+// MicroOVN cluster member.
+func GetBgpRedirectInterfaces(ctx context.Context, c *client.Client) (types.BgpRedirectInterfaces, error) {
+	var response types.BgpRedirectInterfaces
+
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	err := c.Query(queryCtx, "GET", types.APIVersion, api.NewURL().Path("bgp", "interfaces"), nil, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get BGP redirect interfaces: %w", err)
+	}
+
+	return response, nil
+}
+
+// GetBgpRoutes returns routes learned by the BGP daemon, together with their RPKI origin validation state,
+// on the cluster member selected by "target" (empty for local member).
+func GetBgpRoutes(ctx context.Context, c *client.Client, target string) (types.BgpRouteStatus, error) {
+	var response types.BgpRouteStatus
+
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	err := c.Query(queryCtx, "GET", types.APIVersion, api.NewURL().Path("bgp", "routes").Target(target), nil, &response)
+	if err != nil {
+		return response, fmt.Errorf("failed to get BGP routes: %w", err)
+	}
+
+	return response, nil
+}
+
+// GetMemberFacts returns facts most recently published by every MicroOVN cluster member.
+func GetMemberFacts(ctx context.Context, c *client.Client) (types.MemberFactsList, error) {
+	var response types.MemberFactsList
+
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	err := c.Query(queryCtx, "GET", types.APIVersion, api.NewURL().Path("facts"), nil, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get member facts: %w", err)
+	}
+
+	return response, nil
+}
+
+// GetSwitchInventory returns bridges, ports and interfaces configured in the Open vSwitch running on
+// the cluster member selected by "target" (empty for local member).
+func GetSwitchInventory(ctx context.Context, c *client.Client, target string) (types.SwitchInventory, error) {
+	var response types.SwitchInventory
+
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	err := c.Query(queryCtx, "GET", types.APIVersion, api.NewURL().Path("switch", "inventory").Target(target), nil, &response)
+	if err != nil {
+		return response, fmt.Errorf("failed to get OVS inventory: %w", err)
+	}
+
+	return response, nil
+}
+
+// GetDatapathStats returns datapath flow and conntrack statistics of the Open vSwitch running on
+// the cluster member selected by "target" (empty for local member).
+func GetDatapathStats(ctx context.Context, c *client.Client, target string) (types.DatapathStats, error) {
+	var response types.DatapathStats
+
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+
+	err := c.Query(queryCtx, "GET", types.APIVersion, api.NewURL().Path("switch", "datapath").Target(target), nil, &response)
+	if err != nil {
+		return response, fmt.Errorf("failed to get datapath statistics: %w", err)
+	}
+
+	return response, nil
+}
+
+// GetHostPorts returns host management ports of the cluster member selected by "target" (empty for local
+// member).
+func GetHostPorts(ctx context.Context, c *client.Client, target string) ([]types.HostPort, error) {
+	var response []types.HostPort
+
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	err := c.Query(queryCtx, "GET", types.APIVersion, api.NewURL().Path("host-ports").Target(target), nil, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list host ports: %w", err)
+	}
+
+	return response, nil
+}
+
+// AddHostPort creates host management port on the cluster member selected by "target" (empty for local
+// member), that connects the member to the Logical Switch from the "request".
+func AddHostPort(ctx context.Context, c *client.Client, target string, request types.HostPortRequest) (types.HostPort, error) {
+	var response types.HostPort
+
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*60)
+	defer cancel()
+
+	err := c.Query(queryCtx, "POST", types.APIVersion, api.NewURL().Path("host-ports").Target(target), request, &response)
+	if err != nil {
+		return response, fmt.Errorf("failed to add host port: %w", err)
+	}
+
+	return response, nil
+}
+
+// RemoveHostPort removes host management port from the "logicalSwitch" on the cluster member selected by
+// "target" (empty for local member).
+func RemoveHostPort(ctx context.Context, c *client.Client, target string, logicalSwitch string) error {
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*60)
+	defer cancel()
+
+	err := c.Query(queryCtx, "DELETE", types.APIVersion, api.NewURL().Path("host-ports", logicalSwitch).Target(target), nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to remove host port: %w", err)
+	}
+
+	return nil
+}
+
+// CheckMaintenance asks the local MicroOVN daemon whether the disruptive "job" is allowed to run now,
+// considering configured maintenance windows. Zero "deadline" means that the job never becomes urgent.
+func CheckMaintenance(ctx context.Context, c *client.Client, job string, deadline time.Time) (types.MaintenanceCheckResult, error) {
+	var response types.MaintenanceCheckResult
+	request := types.MaintenanceCheck{Job: job, Deadline: deadline}
+
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	err := c.Query(queryCtx, "POST", types.APIVersion, api.NewURL().Path("maintenance", "check"), request, &response)
+	if err != nil {
+		return response, fmt.Errorf("failed to check maintenance windows: %w", err)
+	}
+
+	return response, nil
+}
+
+// ExportTopology returns logical objects selected by the "request" from the OVN Northbound database.
+func ExportTopology(ctx context.Context, c *client.Client, request types.TopologyExportRequest) (types.TopologyDocument, error) {
+	var response types.TopologyDocument
+
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*60)
+	defer cancel()
+
+	err := c.Query(queryCtx, "POST", types.APIVersion, api.NewURL().Path("ovn", "export"), request, &response)
+	if err != nil {
+		return response, fmt.Errorf("failed to export OVN topology: %w", err)
+	}
+
+	return response, nil
+}
+
+// ImportTopology creates logical objects from the "document" in the OVN Northbound database.
+func ImportTopology(ctx context.Context, c *client.Client, document types.TopologyDocument) (types.TopologyImportResult, error) {
+	var response types.TopologyImportResult
+
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*60)
+	defer cancel()
+
+	err := c.Query(queryCtx, "POST", types.APIVersion, api.NewURL().Path("ovn", "import"), document, &response)
+	if err != nil {
+		return response, fmt.Errorf("failed to import OVN topology: %w", err)
+	}
+
+	return response, nil
+}
+
+// GetTopologyGraph returns graph of logical and physical objects from the OVN Northbound and Southbound
+// databases. If "router" is not empty, only the neighborhood of the router, up to "depth" edges away from it,
+// is returned.
+func GetTopologyGraph(ctx context.Context, c *client.Client, router string, depth int) (types.TopologyGraph, error) {
+	var response types.TopologyGraph
+
+	queryCtx, cancel := context.WithTimeout(ctx, time.Second*60)
+	defer cancel()
+
+	url := api.NewURL().Path("ovn", "topology")
+	if router != "" {
+		url = url.WithQuery("router", router).WithQuery("depth", strconv.Itoa(depth))
+	}
+
+	err := c.Query(queryCtx, "GET", types.APIVersion, url, nil, &response)
+	if err != nil {
+		return response, fmt.Errorf("failed to get OVN topology graph: %w", err)
+	}
+
+	return response, nil
+}
+
+// WaitForRealization requests MicroOVN to increment the "nb_cfg" sequence number in the OVN Northbound
+// database and wait until all changes made so far are realized at the "level" ("sb" or "hv"). Changes
+// that are not realized within the "timeout" are reported as an error that lists lagging chassis.
+func WaitForRealization(ctx context.Context, c *client.Client, level string, timeout time.Duration) (types.RealizationStatus, error) {
+	var response types.RealizationStatus
+
+	queryCtx, cancel := context.WithTimeout(ctx, timeout+time.Second*30)
+	defer cancel()
+
+	// The timeout is sent in whole seconds, round it up so that sub-second timeouts are not rejected
+	request := types.RealizationRequest{Level: level, Timeout: int(math.Ceil(timeout.Seconds()))}
+	err := c.Query(queryCtx, "PUT", types.APIVersion, api.NewURL().Path("ovn", "realization"), request, &response)
+	if err != nil {
+		return response, fmt.Errorf("failed to wait for realization of OVN changes: %w", err)
+	}
+
+	if !response.Realized {
+		lagging := make([]string, 0, len(response.Lagging))
+		for _, chassis := range response.Lagging {
+			lagging = append(lagging, chassis.Chassis)
+		}
+		if len(lagging) > 0 {
+			return response, fmt.Errorf("OVN changes were not realized within %s, lagging chassis: %s", timeout, strings.Join(lagging, ", "))
+		}
+		return response, fmt.Errorf("OVN changes were not realized within %s", timeout)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+type cmdBgp struct {
+	common *CmdControl
+}
+
+// Command returns definition for "microovn bgp" subcommand
+func (c *cmdBgp) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bgp",
+		Short: "Inspect BGP service managed by MicroOVN",
+	}
+
+	bgpRoutesCmd := &cmdBgpRoutes{common: c.common, bgp: c}
+	cmd.AddCommand(bgpRoutesCmd.Command())
+
+	return cmd
+}
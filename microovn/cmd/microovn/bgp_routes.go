@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	lxdCmd "github.com/canonical/lxd/shared/cmd"
+	"github.com/canonical/lxd/shared/i18n"
+	"github.com/canonical/microcluster/v2/microcluster"
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/client"
+)
+
+type cmdBgpRoutes struct {
+	common     *CmdControl
+	bgp        *cmdBgp
+	flagMember string
+	flagFormat string
+}
+
+// Command returns definition for "microovn bgp routes" subcommand
+func (c *cmdBgpRoutes) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "routes",
+		Short: "Show routes learned from BGP peers and their RPKI validation state",
+		Args:  cobra.NoArgs,
+		RunE:  c.Run,
+	}
+
+	cmd.Flags().StringVar(
+		&c.flagMember,
+		"member",
+		"",
+		"Optional name of the cluster member to inspect (local member by default)",
+	)
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "table", i18n.G("Format (csv|json|table|yaml|compact)")+"``")
+
+	return cmd
+}
+
+// Run method is an implementation of the "microovn bgp routes" subcommand
+func (c *cmdBgpRoutes) Run(_ *cobra.Command, _ []string) error {
+	m, err := microcluster.App(microcluster.Args{StateDir: c.common.FlagStateDir})
+	if err != nil {
+		return err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	status, err := client.GetBgpRoutes(context.Background(), cli, c.flagMember)
+	if err != nil {
+		return err
+	}
+
+	header := []string{"PREFIX", "ORIGIN AS", "SESSION", "VALIDATION", "STATE"}
+	data := make([][]string, 0, len(status.Routes))
+	for _, route := range status.Routes {
+		data = append(data, []string{route.Prefix, route.OriginAS, route.Protocol, formatRouteValidation(route), formatRouteState(route)})
+	}
+
+	if c.flagFormat != lxdCmd.TableFormatTable {
+		return lxdCmd.RenderTable(c.flagFormat, header, data, status)
+	}
+
+	fmt.Printf("Member: %s\n", status.Member)
+	if len(status.RpkiCaches) == 0 {
+		fmt.Println("RPKI: not configured")
+	} else {
+		fmt.Println("RPKI caches:")
+	}
+	for _, cache := range status.RpkiCaches {
+		fmt.Printf("    %s: %s %s\n", cache.Name, cache.State, cache.Info)
+	}
+
+	return lxdCmd.RenderTable(c.flagFormat, header, data, status)
+}
+
+// formatRouteValidation returns human-readable RPKI validation state of the route.
+func formatRouteValidation(route types.BgpRoute) string {
+	if route.Validation == "" {
+		return "-"
+	}
+	return route.Validation
+}
+
+// formatRouteState returns human-readable state of the route in the routing table.
+func formatRouteState(route types.BgpRoute) string {
+	switch {
+	case route.Filtered:
+		return "rejected"
+	case route.Preferred:
+		return "preferred"
+	default:
+		return "alternative"
+	}
+}
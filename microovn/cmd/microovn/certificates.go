@@ -27,5 +27,8 @@ func (c *cmdCertificates) Command() *cobra.Command {
 	certificatesSetCustmCa := cmdCertificatesSetCA{common: c.common, certificates: c}
 	cmd.AddCommand(certificatesSetCustmCa.Command())
 
+	certificatesTrustCmd := cmdCertificatesTrust{common: c.common, certificates: c}
+	cmd.AddCommand(certificatesTrustCmd.Command())
+
 	return cmd
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/canonical/microovn/microovn/api/types"
 	"github.com/canonical/microovn/microovn/client"
 	"github.com/canonical/microovn/microovn/ovn/paths"
+	"github.com/canonical/microovn/microovn/ovn/vswitch"
 	"github.com/spf13/cobra"
 )
 
@@ -36,12 +37,13 @@ type certBundle struct {
 
 // ovnCertificatePaths is structure that holds paths to all certificates used by OVN
 type ovnCertificatePaths struct {
-	Ca      *caCertInfo `json:"ca"`
-	Nb      *certBundle `json:"ovnnb"`
-	Sb      *certBundle `json:"ovnsb"`
-	Northd  *certBundle `json:"ovn-northd"`
-	Chassis *certBundle `json:"ovn-controller"`
-	Client  *certBundle `json:"client"`
+	Ca         *caCertInfo `json:"ca"`
+	Nb         *certBundle `json:"ovnnb"`
+	Sb         *certBundle `json:"ovnsb"`
+	Northd     *certBundle `json:"ovn-northd"`
+	Chassis    *certBundle `json:"ovn-controller"`
+	Client     *certBundle `json:"client"`
+	OvsManager *certBundle `json:"ovs-manager,omitempty"`
 }
 
 var outputFormats = []string{"text", "json"}
@@ -123,6 +125,17 @@ func (c *cmdCertificatesList) Run(cmd *cobra.Command, _ []string) error {
 			ctlCert, ctlKey := paths.PkiOvnControllerCertFiles()
 			expectedCertificates.Chassis = &certBundle{ctlCert, ctlKey}
 		}
+		if srv.Service == types.SrvSwitch {
+			remoteManager, err := client.GetConfig(context.Background(), cli, vswitch.RemoteManagerConfigKey, "")
+			if err != nil {
+				return err
+			}
+
+			if remoteManager.IsSet {
+				managerCert, managerKey := paths.PkiOvsManagerCertFiles()
+				expectedCertificates.OvsManager = &certBundle{managerCert, managerKey}
+			}
+		}
 		clientCert, clientKey := paths.PkiClientCertFiles()
 		expectedCertificates.Client = &certBundle{clientCert, clientKey}
 	}
@@ -168,6 +181,9 @@ func printOvnCertStatus(certificates *ovnCertificatePaths) {
 
 	fmt.Println("\n[Client]")
 	printCertBundleStatus(certificates.Client)
+
+	fmt.Println("\n[OVS Remote Manager]")
+	printCertBundleStatus(certificates.OvsManager)
 }
 
 // printCertBundleStatus prints status of individual files in certificate bundle
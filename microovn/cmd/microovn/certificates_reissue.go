@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/canonical/microcluster/v2/microcluster"
@@ -18,12 +19,15 @@ var validCertificates = []string{
 	"ovnsb",
 	"ovn-controller",
 	"ovn-northd",
+	"ovs-manager",
 	"all",
 }
 
 type cmdCertificatesReissue struct {
-	common       *CmdControl
-	certificates *cmdCertificates
+	common         *CmdControl
+	certificates   *cmdCertificates
+	flagAllMembers bool
+	flagService    string
 }
 
 // Command method returns definition for "microovn certificates reissue" subcommand
@@ -34,11 +38,23 @@ func (c *cmdCertificatesReissue) Command() *cobra.Command {
 			"Reissue certificate for specified SERVICE on the local node. (Valid service names: %s)",
 			strings.Join(validCertificates, ", "),
 		),
+		Long: "Reissue certificate for specified SERVICE on the local node.\n\n" +
+			"With --all-members, certificates are reissued on every member of the cluster and services that use\n" +
+			"them are restarted, one member at a time. SERVICE argument is not used in this mode, use --service\n" +
+			"to limit the reissue to a single service.",
 		ValidArgs: validCertificates,
-		Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
 		RunE:      c.Run,
 	}
 
+	cmd.Flags().BoolVar(&c.flagAllMembers, "all-members", false, "Reissue certificates on every member of the cluster")
+	cmd.Flags().StringVar(
+		&c.flagService,
+		"service",
+		"",
+		"Limit cluster-wide reissue to a single service (only applicable with --all-members)",
+	)
+
 	return cmd
 }
 
@@ -46,6 +62,19 @@ func (c *cmdCertificatesReissue) Command() *cobra.Command {
 // service to issue new certificate for selected OVN service.
 func (c *cmdCertificatesReissue) Run(_ *cobra.Command, args []string) error {
 	var response types.IssueCertificateResponse
+
+	if c.flagAllMembers {
+		return c.runAllMembers(args)
+	}
+
+	if c.flagService != "" {
+		return fmt.Errorf("--service can be used only together with --all-members")
+	}
+
+	if len(args) != 1 {
+		return fmt.Errorf("accepts 1 arg(s), received %d", len(args))
+	}
+
 	m, err := microcluster.App(microcluster.Args{StateDir: c.common.FlagStateDir})
 	if err != nil {
 		return err
@@ -71,3 +100,37 @@ func (c *cmdCertificatesReissue) Run(_ *cobra.Command, args []string) error {
 	response.PrettyPrint()
 	return nil
 }
+
+// runAllMembers implements "microovn certificates reissue --all-members". It requests every MicroOVN cluster member
+// to issue new certificates and to restart services that use them.
+func (c *cmdCertificatesReissue) runAllMembers(args []string) error {
+	if len(args) != 0 {
+		return fmt.Errorf("SERVICE argument can't be used together with --all-members, use --service instead")
+	}
+
+	service := c.flagService
+	if service == "all" {
+		service = ""
+	}
+	if service != "" && !slices.Contains(validCertificates, service) {
+		return fmt.Errorf("invalid service '%s'. (Valid service names: %s)", service, strings.Join(validCertificates, ", "))
+	}
+
+	m, err := microcluster.App(microcluster.Args{StateDir: c.common.FlagStateDir})
+	if err != nil {
+		return err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	response, err := client.ReissueClusterCertificates(context.Background(), cli, service)
+	if err != nil {
+		return fmt.Errorf("command failed: %s", err)
+	}
+
+	response.PrettyPrint()
+	return nil
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/api/types"
+)
+
+type cmdCertificatesTrust struct {
+	common       *CmdControl
+	certificates *cmdCertificates
+}
+
+// Command returns definition for "microovn certificates trust" subcommand
+func (c *cmdCertificatesTrust) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "trust",
+		Short: "Manage additional CA certificates trusted to issue OVN database client certificates",
+		Long: `Manage additional CA certificates trusted to issue OVN database client certificates
+
+OVN Northbound and Southbound database servers accept clients with certificates
+issued by the MicroOVN CA. CA certificates added to the trust bundle are accepted
+as issuers of client certificates as well, which allows clients with their own
+PKI to connect without MicroOVN certificates. MicroOVN keeps issuing its own
+certificates with the MicroOVN CA.`,
+	}
+
+	certificatesTrustAddCmd := cmdCertificatesTrustAdd{common: c.common, trust: c}
+	cmd.AddCommand(certificatesTrustAddCmd.Command())
+
+	certificatesTrustRemoveCmd := cmdCertificatesTrustRemove{common: c.common, trust: c}
+	cmd.AddCommand(certificatesTrustRemoveCmd.Command())
+
+	certificatesTrustListCmd := cmdCertificatesTrustList{common: c.common, trust: c}
+	cmd.AddCommand(certificatesTrustListCmd.Command())
+
+	return cmd
+}
+
+// printTrustedCaResponse prints fingerprints of the CA certificates changed by the request "action" and
+// returns an error if the updated trust bundle failed to be distributed to some cluster members.
+func printTrustedCaResponse(response types.TrustedCaResponse, action string) error {
+	if len(response.Fingerprints) == 0 {
+		fmt.Println("No changes in trusted CA certificates")
+	}
+	for _, fingerprint := range response.Fingerprints {
+		fmt.Printf("%s CA certificate %s\n", action, fingerprint)
+	}
+
+	if len(response.Errors) != 0 {
+		for _, errMsg := range response.Errors {
+			fmt.Println(errMsg)
+		}
+		return fmt.Errorf("trust bundle was not updated on every cluster member")
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/canonical/microcluster/v2/microcluster"
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/client"
+)
+
+type cmdCertificatesTrustAdd struct {
+	common *CmdControl
+	trust  *cmdCertificatesTrust
+}
+
+// Command returns definition for "microovn certificates trust add" subcommand
+func (c *cmdCertificatesTrustAdd) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add <ca.pem>",
+		Short: "Trust CA certificates from the PEM file to issue OVN database client certificates",
+		Args:  cobra.ExactArgs(1),
+		RunE:  c.Run,
+	}
+
+	return cmd
+}
+
+// Run method is an implementation of the "microovn certificates trust add" subcommand
+func (c *cmdCertificatesTrustAdd) Run(_ *cobra.Command, args []string) error {
+	certData, err := os.ReadFile(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to read certificate file: %w", err)
+	}
+
+	m, err := microcluster.App(microcluster.Args{StateDir: c.common.FlagStateDir})
+	if err != nil {
+		return err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	response, err := client.AddTrustedCAs(context.Background(), cli, string(certData))
+	if err != nil {
+		return err
+	}
+
+	if len(response.Fingerprints) != 0 {
+		fmt.Fprintln(os.Stderr, "Warning: OVN database servers use the same CA certificates to verify their RAFT "+
+			"cluster peers, so holders of certificates issued by the trusted CAs can also connect to the RAFT ports")
+	}
+
+	return printTrustedCaResponse(response, "Trusted")
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	lxdCmd "github.com/canonical/lxd/shared/cmd"
+	"github.com/canonical/lxd/shared/i18n"
+	"github.com/canonical/microcluster/v2/microcluster"
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/client"
+)
+
+type cmdCertificatesTrustList struct {
+	common     *CmdControl
+	trust      *cmdCertificatesTrust
+	flagFormat string
+}
+
+// Command returns definition for "microovn certificates trust list" subcommand
+func (c *cmdCertificatesTrustList) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List CA certificates trusted to issue OVN database client certificates",
+		Args:  cobra.NoArgs,
+		RunE:  c.Run,
+	}
+
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "table", i18n.G("Format (csv|json|table|yaml|compact)")+"``")
+
+	return cmd
+}
+
+// Run method is an implementation of the "microovn certificates trust list" subcommand
+func (c *cmdCertificatesTrustList) Run(_ *cobra.Command, _ []string) error {
+	m, err := microcluster.App(microcluster.Args{StateDir: c.common.FlagStateDir})
+	if err != nil {
+		return err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	trustedCAs, err := client.GetTrustedCAs(context.Background(), cli)
+	if err != nil {
+		return err
+	}
+
+	data := make([][]string, len(trustedCAs))
+	for i, ca := range trustedCAs {
+		data[i] = []string{ca.Subject, ca.Fingerprint, ca.NotAfter.UTC().Format(time.RFC3339)}
+	}
+
+	header := []string{"SUBJECT", "FINGERPRINT", "EXPIRES"}
+	sort.Sort(lxdCmd.SortColumnsNaturally(data))
+
+	return lxdCmd.RenderTable(c.flagFormat, header, data, trustedCAs)
+}
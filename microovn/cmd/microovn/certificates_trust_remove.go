@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/canonical/microcluster/v2/microcluster"
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/client"
+	"github.com/canonical/microovn/microovn/ovn/certificates"
+)
+
+type cmdCertificatesTrustRemove struct {
+	common *CmdControl
+	trust  *cmdCertificatesTrust
+}
+
+// Command returns definition for "microovn certificates trust remove" subcommand
+func (c *cmdCertificatesTrustRemove) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove <ca.pem|fingerprint>",
+		Short: "Stop trusting CA certificates to issue OVN database client certificates",
+		Long: `Stop trusting CA certificates to issue OVN database client certificates
+
+The argument is either a path to the PEM file with CA certificates that were
+previously added, or a SHA-256 fingerprint of the CA certificate, as shown by
+"microovn certificates trust list".`,
+		Args: cobra.ExactArgs(1),
+		RunE: c.Run,
+	}
+
+	return cmd
+}
+
+// Run method is an implementation of the "microovn certificates trust remove" subcommand
+func (c *cmdCertificatesTrustRemove) Run(_ *cobra.Command, args []string) error {
+	fingerprints := []string{args[0]}
+
+	certData, err := os.ReadFile(args[0])
+	if err == nil {
+		caCerts, err := certificates.ParseCACertificates(string(certData))
+		if err != nil {
+			return err
+		}
+
+		fingerprints = fingerprints[:0]
+		for _, cert := range caCerts {
+			fingerprints = append(fingerprints, certificates.Fingerprint(cert))
+		}
+	}
+
+	m, err := microcluster.App(microcluster.Args{StateDir: c.common.FlagStateDir})
+	if err != nil {
+		return err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	var allErrors error
+	for _, fingerprint := range fingerprints {
+		response, err := client.RemoveTrustedCA(context.Background(), cli, fingerprint)
+		if err != nil {
+			allErrors = errors.Join(allErrors, err)
+			continue
+		}
+
+		allErrors = errors.Join(allErrors, printTrustedCaResponse(response, "Removed"))
+	}
+
+	return allErrors
+}
@@ -8,11 +8,15 @@ import (
 	"github.com/canonical/lxd/lxd/util"
 	"github.com/canonical/microcluster/v2/microcluster"
 	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/ovn/ovsdb"
 )
 
 type cmdClusterJoin struct {
 	common  *CmdControl
 	cluster *cmdCluster
+
+	flagAllowVersionSkew bool
 }
 
 func (c *cmdClusterJoin) Command() *cobra.Command {
@@ -23,6 +27,13 @@ func (c *cmdClusterJoin) Command() *cobra.Command {
 		RunE:  c.Run,
 	}
 
+	cmd.Flags().BoolVar(
+		&c.flagAllowVersionSkew,
+		ovsdb.AllowVersionSkewConfigKey,
+		false,
+		"Join even if MicroOVN, OVN or database schema versions are not compatible with the existing members",
+	)
+
 	return cmd
 }
 
@@ -42,5 +53,10 @@ func (c *cmdClusterJoin) Run(_ *cobra.Command, args []string) error {
 	address := util.NetworkInterfaceAddress()
 	address = util.CanonicalNetworkAddress(address, DefaultMicroClusterPort)
 
-	return m.JoinCluster(context.Background(), hostname, address, args[0], nil)
+	var initConfig map[string]string
+	if c.flagAllowVersionSkew {
+		initConfig = map[string]string{ovsdb.AllowVersionSkewConfigKey: "true"}
+	}
+
+	return m.JoinCluster(context.Background(), hostname, address, args[0], initConfig)
 }
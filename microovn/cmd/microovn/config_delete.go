@@ -10,8 +10,9 @@ import (
 )
 
 type cmdConfigDelete struct {
-	common *CmdControl
-	config *cmdConfig
+	common   *CmdControl
+	config   *cmdConfig
+	nodeName string
 }
 
 // Command returns definition for "microovn config delete" subcommand
@@ -22,6 +23,13 @@ func (c *cmdConfigDelete) Command() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE:  c.Run,
 	}
+
+	cmd.Flags().StringVar(
+		&c.nodeName,
+		"node",
+		"",
+		"Optional name of the node to target (applicable only to member specific options)",
+	)
 	return cmd
 }
 
@@ -39,7 +47,7 @@ func (c *cmdConfigDelete) Run(_ *cobra.Command, args []string) error {
 		return err
 	}
 
-	response, err := client.DeleteConfig(context.Background(), cli, key)
+	response, err := client.DeleteConfig(context.Background(), cli, key, c.nodeName)
 
 	if err != nil {
 		return fmt.Errorf("failed to delete config option '%s': %s", key, err)
@@ -10,8 +10,9 @@ import (
 )
 
 type cmdConfigGet struct {
-	common *CmdControl
-	config *cmdConfig
+	common   *CmdControl
+	config   *cmdConfig
+	nodeName string
 }
 
 // Command returns definition for "microovn config get" subcommand
@@ -22,6 +23,13 @@ func (c *cmdConfigGet) Command() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE:  c.Run,
 	}
+
+	cmd.Flags().StringVar(
+		&c.nodeName,
+		"node",
+		"",
+		"Optional name of the node to target (applicable only to member specific options)",
+	)
 	return cmd
 }
 
@@ -39,7 +47,7 @@ func (c *cmdConfigGet) Run(_ *cobra.Command, args []string) error {
 		return err
 	}
 
-	response, err := client.GetConfig(context.Background(), cli, key)
+	response, err := client.GetConfig(context.Background(), cli, key, c.nodeName)
 
 	if err != nil {
 		return fmt.Errorf("failed to get config option '%s': %s", key, err)
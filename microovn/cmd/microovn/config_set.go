@@ -10,8 +10,9 @@ import (
 )
 
 type cmdConfigSet struct {
-	common *CmdControl
-	config *cmdConfig
+	common   *CmdControl
+	config   *cmdConfig
+	nodeName string
 }
 
 // Command returns definition for "microovn config set" subcommand
@@ -22,6 +23,13 @@ func (c *cmdConfigSet) Command() *cobra.Command {
 		Args:  cobra.ExactArgs(2),
 		RunE:  c.Run,
 	}
+
+	cmd.Flags().StringVar(
+		&c.nodeName,
+		"node",
+		"",
+		"Optional name of the node to target (applicable only to member specific options)",
+	)
 	return cmd
 }
 
@@ -40,7 +48,7 @@ func (c *cmdConfigSet) Run(_ *cobra.Command, args []string) error {
 		return err
 	}
 
-	response, err := client.SetConfig(context.Background(), cli, key, value)
+	response, err := client.SetConfig(context.Background(), cli, key, value, c.nodeName)
 
 	if err != nil {
 		return fmt.Errorf("failed to set config option '%s': %s", key, err)
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+type cmdDebug struct {
+	common *CmdControl
+}
+
+// Command returns definition for "microovn debug" subcommand
+func (c *cmdDebug) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "debug",
+		Short: "Debugging and visualization helpers",
+	}
+
+	debugTopologyCmd := &cmdDebugTopology{common: c.common, debug: c}
+	cmd.AddCommand(debugTopologyCmd.Command())
+
+	return cmd
+}
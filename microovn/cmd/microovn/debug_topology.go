@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/canonical/microcluster/v2/microcluster"
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/client"
+	"github.com/canonical/microovn/microovn/ovn/topology"
+)
+
+type cmdDebugTopology struct {
+	common     *CmdControl
+	debug      *cmdDebug
+	flagFormat string
+	flagRouter string
+	flagDepth  int
+}
+
+// Command returns definition for "microovn debug topology" subcommand
+func (c *cmdDebugTopology) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "topology",
+		Short: "Print graph of OVN logical topology and chassis bindings",
+		Long: "Print graph of Logical Routers and Switches, their ports, gateway chassis, HA chassis groups and\n" +
+			"port bindings, built from the OVN Northbound and Southbound databases. Output in the DOT format\n" +
+			"can be rendered with Graphviz, e.g. \"microovn debug topology | dot -Tsvg > topology.svg\".",
+		Args: cobra.NoArgs,
+		RunE: c.Run,
+	}
+
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "dot", "Output format (dot|json)")
+	cmd.Flags().StringVar(&c.flagRouter, "router", "", "Show only neighborhood of the Logical Router")
+	cmd.Flags().IntVar(
+		&c.flagDepth,
+		"depth",
+		topology.DefaultGraphDepth,
+		"Maximum distance of the shown objects from the router selected by --router",
+	)
+
+	return cmd
+}
+
+// Run method is an implementation of the "microovn debug topology" subcommand
+func (c *cmdDebugTopology) Run(_ *cobra.Command, _ []string) error {
+	if c.flagFormat != "dot" && c.flagFormat != "json" {
+		return fmt.Errorf("unsupported format '%s'. Supported formats are: dot, json", c.flagFormat)
+	}
+
+	if c.flagDepth < 1 {
+		return fmt.Errorf("depth must be a positive number")
+	}
+
+	m, err := microcluster.App(microcluster.Args{StateDir: c.common.FlagStateDir})
+	if err != nil {
+		return err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	graph, err := client.GetTopologyGraph(context.Background(), cli, c.flagRouter, c.flagDepth)
+	if err != nil {
+		return err
+	}
+
+	if c.flagFormat == "dot" {
+		fmt.Print(topology.RenderDot(graph))
+		return nil
+	}
+
+	data, err := json.MarshalIndent(graph, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize topology graph: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
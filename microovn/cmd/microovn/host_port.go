@@ -0,0 +1,34 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+type cmdHostPort struct {
+	common *CmdControl
+}
+
+// Command returns definition for "microovn host-port" subcommand
+func (c *cmdHostPort) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "host-port",
+		Short: "Manage host ports that connect cluster members to OVN logical networks",
+		Long: `Manage host ports that connect cluster members to OVN logical networks
+
+A host port is an internal interface on the OVN integration bridge, bound to
+a Logical Switch Port. It gives the host an IP address on the OVN logical
+network, for example for monitoring agents or storage frontends. MicroOVN
+restores addresses of the host ports when the member restarts.`,
+	}
+
+	hostPortAddCmd := cmdHostPortAdd{common: c.common, hostPort: c}
+	cmd.AddCommand(hostPortAddCmd.Command())
+
+	hostPortRemoveCmd := cmdHostPortRemove{common: c.common, hostPort: c}
+	cmd.AddCommand(hostPortRemoveCmd.Command())
+
+	hostPortListCmd := cmdHostPortList{common: c.common, hostPort: c}
+	cmd.AddCommand(hostPortListCmd.Command())
+
+	return cmd
+}
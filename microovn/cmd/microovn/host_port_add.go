@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/canonical/microcluster/v2/microcluster"
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/client"
+)
+
+type cmdHostPortAdd struct {
+	common     *CmdControl
+	hostPort   *cmdHostPort
+	flagIPs    []string
+	flagMember string
+}
+
+// Command returns definition for "microovn host-port add" subcommand
+func (c *cmdHostPortAdd) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add <LOGICAL_SWITCH>",
+		Short: "Connect cluster member to the OVN Logical Switch",
+		Args:  cobra.ExactArgs(1),
+		RunE:  c.Run,
+	}
+
+	cmd.Flags().StringSliceVar(
+		&c.flagIPs,
+		"ip",
+		nil,
+		"IP address in CIDR notation to configure on the host port (can be repeated)",
+	)
+	cmd.Flags().StringVar(
+		&c.flagMember,
+		"member",
+		"",
+		"Optional name of the cluster member to connect (local member by default)",
+	)
+
+	return cmd
+}
+
+// Run method is an implementation of the "microovn host-port add" subcommand
+func (c *cmdHostPortAdd) Run(_ *cobra.Command, args []string) error {
+	m, err := microcluster.App(microcluster.Args{StateDir: c.common.FlagStateDir})
+	if err != nil {
+		return err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	request := types.HostPortRequest{LogicalSwitch: args[0], Addresses: c.flagIPs}
+	hostPort, err := client.AddHostPort(context.Background(), cli, c.flagMember, request)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf(
+		"Host port '%s' on member '%s' is bound to Logical Switch Port '%s'\n",
+		hostPort.Iface, hostPort.Member, hostPort.LogicalPort,
+	)
+	if len(hostPort.Addresses) > 0 {
+		fmt.Printf("Addresses: %s\n", strings.Join(hostPort.Addresses, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	lxdCmd "github.com/canonical/lxd/shared/cmd"
+	"github.com/canonical/lxd/shared/i18n"
+	"github.com/canonical/microcluster/v2/microcluster"
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/client"
+)
+
+type cmdHostPortList struct {
+	common     *CmdControl
+	hostPort   *cmdHostPort
+	flagFormat string
+	flagMember string
+}
+
+// Command returns definition for "microovn host-port list" subcommand
+func (c *cmdHostPortList) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List host ports of the cluster member",
+		Args:  cobra.NoArgs,
+		RunE:  c.Run,
+	}
+
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "table", i18n.G("Format (csv|json|table|yaml|compact)")+"``")
+	cmd.Flags().StringVar(
+		&c.flagMember,
+		"member",
+		"",
+		"Optional name of the cluster member to inspect (local member by default)",
+	)
+
+	return cmd
+}
+
+// Run method is an implementation of the "microovn host-port list" subcommand
+func (c *cmdHostPortList) Run(_ *cobra.Command, _ []string) error {
+	m, err := microcluster.App(microcluster.Args{StateDir: c.common.FlagStateDir})
+	if err != nil {
+		return err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	hostPorts, err := client.GetHostPorts(context.Background(), cli, c.flagMember)
+	if err != nil {
+		return err
+	}
+
+	data := make([][]string, len(hostPorts))
+	for i, hostPort := range hostPorts {
+		data[i] = []string{
+			hostPort.LogicalSwitch, hostPort.LogicalPort, hostPort.Iface, hostPort.Mac, strings.Join(hostPort.Addresses, ", "),
+		}
+	}
+
+	header := []string{"LOGICAL SWITCH", "LOGICAL PORT", "INTERFACE", "MAC", "ADDRESSES"}
+	sort.Sort(lxdCmd.SortColumnsNaturally(data))
+
+	return lxdCmd.RenderTable(c.flagFormat, header, data, hostPorts)
+}
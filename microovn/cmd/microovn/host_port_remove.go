@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/canonical/microcluster/v2/microcluster"
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/client"
+)
+
+type cmdHostPortRemove struct {
+	common     *CmdControl
+	hostPort   *cmdHostPort
+	flagMember string
+}
+
+// Command returns definition for "microovn host-port remove" subcommand
+func (c *cmdHostPortRemove) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove <LOGICAL_SWITCH>",
+		Short: "Disconnect cluster member from the OVN Logical Switch",
+		Args:  cobra.ExactArgs(1),
+		RunE:  c.Run,
+	}
+
+	cmd.Flags().StringVar(
+		&c.flagMember,
+		"member",
+		"",
+		"Optional name of the cluster member to disconnect (local member by default)",
+	)
+
+	return cmd
+}
+
+// Run method is an implementation of the "microovn host-port remove" subcommand
+func (c *cmdHostPortRemove) Run(_ *cobra.Command, args []string) error {
+	m, err := microcluster.App(microcluster.Args{StateDir: c.common.FlagStateDir})
+	if err != nil {
+		return err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	err = client.RemoveHostPort(context.Background(), cli, c.flagMember, args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Host port on Logical Switch '%s' was removed\n", args[0])
+	return nil
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/canonical/lxd/shared/validate"
 	"github.com/canonical/microcluster/v2/microcluster"
 	microovnAPI "github.com/canonical/microovn/microovn/api"
+	"github.com/canonical/microovn/microovn/ovn/environment"
 	"github.com/spf13/cobra"
 )
 
@@ -72,6 +73,21 @@ func (c *cmdInit) wantsCustomCA() (string, string, error) {
 	return "", "", nil
 }
 
+// wantsStandaloneDatabase asks whether the OVN databases should run in standalone mode and returns the init
+// config option that selects it. Empty key and value are returned if the default (clustered) mode is kept.
+func (c *cmdInit) wantsStandaloneDatabase() (string, string, error) {
+	standalone, err := c.common.asker.AskBool("Would you like to run OVN databases in standalone mode? This is suitable only for single-node deployments, databases are converted to clustered mode automatically when another central node joins. (yes/no) [default=no]: ", "no")
+	if err != nil {
+		return "", "", err
+	}
+
+	if standalone {
+		return environment.DatabaseModeInitKey, string(environment.DatabaseModeStandalone), nil
+	}
+
+	return "", "", nil
+}
+
 func (c *cmdInit) selectServices() (string, error) {
 	validServices := []string{"central", "chassis", "switch", "auto"}
 	serviceList, err := c.common.asker.AskString("Please select comma-separated list services you would like to enable on this node (central/chassis/switch) or let MicroOVN automatically decide (auto) [default=auto]: ", "auto", validate.IsAny)
@@ -112,6 +128,7 @@ func (c *cmdInit) Run(_ *cobra.Command, _ []string) error {
 	// User interaction.
 	mode := "existing"
 	customEncapsulationIPSupported := shared.ValueInSlice("custom_encapsulation_ip", microovnAPI.Extensions())
+	standaloneDatabaseSupported := shared.ValueInSlice("ovn_standalone_database", microovnAPI.Extensions())
 
 	if isUninitialized {
 		// Get system name.
@@ -162,6 +179,17 @@ func (c *cmdInit) Run(_ *cobra.Command, _ []string) error {
 				}
 			}
 
+			if standaloneDatabaseSupported {
+				key, mode, err := c.wantsStandaloneDatabase()
+				if err != nil {
+					return err
+				}
+
+				if key != "" && mode != "" {
+					optionalConfig[key] = mode
+				}
+			}
+
 			certPath, keyPath, err := c.wantsCustomCA()
 			if err != nil {
 				return err
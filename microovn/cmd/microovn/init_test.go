@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	cli "github.com/canonical/lxd/shared/cmd"
+)
+
+// newTestInit returns cmdInit that reads answers to its questions from "input".
+func newTestInit(input string) *cmdInit {
+	asker := cli.NewAsker(bufio.NewReader(strings.NewReader(input)), nil)
+	return &cmdInit{common: &CmdControl{asker: asker}}
+}
+
+func TestUnexported_wantsStandaloneDatabase(t *testing.T) {
+	testCases := []struct {
+		input         string
+		expectedKey   string
+		expectedValue string
+		isValid       bool
+	}{
+		// Empty answer selects the default clustered mode
+		{input: "\n", isValid: true},
+		{input: "no\n", isValid: true},
+		{input: "n\n", isValid: true},
+		{input: "yes\n", expectedKey: "ovn-database-mode", expectedValue: "standalone", isValid: true},
+		{input: "Y\n", expectedKey: "ovn-database-mode", expectedValue: "standalone", isValid: true},
+		// Invalid answer is asked again
+		{input: "standalone\nyes\n", expectedKey: "ovn-database-mode", expectedValue: "standalone", isValid: true},
+		// No answer at all
+		{input: "", isValid: false},
+	}
+
+	for _, tc := range testCases {
+		key, value, err := newTestInit(tc.input).wantsStandaloneDatabase()
+		if !tc.isValid {
+			if err == nil {
+				t.Errorf("Expected error for input %q, got '%s=%s'", tc.input, key, value)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Unexpected error for input %q: %v", tc.input, err)
+			continue
+		}
+
+		if key != tc.expectedKey || value != tc.expectedValue {
+			t.Errorf("Expected '%s=%s' for input %q, got '%s=%s'", tc.expectedKey, tc.expectedValue, tc.input, key, value)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package main
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
 
@@ -78,10 +79,51 @@ func main() {
 	var cmdConfig = cmdConfig{common: &commonCmd}
 	app.AddCommand(cmdConfig.Command())
 
+	var cmdOvsdb = cmdOvsdb{common: &commonCmd}
+	app.AddCommand(cmdOvsdb.Command())
+
+	var cmdSwitch = cmdSwitch{common: &commonCmd}
+	app.AddCommand(cmdSwitch.Command())
+
+	var cmdHostPort = cmdHostPort{common: &commonCmd}
+	app.AddCommand(cmdHostPort.Command())
+
+	var cmdMaintenance = cmdMaintenance{common: &commonCmd}
+	app.AddCommand(cmdMaintenance.Command())
+
+	var cmdOvn = cmdOvn{common: &commonCmd}
+	app.AddCommand(cmdOvn.Command())
+
+	var cmdDebug = cmdDebug{common: &commonCmd}
+	app.AddCommand(cmdDebug.Command())
+
+	var cmdBgp = cmdBgp{common: &commonCmd}
+	app.AddCommand(cmdBgp.Command())
+
 	app.InitDefaultHelpCmd()
 
 	err := app.Execute()
 	if err != nil {
+		var exitErr *exitCodeError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.code)
+		}
 		os.Exit(1)
 	}
 }
+
+// exitCodeError is an error that makes the command exit with a specific exit code, instead of the generic 1.
+type exitCodeError struct {
+	err  error
+	code int
+}
+
+// Error returns message of the wrapped error.
+func (e *exitCodeError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *exitCodeError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+type cmdMaintenance struct {
+	common *CmdControl
+}
+
+// Command returns definition for "microovn maintenance" subcommand
+func (c *cmdMaintenance) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "maintenance",
+		Short: "Interact with maintenance windows",
+	}
+
+	maintenanceCheckCmd := &cmdMaintenanceCheck{common: c.common, maintenance: c}
+	cmd.AddCommand(maintenanceCheckCmd.Command())
+
+	return cmd
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/canonical/microcluster/v2/microcluster"
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/client"
+)
+
+// maintenanceDeferredExitCode is an exit code of the "microovn maintenance check" command when the job should
+// be deferred. Other failures exit with code 1.
+const maintenanceDeferredExitCode = 2
+
+type cmdMaintenanceCheck struct {
+	common       *CmdControl
+	maintenance  *cmdMaintenance
+	flagDeadline int64
+}
+
+// Command returns definition for "microovn maintenance check" subcommand
+func (c *cmdMaintenanceCheck) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "check <job>",
+		Short: "Check whether a disruptive job is allowed to run now",
+		Long: "Check whether a disruptive job is allowed to run now, considering configured maintenance windows.\n" +
+			"Command exits with code 2 if the job should be deferred until the next maintenance window, " +
+			"and with code 1 if the check failed.",
+		Args: cobra.ExactArgs(1),
+		RunE: c.Run,
+	}
+
+	cmd.Flags().Int64Var(
+		&c.flagDeadline,
+		"deadline",
+		0,
+		"Optional UNIX timestamp after which the job can't be deferred (e.g. certificate expiration)",
+	)
+
+	return cmd
+}
+
+// Run method is an implementation of the "microovn maintenance check" subcommand
+func (c *cmdMaintenanceCheck) Run(_ *cobra.Command, args []string) error {
+	m, err := microcluster.App(microcluster.Args{StateDir: c.common.FlagStateDir})
+	if err != nil {
+		return err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	var deadline time.Time
+	if c.flagDeadline > 0 {
+		deadline = time.Unix(c.flagDeadline, 0).UTC()
+	}
+
+	result, err := client.CheckMaintenance(context.Background(), cli, args[0], deadline)
+	if err != nil {
+		return err
+	}
+
+	if !result.Allowed {
+		return &exitCodeError{
+			err: fmt.Errorf(
+				"job '%s' is deferred until next maintenance window (%s UTC)", args[0], result.NextWindow.Format(time.DateTime),
+			),
+			code: maintenanceDeferredExitCode,
+		}
+	}
+
+	fmt.Printf("Job '%s' is allowed to run\n", args[0])
+	return nil
+}
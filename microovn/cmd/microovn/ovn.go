@@ -0,0 +1,28 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+type cmdOvn struct {
+	common *CmdControl
+}
+
+// Command returns definition for "microovn ovn" subcommand
+func (c *cmdOvn) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ovn",
+		Short: "Manage OVN logical topology",
+	}
+
+	ovnExportCmd := &cmdOvnExport{common: c.common, ovn: c}
+	cmd.AddCommand(ovnExportCmd.Command())
+
+	ovnImportCmd := &cmdOvnImport{common: c.common, ovn: c}
+	cmd.AddCommand(ovnImportCmd.Command())
+
+	ovnWaitCmd := &cmdOvnWait{common: c.common, ovn: c}
+	cmd.AddCommand(ovnWaitCmd.Command())
+
+	return cmd
+}
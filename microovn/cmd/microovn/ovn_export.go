@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/canonical/microcluster/v2/microcluster"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/client"
+	"github.com/canonical/microovn/microovn/ovn/topology"
+)
+
+type cmdOvnExport struct {
+	common          *CmdControl
+	ovn             *cmdOvn
+	flagNames       []string
+	flagExternalIDs []string
+	flagFormat      string
+	flagOutput      string
+}
+
+// Command returns definition for "microovn ovn export" subcommand
+func (c *cmdOvnExport) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export logical objects from the OVN Northbound database",
+		Long: "Export Logical Switches, Logical Routers, Load Balancers and Port Groups, together with their ports,\n" +
+			"ACLs, NAT rules and static routes, into a portable document that can be imported into another cluster.\n" +
+			"If no selector is specified, all objects are exported.",
+		Args: cobra.NoArgs,
+		RunE: c.Run,
+	}
+
+	cmd.Flags().StringSliceVar(&c.flagNames, "name", nil, "Name of the object to export (can be repeated)")
+	cmd.Flags().StringSliceVar(
+		&c.flagExternalIDs,
+		"external-id",
+		nil,
+		"Export only objects with the external ID in the '<key>=<value>' format (can be repeated)",
+	)
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "yaml", "Format of the document (yaml|json)")
+	cmd.Flags().StringVarP(&c.flagOutput, "output", "o", "", "Write the document to a file instead of standard output")
+
+	return cmd
+}
+
+// Run method is an implementation of the "microovn ovn export" subcommand
+func (c *cmdOvnExport) Run(_ *cobra.Command, _ []string) error {
+	request := types.TopologyExportRequest{Names: c.flagNames, ExternalIDs: make(map[string]string)}
+	for _, externalID := range c.flagExternalIDs {
+		key, value, found := strings.Cut(externalID, "=")
+		if !found || key == "" {
+			return fmt.Errorf("external ID '%s' does not conform to the '<key>=<value>' format", externalID)
+		}
+		request.ExternalIDs[key] = value
+	}
+
+	if c.flagFormat != "yaml" && c.flagFormat != "json" {
+		return fmt.Errorf("unsupported format '%s'. Supported formats are: yaml, json", c.flagFormat)
+	}
+
+	m, err := microcluster.App(microcluster.Args{StateDir: c.common.FlagStateDir})
+	if err != nil {
+		return err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	document, err := client.ExportTopology(context.Background(), cli, request)
+	if err != nil {
+		return err
+	}
+	topology.NormalizeDocument(&document)
+
+	for _, warning := range document.Warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+
+	var data []byte
+	if c.flagFormat == "json" {
+		data, err = json.MarshalIndent(document, "", "  ")
+		data = append(data, '\n')
+	} else {
+		data, err = yaml.Marshal(document)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to serialize topology document: %w", err)
+	}
+
+	if c.flagOutput == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+
+	return os.WriteFile(c.flagOutput, data, 0600)
+}
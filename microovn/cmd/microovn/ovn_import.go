@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/canonical/microcluster/v2/microcluster"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/client"
+)
+
+type cmdOvnImport struct {
+	common      *CmdControl
+	ovn         *cmdOvn
+	waitLevel   string
+	waitTimeout time.Duration
+}
+
+// Command returns definition for "microovn ovn import" subcommand
+func (c *cmdOvnImport) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Import logical objects into the OVN Northbound database",
+		Long: "Import logical objects from a document created by \"microovn ovn export\". Document can be in\n" +
+			"YAML or JSON format. Use \"-\" to read the document from standard input. All objects are created in\n" +
+			"a single transaction, and nothing is created if any of the objects already exists.",
+		Args: cobra.ExactArgs(1),
+		RunE: c.Run,
+	}
+
+	cmd.Flags().StringVar(&c.waitLevel, "wait", "", "Wait until imported objects are realized (sb|hv)")
+	cmd.Flags().DurationVar(&c.waitTimeout, "wait-timeout", types.DefaultRealizationTimeout*time.Second, "Maximum time to wait for realization")
+
+	return cmd
+}
+
+// Run method is an implementation of the "microovn ovn import" subcommand
+func (c *cmdOvnImport) Run(_ *cobra.Command, args []string) error {
+	var data []byte
+	var err error
+	if args[0] == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(args[0])
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read topology document: %w", err)
+	}
+
+	if c.waitLevel != "" {
+		err = types.ValidateRealizationLevel(c.waitLevel)
+		if err != nil {
+			return err
+		}
+	}
+
+	// JSON documents are parsed as well, because JSON is a subset of YAML
+	var document types.TopologyDocument
+	err = yaml.Unmarshal(data, &document)
+	if err != nil {
+		return fmt.Errorf("failed to parse topology document: %w", err)
+	}
+
+	m, err := microcluster.App(microcluster.Args{StateDir: c.common.FlagStateDir})
+	if err != nil {
+		return err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	result, err := client.ImportTopology(context.Background(), cli, document)
+	if err != nil {
+		return err
+	}
+
+	tables := make([]string, 0, len(result.Created))
+	for table := range result.Created {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+
+	fmt.Println("Imported records:")
+	for _, table := range tables {
+		fmt.Printf("    %s: %d\n", table, result.Created[table])
+	}
+
+	if c.waitLevel != "" {
+		return waitForRealization(cli, c.waitLevel, c.waitTimeout)
+	}
+	return nil
+}
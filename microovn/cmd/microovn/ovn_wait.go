@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/canonical/microcluster/v2/client"
+	"github.com/canonical/microcluster/v2/microcluster"
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	microovnClient "github.com/canonical/microovn/microovn/client"
+)
+
+type cmdOvnWait struct {
+	common      *CmdControl
+	ovn         *cmdOvn
+	flagLevel   string
+	flagTimeout time.Duration
+}
+
+// Command returns definition for "microovn ovn wait" subcommand
+func (c *cmdOvnWait) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "wait",
+		Short: "Wait until changes in the OVN Northbound database are realized",
+		Long: `Wait until changes in the OVN Northbound database are realized
+
+Similarly to "ovn-nbctl --wait", the command increments the nb_cfg sequence
+number in the OVN Northbound database and waits until ovn-northd translates all
+changes made so far into the OVN Southbound database (--for sb), or until every
+chassis applies them (--for hv). If the changes are not realized within the
+timeout, the command fails and lists lagging chassis.`,
+		Args: cobra.NoArgs,
+		RunE: c.Run,
+	}
+
+	cmd.Flags().StringVar(&c.flagLevel, "for", types.RealizationHV, "Level of realization to wait for (sb|hv)")
+	cmd.Flags().DurationVar(&c.flagTimeout, "timeout", types.DefaultRealizationTimeout*time.Second, "Maximum time to wait")
+
+	return cmd
+}
+
+// Run method is an implementation of the "microovn ovn wait" subcommand
+func (c *cmdOvnWait) Run(_ *cobra.Command, _ []string) error {
+	err := types.ValidateRealizationLevel(c.flagLevel)
+	if err != nil {
+		return err
+	}
+
+	m, err := microcluster.App(microcluster.Args{StateDir: c.common.FlagStateDir})
+	if err != nil {
+		return err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	return waitForRealization(cli, c.flagLevel, c.flagTimeout)
+}
+
+// waitForRealization waits until the changes in the OVN Northbound database are realized at the "level"
+// and prints the result. It is used by commands that accept "--wait" option.
+func waitForRealization(cli *client.Client, level string, timeout time.Duration) error {
+	status, err := microovnClient.WaitForRealization(context.Background(), cli, level, timeout)
+	for _, chassis := range status.Lagging {
+		fmt.Printf("Chassis %s applied changes up to nb_cfg %d (expected %d)\n", chassis.Chassis, chassis.NbCfg, status.Target)
+	}
+	if err != nil {
+		return err
+	}
+
+	if level == types.RealizationSB {
+		fmt.Printf("Changes up to nb_cfg %d were translated into the OVN Southbound database\n", status.Target)
+	} else {
+		fmt.Printf("Changes up to nb_cfg %d were applied on every chassis\n", status.Target)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+type cmdOvsdb struct {
+	common *CmdControl
+}
+
+// Command returns definition for "microovn ovsdb" subcommand
+func (c *cmdOvsdb) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ovsdb",
+		Short: "Inspect and repair OVN databases",
+	}
+
+	ovsdbSbConnectionsCmd := &cmdOvsdbSbConnections{common: c.common, ovsdb: c}
+	cmd.AddCommand(ovsdbSbConnectionsCmd.Command())
+
+	ovsdbSchemaDiffCmd := &cmdOvsdbSchemaDiff{common: c.common, ovsdb: c}
+	cmd.AddCommand(ovsdbSchemaDiffCmd.Command())
+
+	ovsdbCheckCmd := &cmdOvsdbCheck{common: c.common, ovsdb: c}
+	cmd.AddCommand(ovsdbCheckCmd.Command())
+
+	ovsdbReseedCmd := &cmdOvsdbReseed{common: c.common, ovsdb: c}
+	cmd.AddCommand(ovsdbReseedCmd.Command())
+
+	return cmd
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	lxdCmd "github.com/canonical/lxd/shared/cmd"
+	"github.com/canonical/lxd/shared/i18n"
+	"github.com/canonical/microcluster/v2/microcluster"
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/client"
+)
+
+type cmdOvsdbCheck struct {
+	common     *CmdControl
+	ovsdb      *cmdOvsdb
+	flagFormat string
+}
+
+// Command returns definition for "microovn ovsdb check" subcommand
+func (c *cmdOvsdbCheck) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Check integrity of OVN Northbound and Southbound databases on every central member",
+		Long: `Check integrity of OVN Northbound and Southbound databases on every central member
+
+Database files are checked with "ovsdb-tool check-cluster" (or "ovsdb-tool show-log"
+for standalone databases). Servers of the clustered databases are also checked to be
+connected, up-to-date members of their RAFT clusters. The command fails if any of
+the databases is not healthy. Unhealthy member can be fixed with
+"microovn ovsdb reseed <member>".`,
+		Args: cobra.NoArgs,
+		RunE: c.Run,
+	}
+
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "table", i18n.G("Format (csv|json|table|yaml|compact)")+"``")
+
+	return cmd
+}
+
+// Run method is an implementation of the "microovn ovsdb check" subcommand
+func (c *cmdOvsdbCheck) Run(_ *cobra.Command, _ []string) error {
+	m, err := microcluster.App(microcluster.Args{StateDir: c.common.FlagStateDir})
+	if err != nil {
+		return err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	report, err := client.GetAllOvsdbChecks(context.Background(), cli)
+	if err != nil {
+		return err
+	}
+
+	unhealthy := 0
+	data := make([][]string, len(report))
+	for i, result := range report {
+		healthy := "yes"
+		if !result.Healthy {
+			healthy = "no"
+			unhealthy++
+		}
+		data[i] = []string{result.Member, result.Database, result.Mode, result.Status, result.Role, healthy, strings.Join(result.Problems, "\n")}
+	}
+
+	header := []string{"MEMBER", "DATABASE", "MODE", "STATUS", "ROLE", "HEALTHY", "PROBLEMS"}
+	sort.Sort(lxdCmd.SortColumnsNaturally(data))
+
+	err = lxdCmd.RenderTable(c.flagFormat, header, data, report)
+	if err != nil {
+		return err
+	}
+
+	if unhealthy > 0 {
+		return fmt.Errorf("%d of %d databases are not healthy", unhealthy, len(report))
+	}
+	return nil
+}
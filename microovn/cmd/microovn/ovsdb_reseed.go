@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/canonical/microcluster/v2/microcluster"
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/client"
+)
+
+type cmdOvsdbReseed struct {
+	common *CmdControl
+	ovsdb  *cmdOvsdb
+}
+
+// Command returns definition for "microovn ovsdb reseed" subcommand
+func (c *cmdOvsdbReseed) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reseed <MEMBER>",
+		Short: "Replace OVN Northbound and Southbound databases on a member with copies from healthy peers",
+		Long: `Replace OVN Northbound and Southbound databases on a member with copies from healthy peers
+
+Database servers on the member leave their RAFT clusters, database files are moved
+to backups in the database directory and the servers join the clusters again. They
+receive a fresh copy of the data from the cluster leader. The command waits until
+both databases catch up with the cluster.
+
+Reseed is refused unless the remaining central members have a healthy majority in
+both clusters.`,
+		Args: cobra.ExactArgs(1),
+		RunE: c.Run,
+	}
+
+	return cmd
+}
+
+// Run method is an implementation of the "microovn ovsdb reseed" subcommand
+func (c *cmdOvsdbReseed) Run(_ *cobra.Command, args []string) error {
+	m, err := microcluster.App(microcluster.Args{StateDir: c.common.FlagStateDir})
+	if err != nil {
+		return err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Reseeding OVN databases on '%s'...\n", args[0])
+	err = client.ReseedOvsdb(context.Background(), cli, args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("OVN databases on '%s' were reseeded and caught up with the cluster\n", args[0])
+	return nil
+}
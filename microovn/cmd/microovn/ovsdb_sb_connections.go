@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	lxdCmd "github.com/canonical/lxd/shared/cmd"
+	"github.com/canonical/lxd/shared/i18n"
+	"github.com/canonical/microcluster/v2/microcluster"
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/client"
+)
+
+type cmdOvsdbSbConnections struct {
+	common     *CmdControl
+	ovsdb      *cmdOvsdb
+	flagFormat string
+}
+
+// Command returns definition for "microovn ovsdb sb-connections" subcommand
+func (c *cmdOvsdbSbConnections) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sb-connections",
+		Short: "Show number of connections to each OVN Southbound database server",
+		Args:  cobra.NoArgs,
+		RunE:  c.Run,
+	}
+
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "table", i18n.G("Format (csv|json|table|yaml|compact)")+"``")
+
+	return cmd
+}
+
+// Run method is an implementation of the "microovn ovsdb sb-connections" subcommand
+func (c *cmdOvsdbSbConnections) Run(_ *cobra.Command, _ []string) error {
+	m, err := microcluster.App(microcluster.Args{StateDir: c.common.FlagStateDir})
+	if err != nil {
+		return err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	report, err := client.GetAllSbConnections(context.Background(), cli)
+	if err != nil {
+		return err
+	}
+
+	data := make([][]string, len(report))
+	for i, result := range report {
+		connections := strconv.Itoa(result.Connections)
+		if result.Error != "" {
+			connections = "-"
+		}
+		data[i] = []string{result.Member, connections, result.Error}
+	}
+
+	header := []string{"MEMBER", "CONNECTIONS", "ERROR"}
+	sort.Sort(lxdCmd.SortColumnsNaturally(data))
+
+	return lxdCmd.RenderTable(c.flagFormat, header, data, report)
+}
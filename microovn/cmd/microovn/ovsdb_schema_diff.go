@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	lxdCmd "github.com/canonical/lxd/shared/cmd"
+	"github.com/canonical/lxd/shared/i18n"
+	"github.com/canonical/microcluster/v2/microcluster"
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/client"
+)
+
+type cmdOvsdbSchemaDiff struct {
+	common     *CmdControl
+	ovsdb      *cmdOvsdb
+	flagDB     string
+	flagFormat string
+}
+
+// Command returns definition for "microovn ovsdb schema-diff" subcommand
+func (c *cmdOvsdbSchemaDiff) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schema-diff",
+		Short: "Compare schemas of the running OVN databases with the schemas shipped in MicroOVN",
+		Long: `Compare schemas of the running OVN databases with the schemas shipped in MicroOVN
+
+Changes are listed from the point of view of the pending schema conversion. For
+example, a table that exists only in the shipped schema is listed as "added".
+Changes that can break existing database clients (like removed tables or columns,
+changed column types and new indexes) are marked as breaking.`,
+		Args: cobra.NoArgs,
+		RunE: c.Run,
+	}
+
+	cmd.Flags().StringVar(&c.flagDB, "db", "", "Compare only schema of this database (nb|sb)")
+	cmd.Flags().StringVarP(&c.flagFormat, "format", "f", "table", i18n.G("Format (csv|json|table|yaml|compact)")+"``")
+
+	return cmd
+}
+
+// Run method is an implementation of the "microovn ovsdb schema-diff" subcommand
+func (c *cmdOvsdbSchemaDiff) Run(_ *cobra.Command, _ []string) error {
+	databases := []string{"nb", "sb"}
+	if c.flagDB != "" {
+		if c.flagDB != "nb" && c.flagDB != "sb" {
+			return fmt.Errorf("unknown database '%s'. Supported values are: nb, sb", c.flagDB)
+		}
+		databases = []string{c.flagDB}
+	}
+
+	m, err := microcluster.App(microcluster.Args{StateDir: c.common.FlagStateDir})
+	if err != nil {
+		return err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	diffs := make([]types.OvsdbSchemaDiff, 0, len(databases))
+	data := [][]string{}
+	for _, db := range databases {
+		diff, err := client.GetOvsdbSchemaDiff(context.Background(), cli, db)
+		if err != nil {
+			return err
+		}
+		diffs = append(diffs, diff)
+
+		for _, change := range diff.Changes {
+			breaking := ""
+			if change.Breaking {
+				breaking = "yes"
+			}
+			data = append(data, []string{diff.Database, change.Kind, change.Table, change.Column, change.Detail, breaking})
+		}
+	}
+
+	if c.flagFormat == lxdCmd.TableFormatTable {
+		for _, diff := range diffs {
+			fmt.Printf("%s: running schema %s, shipped schema %s\n", diff.Database, diff.RunningVersion, diff.TargetVersion)
+		}
+		if len(data) == 0 {
+			fmt.Println("Schemas are identical")
+			return nil
+		}
+	}
+
+	header := []string{"DATABASE", "CHANGE", "TABLE", "COLUMN", "DETAIL", "BREAKING"}
+	return lxdCmd.RenderTable(c.flagFormat, header, data, diffs)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/canonical/microcluster/v2/microcluster"
 	"github.com/spf13/cobra"
@@ -68,6 +69,8 @@ type cmdEnable struct {
 	common      *CmdControl
 	extraConfig []string
 	nodeName    string
+	waitLevel   string
+	waitTimeout time.Duration
 }
 
 func (c *cmdEnable) Command() *cobra.Command {
@@ -95,6 +98,20 @@ func (c *cmdEnable) Command() *cobra.Command {
 		"Optional name of the node to target",
 	)
 
+	cmd.Flags().StringVar(
+		&c.waitLevel,
+		"wait",
+		"",
+		"Wait until changes made in the OVN Northbound database are realized (sb|hv)",
+	)
+
+	cmd.Flags().DurationVar(
+		&c.waitTimeout,
+		"wait-timeout",
+		types.DefaultRealizationTimeout*time.Second,
+		"Maximum time to wait for realization of the changes",
+	)
+
 	return cmd
 }
 
@@ -115,6 +132,13 @@ func (c *cmdEnable) Run(_ *cobra.Command, args []string) error {
 		return err
 	}
 
+	if c.waitLevel != "" {
+		err = types.ValidateRealizationLevel(c.waitLevel)
+		if err != nil {
+			return err
+		}
+	}
+
 	ws, regenEnv, err := client.EnableService(context.Background(), cli, targetService, &extraConfig, c.nodeName)
 
 	if err != nil {
@@ -125,6 +149,10 @@ func (c *cmdEnable) Run(_ *cobra.Command, args []string) error {
 	if c.common.FlagLogVerbose {
 		regenEnv.PrettyPrint()
 	}
+
+	if c.waitLevel != "" {
+		return waitForRealization(cli, c.waitLevel, c.waitTimeout)
+	}
 	return nil
 }
 
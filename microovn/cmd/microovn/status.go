@@ -6,9 +6,11 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/canonical/microcluster/v2/microcluster"
 	"github.com/canonical/microovn/microovn/api/types"
+	microovnFacts "github.com/canonical/microovn/microovn/facts"
 	ovnCmd "github.com/canonical/microovn/microovn/ovn/cmd"
 	"github.com/spf13/cobra"
 
@@ -53,6 +55,20 @@ func (c *cmdStatus) Run(_ *cobra.Command, _ []string) error {
 		return err
 	}
 
+	// Get BGP redirect interfaces. Failure to fetch them is not fatal, the daemon
+	// might not support this API yet.
+	bgpInterfaces, err := client.GetBgpRedirectInterfaces(context.Background(), cli)
+	if err != nil {
+		bgpInterfaces = types.BgpRedirectInterfaces{}
+	}
+
+	// Get facts published by cluster members. Same as with BGP interfaces, failure to
+	// fetch them is not fatal.
+	memberFacts, err := client.GetMemberFacts(context.Background(), cli)
+	if err != nil {
+		memberFacts = types.MemberFactsList{}
+	}
+
 	fmt.Println("MicroOVN deployment summary:")
 
 	for _, server := range clusterMembers {
@@ -69,25 +85,147 @@ func (c *cmdStatus) Run(_ *cobra.Command, _ []string) error {
 
 		fmt.Printf("- %s (%s)\n", server.Name, server.Address.Addr().String())
 		fmt.Printf("  Services: %s\n", strings.Join(srvServices, ", "))
+
+		// BGP redirect interfaces.
+		srvBgpInterfaces := []string{}
+		for _, bgpInterface := range bgpInterfaces {
+			if bgpInterface.Member != server.Name {
+				continue
+			}
+
+			srvBgpInterfaces = append(srvBgpInterfaces, fmt.Sprintf("%s (%s)", bgpInterface.Iface, bgpInterface.Redirect))
+		}
+
+		if len(srvBgpInterfaces) > 0 {
+			fmt.Printf("  BGP interfaces: %s\n", strings.Join(srvBgpInterfaces, ", "))
+		}
+
+		// Published facts.
+		for _, facts := range memberFacts {
+			if facts.Member == server.Name {
+				printMemberFacts(facts, time.Now())
+			}
+		}
 	}
 
 	// Get OVN clustered DB schema version status
+	memberAddrs := make(map[string]string, len(clusterMembers))
+	for _, member := range clusterMembers {
+		memberAddrs[member.Name] = member.Address.Addr().String()
+	}
+
 	fmt.Println("OVN Database summary:")
-	reportOvsdbSchemaStatus(cli, ovnCmd.OvsdbTypeNBLocal)
-	reportOvsdbSchemaStatus(cli, ovnCmd.OvsdbTypeSBLocal)
+	reportOvsdbSchemaStatus(cli, ovnCmd.OvsdbTypeNBLocal, memberFacts, memberAddrs)
+	reportOvsdbSchemaStatus(cli, ovnCmd.OvsdbTypeSBLocal, memberFacts, memberAddrs)
 	return nil
 }
 
-// reportOvsdbSchemaStatus fetches currently active schema version and list of expected schema version from each
-// node in the deployment. Based on the results it then prints a report for the user.
-func reportOvsdbSchemaStatus(cli *microClusterClient.Client, ovsdbType ovnCmd.OvsdbType) {
+// printMemberFacts prints facts published by a cluster member, together with their age relative to "now".
+func printMemberFacts(facts types.MemberFacts, now time.Time) {
+	age := now.Sub(facts.Updated).Round(time.Second)
+	staleNote := ""
+	// Facts older than a few publish intervals are marked as stale
+	if age > 3*microovnFacts.PublishInterval {
+		staleNote = ", stale"
+	}
+	fmt.Printf("  Facts: updated %s (%s ago%s)\n", facts.Updated.UTC().Format(time.DateTime+" MST"), age, staleNote)
+	fmt.Printf("    Versions: MicroOVN %s, OVN %s, Open vSwitch %s\n", facts.MicroOvnVersion, facts.OvnVersion, facts.OvsVersion)
+
+	if len(facts.Services) > 0 {
+		runtimeServices := []string{}
+		for service, state := range facts.Services {
+			runtimeServices = append(runtimeServices, fmt.Sprintf("%s (%s)", service, state))
+		}
+		sort.Strings(runtimeServices)
+		fmt.Printf("    Runtime: %s\n", strings.Join(runtimeServices, ", "))
+	}
+
+	if facts.EncapIP != "" {
+		fmt.Printf("    Encapsulation IP: %s\n", facts.EncapIP)
+	}
+
+	if len(facts.EncapOptions) > 0 {
+		encapOptions := []string{}
+		for option, value := range facts.EncapOptions {
+			encapOptions = append(encapOptions, fmt.Sprintf("%s=%s", option, value))
+		}
+		sort.Strings(encapOptions)
+		fmt.Printf("    Encapsulation options: %s\n", strings.Join(encapOptions, ", "))
+	}
+
+	if len(facts.RaftRoles) > 0 {
+		raftRoles := []string{}
+		for db, role := range facts.RaftRoles {
+			raftRoles = append(raftRoles, fmt.Sprintf("%s %s", db, role))
+		}
+		sort.Strings(raftRoles)
+		fmt.Printf("    RAFT roles: %s\n", strings.Join(raftRoles, ", "))
+	}
+
+	if len(facts.VirtualIPs) > 0 {
+		virtualIPs := []string{}
+		for db, virtualIP := range facts.VirtualIPs {
+			virtualIPs = append(virtualIPs, fmt.Sprintf("%s %s", db, virtualIP))
+		}
+		sort.Strings(virtualIPs)
+		fmt.Printf("    Virtual IPs: %s\n", strings.Join(virtualIPs, ", "))
+	}
+
+	if len(facts.ReadOnlyListeners) > 0 {
+		readOnlyListeners := []string{}
+		for db, targets := range facts.ReadOnlyListeners {
+			for _, target := range targets {
+				readOnlyListeners = append(readOnlyListeners, fmt.Sprintf("%s %s", db, target))
+			}
+		}
+		sort.Strings(readOnlyListeners)
+		fmt.Printf("    Read-only listeners: %s\n", strings.Join(readOnlyListeners, ", "))
+	}
+
+	if len(facts.CertificateExpiry) > 0 {
+		firstService := ""
+		var firstExpiry time.Time
+		for service, expiry := range facts.CertificateExpiry {
+			if firstService == "" || expiry.Before(firstExpiry) || (expiry.Equal(firstExpiry) && service < firstService) {
+				firstService = service
+				firstExpiry = expiry
+			}
+		}
+		fmt.Printf("    Earliest certificate expiry: %s (%s)\n", firstExpiry.UTC().Format(time.DateOnly), firstService)
+	}
+
+	if facts.BgpSessions != nil {
+		limitHit := ""
+		if facts.BgpSessions.LimitHit > 0 {
+			limitHit = fmt.Sprintf(", %d hit prefix limit", facts.BgpSessions.LimitHit)
+		}
+		fmt.Printf("    BGP sessions: %d/%d established%s\n", facts.BgpSessions.Established, facts.BgpSessions.Total, limitHit)
+	}
+
+	for _, job := range facts.DeferredJobs {
+		fmt.Printf("    Deferred: %s (until %s UTC)\n", job.Job, job.NextWindow.UTC().Format(time.DateTime))
+	}
+}
+
+// reportOvsdbSchemaStatus prints report about the currently active schema version and schema versions expected
+// by each node in the deployment. Schema versions are taken from facts published by cluster members. If any
+// member did not publish them (e.g. it runs older MicroOVN during an upgrade), schema versions are fetched
+// from every node directly instead.
+func reportOvsdbSchemaStatus(cli *microClusterClient.Client, ovsdbType ovnCmd.OvsdbType, memberFacts types.MemberFactsList, memberAddrs map[string]string) {
 	ovnDB, err := ovnCmd.NewOvsdbSpec(ovsdbType)
 	if err != nil {
 		printOvsdbSummaryError(err, nil)
 		return
 	}
 
-	activeSchema, errType := client.GetActiveOvsdbSchemaVersion(context.Background(), cli, ovnDB)
+	activeSchema, expectedSchemas, ok := ovsdbSchemaFromFacts(memberFacts, memberAddrs, ovnDB)
+	if ok {
+		printOvsdbSchemaReport(cli, ovnDB, activeSchema, expectedSchemas)
+		return
+	}
+
+	var errType types.OvsdbSchemaFetchError
+	activeSchema, errType = client.GetActiveOvsdbSchemaVersion(context.Background(), cli, ovnDB)
 	if errType != types.OvsdbSchemaFetchErrorNone {
 		printOvsdbSummaryError(
 			fmt.Errorf("failed to get OVN %s active schema version", ovnDB.FriendlyName),
@@ -95,7 +233,7 @@ func reportOvsdbSchemaStatus(cli *microClusterClient.Client, ovsdbType ovnCmd.Ov
 		)
 	}
 
-	expectedSchemas, err := client.GetAllExpectedOvsdbSchemaVersions(context.Background(), cli, ovnDB)
+	expectedSchemas, err = client.GetAllExpectedOvsdbSchemaVersions(context.Background(), cli, ovnDB)
 	if err != nil {
 		printOvsdbSummaryError(
 			fmt.Errorf("failed to get expected OVN %s schema versions", ovnDB.FriendlyName),
@@ -106,6 +244,51 @@ func reportOvsdbSchemaStatus(cli *microClusterClient.Client, ovsdbType ovnCmd.Ov
 	printOvsdbSchemaReport(cli, ovnDB, activeSchema, expectedSchemas)
 }
 
+// ovsdbSchemaFromFacts returns currently active schema version of the database "dbSpec" and schema versions
+// expected by each cluster member, as published in "memberFacts". Members are identified by their addresses
+// in "memberAddrs", which maps member names to addresses. It returns false if any cluster member did not
+// publish its expected schema version, or if no member published the active schema version.
+func ovsdbSchemaFromFacts(memberFacts types.MemberFactsList, memberAddrs map[string]string, dbSpec *ovnCmd.OvsdbSpec) (string, types.OvsdbSchemaReport, bool) {
+	factsByMember := make(map[string]types.MemberFacts, len(memberFacts))
+	for _, facts := range memberFacts {
+		factsByMember[facts.Member] = facts
+	}
+
+	members := make([]string, 0, len(memberAddrs))
+	for member := range memberAddrs {
+		members = append(members, member)
+	}
+	sort.Strings(members)
+
+	activeSchema := ""
+	expectedSchemas := types.OvsdbSchemaReport{}
+	for _, member := range members {
+		facts, ok := factsByMember[member]
+		if !ok {
+			return "", nil, false
+		}
+
+		expectedSchema, ok := facts.ExpectedSchemas[dbSpec.ShortName]
+		if !ok {
+			return "", nil, false
+		}
+		expectedSchemas = append(expectedSchemas, types.OvsdbSchemaVersionResult{
+			Host:          memberAddrs[member],
+			SchemaVersion: expectedSchema,
+		})
+
+		if activeSchema == "" {
+			activeSchema = facts.ActiveSchemas[dbSpec.ShortName]
+		}
+	}
+
+	if activeSchema == "" {
+		return "", nil, false
+	}
+
+	return activeSchema, expectedSchemas, true
+}
+
 // printOvsdbSchemaReport evaluates active and expected schema versions of given OVN database and prints the report. If
 // there's no attention of a user required, it prints simple "OK" message, otherwise it prints detailed reported about
 // the database's active schema version and schema versions expected on each node in the deployment.
@@ -1,9 +1,11 @@
 package main
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/canonical/microovn/microovn/api/types"
+	ovnCmd "github.com/canonical/microovn/microovn/ovn/cmd"
 )
 
 func _ovsdbSchemaRequiresAttention(
@@ -66,3 +68,54 @@ func TestUnexported_ovsdbSchemaRequiresAttentionClusterDiff(t *testing.T) {
 	_ovsdbSchemaRequiresAttention(clusterSchema, nodeError, activeSchema,
 		true, t)
 }
+
+func TestUnexported_ovsdbSchemaFromFacts(t *testing.T) {
+	dbSpec, err := ovnCmd.NewOvsdbSpec(ovnCmd.OvsdbTypeNBLocal)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	memberAddrs := map[string]string{"micro1": "10.0.0.1", "micro2": "10.0.0.2"}
+	memberFacts := types.MemberFactsList{
+		{
+			Member:          "micro2",
+			ExpectedSchemas: map[string]string{"nb": "7.4.0", "sb": "20.37.0"},
+		},
+		{
+			Member:          "micro1",
+			ExpectedSchemas: map[string]string{"nb": "7.3.0", "sb": "20.37.0"},
+			ActiveSchemas:   map[string]string{"nb": "7.3.0", "sb": "20.37.0"},
+		},
+	}
+
+	activeSchema, expectedSchemas, ok := ovsdbSchemaFromFacts(memberFacts, memberAddrs, dbSpec)
+	if !ok {
+		t.Fatalf("Expected schema versions to be found in facts")
+	}
+	if activeSchema != "7.3.0" {
+		t.Errorf("Expected active schema '7.3.0', got '%s'", activeSchema)
+	}
+
+	expected := types.OvsdbSchemaReport{
+		{Host: "10.0.0.1", SchemaVersion: "7.3.0"},
+		{Host: "10.0.0.2", SchemaVersion: "7.4.0"},
+	}
+	if !slices.Equal(expectedSchemas, expected) {
+		t.Errorf("Expected schema report %v, got %v", expected, expectedSchemas)
+	}
+
+	// Member that did not publish its facts
+	memberAddrs["micro3"] = "10.0.0.3"
+	_, _, ok = ovsdbSchemaFromFacts(memberFacts, memberAddrs, dbSpec)
+	if ok {
+		t.Errorf("Expected missing facts of a member to be reported")
+	}
+
+	// No member published active schema version
+	delete(memberAddrs, "micro3")
+	memberFacts[1].ActiveSchemas = nil
+	_, _, ok = ovsdbSchemaFromFacts(memberFacts, memberAddrs, dbSpec)
+	if ok {
+		t.Errorf("Expected missing active schema version to be reported")
+	}
+}
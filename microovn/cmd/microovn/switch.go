@@ -0,0 +1,25 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+type cmdSwitch struct {
+	common *CmdControl
+}
+
+// Command returns definition for "microovn switch" subcommand
+func (c *cmdSwitch) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "switch",
+		Short: "Inspect Open vSwitch managed by MicroOVN",
+	}
+
+	switchShowCmd := &cmdSwitchShow{common: c.common, vswitch: c}
+	cmd.AddCommand(switchShowCmd.Command())
+
+	switchStatsCmd := &cmdSwitchStats{common: c.common, vswitch: c}
+	cmd.AddCommand(switchStatsCmd.Command())
+
+	return cmd
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/canonical/microcluster/v2/microcluster"
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/client"
+)
+
+type cmdSwitchShow struct {
+	common     *CmdControl
+	vswitch    *cmdSwitch
+	flagMember string
+}
+
+// Command returns definition for "microovn switch show" subcommand
+func (c *cmdSwitchShow) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show bridges, ports and interfaces configured in Open vSwitch",
+		Args:  cobra.NoArgs,
+		RunE:  c.Run,
+	}
+
+	cmd.Flags().StringVar(
+		&c.flagMember,
+		"member",
+		"",
+		"Optional name of the cluster member to inspect (local member by default)",
+	)
+
+	return cmd
+}
+
+// Run method is an implementation of the "microovn switch show" subcommand
+func (c *cmdSwitchShow) Run(_ *cobra.Command, _ []string) error {
+	m, err := microcluster.App(microcluster.Args{StateDir: c.common.FlagStateDir})
+	if err != nil {
+		return err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	inventory, err := client.GetSwitchInventory(context.Background(), cli, c.flagMember)
+	if err != nil {
+		return err
+	}
+
+	printSwitchInventory(os.Stdout, inventory)
+	return nil
+}
+
+// printSwitchInventory writes human-readable representation of the OVS inventory into "w". Objects
+// managed by MicroOVN are marked with "[microovn]".
+func printSwitchInventory(w io.Writer, inventory types.SwitchInventory) {
+	managedMark := func(managed bool) string {
+		if managed {
+			return " [microovn]"
+		}
+		return ""
+	}
+
+	_, _ = fmt.Fprintf(w, "Member: %s\n", inventory.Member)
+	for _, bridge := range inventory.Bridges {
+		_, _ = fmt.Fprintf(w, "Bridge %s%s\n", bridge.Name, managedMark(bridge.Managed))
+		for _, port := range bridge.Ports {
+			_, _ = fmt.Fprintf(w, "    Port %s%s\n", port.Name, managedMark(port.Managed))
+			for _, iface := range port.Interfaces {
+				details := []string{}
+				if iface.Type != "" {
+					details = append(details, fmt.Sprintf("type: %s", iface.Type))
+				}
+				details = append(details, fmt.Sprintf("ofport: %d", iface.OfPort))
+				if iface.MTU > 0 {
+					details = append(details, fmt.Sprintf("mtu: %d", iface.MTU))
+				}
+				if iface.AdminState != "" {
+					details = append(details, fmt.Sprintf("admin: %s", iface.AdminState))
+				}
+				if iface.LinkState != "" {
+					details = append(details, fmt.Sprintf("link: %s", iface.LinkState))
+				}
+				if iface.IfaceID != "" {
+					details = append(details, fmt.Sprintf("iface-id: %s", iface.IfaceID))
+				}
+
+				_, _ = fmt.Fprintf(w, "        Interface %s%s\n", iface.Name, managedMark(iface.Managed))
+				_, _ = fmt.Fprintf(w, "            %s\n", strings.Join(details, ", "))
+			}
+		}
+	}
+
+	printSortedMap(w, "Bridge mappings", inventory.BridgeMappings)
+	printSortedMap(w, "External IDs", inventory.ExternalIDs)
+}
+
+// printSortedMap writes "title" followed by key/value pairs from "items" sorted by key into "w". Nothing
+// is written if "items" is empty.
+func printSortedMap(w io.Writer, title string, items map[string]string) {
+	if len(items) == 0 {
+		return
+	}
+
+	keys := make([]string, 0, len(items))
+	for key := range items {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	_, _ = fmt.Fprintf(w, "%s:\n", title)
+	for _, key := range keys {
+		_, _ = fmt.Fprintf(w, "    %s: %s\n", key, items[key])
+	}
+}
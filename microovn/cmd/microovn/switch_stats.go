@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/canonical/microcluster/v2/microcluster"
+	"github.com/spf13/cobra"
+
+	"github.com/canonical/microovn/microovn/client"
+)
+
+type cmdSwitchStats struct {
+	common     *CmdControl
+	vswitch    *cmdSwitch
+	flagMember string
+}
+
+// Command returns definition for "microovn switch stats" subcommand
+func (c *cmdSwitchStats) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show datapath flow statistics and conntrack usage",
+		Args:  cobra.NoArgs,
+		RunE:  c.Run,
+	}
+
+	cmd.Flags().StringVar(
+		&c.flagMember,
+		"member",
+		"",
+		"Optional name of the cluster member to inspect (local member by default)",
+	)
+
+	return cmd
+}
+
+// Run method is an implementation of the "microovn switch stats" subcommand
+func (c *cmdSwitchStats) Run(_ *cobra.Command, _ []string) error {
+	m, err := microcluster.App(microcluster.Args{StateDir: c.common.FlagStateDir})
+	if err != nil {
+		return err
+	}
+
+	cli, err := m.LocalClient()
+	if err != nil {
+		return err
+	}
+
+	stats, err := client.GetDatapathStats(context.Background(), cli, c.flagMember)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Member: %s\n", stats.Member)
+	for _, datapath := range stats.Datapaths {
+		fmt.Printf("Datapath %s\n", datapath.Name)
+		fmt.Printf("    Lookups: hit %d, missed %d, lost %d\n", datapath.Hits, datapath.Misses, datapath.Lost)
+		fmt.Printf("    Flows: %d\n", datapath.Flows)
+	}
+
+	fmt.Printf("Conntrack default limit: %s\n", formatConntrackLimit(stats.ConntrackDefaultLimit))
+	if len(stats.ConntrackZones) > 0 {
+		fmt.Println("Conntrack zones:")
+	}
+	for _, zone := range stats.ConntrackZones {
+		names := ""
+		if len(zone.Names) > 0 {
+			names = fmt.Sprintf(" (%s)", strings.Join(zone.Names, ", "))
+		}
+		fmt.Printf("    %d%s: %d/%s\n", zone.Zone, names, zone.Count, formatConntrackLimit(zone.Limit))
+	}
+
+	for _, warning := range stats.Warnings {
+		fmt.Printf("Warning: %s\n", warning)
+	}
+
+	return nil
+}
+
+// formatConntrackLimit returns human-readable representation of the conntrack limit.
+func formatConntrackLimit(limit int) string {
+	if limit == 0 {
+		return "unlimited"
+	}
+	return strconv.Itoa(limit)
+}
@@ -4,6 +4,7 @@ package main
 import (
 	"context"
 	"os"
+	"sync"
 
 	"github.com/canonical/lxd/shared/logger"
 	"github.com/canonical/microcluster/v2/microcluster"
@@ -13,7 +14,10 @@ import (
 
 	"github.com/canonical/microovn/microovn/api"
 	"github.com/canonical/microovn/microovn/database"
+	"github.com/canonical/microovn/microovn/facts"
 	"github.com/canonical/microovn/microovn/ovn"
+	ovnCluster "github.com/canonical/microovn/microovn/ovn/cluster"
+	"github.com/canonical/microovn/microovn/ovn/vswitch"
 	"github.com/canonical/microovn/microovn/version"
 )
 
@@ -65,6 +69,10 @@ func (c *cmdDaemon) Run(_ *cobra.Command, _ []string) error {
 		return err
 	}
 
+	// Virtual IPs are released when the daemon stops, wait for it after the "shutdownCtx" is cancelled
+	var virtualIPs sync.WaitGroup
+	defer virtualIPs.Wait()
+
 	shutdownCtx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -76,7 +84,16 @@ func (c *cmdDaemon) Run(_ *cobra.Command, _ []string) error {
 	}
 	h.PreRemove = ovn.Leave
 	h.PostRemove = func(ctx context.Context, s state.State, _ bool) error { return ovn.Refresh(shutdownCtx, ctx, s) }
-	h.OnStart = ovn.Start
+	h.OnStart = func(ctx context.Context, s state.State) error {
+		go facts.Run(shutdownCtx, s)
+		virtualIPs.Add(1)
+		go func() {
+			defer virtualIPs.Done()
+			ovnCluster.RunVirtualIPs(shutdownCtx, s)
+		}()
+		go vswitch.RunConntrackZoneRefresh(shutdownCtx, s)
+		return ovn.Start(ctx, s)
+	}
 
 	daemonArgs := microcluster.DaemonArgs{
 		Verbose:          c.global.flagLogVerbose,
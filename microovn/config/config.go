@@ -74,3 +74,71 @@ func DeleteConfig(ctx context.Context, s state.State, key string) error {
 	}
 	return nil
 }
+
+// SetMemberConfig function inserts or updates rows in the "member_config" table of the MicroOVN's database.
+// Unlike SetConfig, the value applies only to the cluster member specified by the "member" argument.
+func SetMemberConfig(ctx context.Context, s state.State, member string, key string, value string) error {
+	// Upsert config value in the database
+	err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		item := database.MemberConfigItem{Member: member, Key: key, Value: value}
+		exists, err := database.MemberConfigItemExists(ctx, tx, member, key)
+		if err != nil {
+			return fmt.Errorf("failed to check if config '%s' exists for member '%s': %s", key, member, err)
+		}
+		if exists {
+			err = database.UpdateMemberConfigItem(ctx, tx, member, key, item)
+		} else {
+			_, err = database.CreateMemberConfigItem(ctx, tx, item)
+		}
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set config '%s' for member '%s' into database: %s", key, member, err)
+	}
+	return nil
+}
+
+// GetMemberConfig function retrieves items from the member_config table of the MicroOVN's database. In case
+// that a row with the given member and key does not exist in the table, both returned item and error are nil.
+func GetMemberConfig(ctx context.Context, s state.State, member string, key string) (*database.MemberConfigItem, error) {
+	var err error
+	var item *database.MemberConfigItem
+	err = s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		exists, err := database.MemberConfigItemExists(ctx, tx, member, key)
+		if err != nil {
+			return fmt.Errorf("failed to check if config '%s' exists for member '%s': %s", key, member, err)
+		}
+
+		if !exists {
+			return nil
+		}
+
+		item, err = database.GetMemberConfigItem(ctx, tx, member, key)
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config '%s' for member '%s' from database: %v", key, member, err)
+	}
+	return item, nil
+}
+
+// DeleteMemberConfig removes an item with the specified member and key from the member_config table of the
+// MicroOVN's database. If the item is not present in the table, this function returns successfully.
+func DeleteMemberConfig(ctx context.Context, s state.State, member string, key string) error {
+	err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		exists, err := database.MemberConfigItemExists(ctx, tx, member, key)
+		if err != nil {
+			return fmt.Errorf("failed to check if config '%s' exists for member '%s': %s", key, member, err)
+		}
+		if !exists {
+			return nil
+		}
+		return database.DeleteMemberConfigItem(ctx, tx, member, key)
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to delete config '%s' for member '%s' from database: %s", key, member, err)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package database
+
+//go:generate -command mapper lxd-generate db mapper -t bgp_interface.mapper.go
+//go:generate mapper reset
+//
+//go:generate mapper stmt -d github.com/canonical/microcluster/v2/cluster -e BgpInterface objects table=bgp_interfaces
+//go:generate mapper stmt -d github.com/canonical/microcluster/v2/cluster -e BgpInterface objects-by-Member table=bgp_interfaces
+//go:generate mapper stmt -d github.com/canonical/microcluster/v2/cluster -e BgpInterface objects-by-Member-and-Iface table=bgp_interfaces
+//go:generate mapper stmt -d github.com/canonical/microcluster/v2/cluster -e BgpInterface id table=bgp_interfaces
+//go:generate mapper stmt -d github.com/canonical/microcluster/v2/cluster -e BgpInterface create table=bgp_interfaces
+//go:generate mapper stmt -d github.com/canonical/microcluster/v2/cluster -e BgpInterface delete-by-Member table=bgp_interfaces
+//
+//go:generate mapper method -i -d github.com/canonical/microcluster/v2/cluster -e BgpInterface GetMany table=bgp_interfaces
+//go:generate mapper method -i -d github.com/canonical/microcluster/v2/cluster -e BgpInterface GetOne table=bgp_interfaces
+//go:generate mapper method -i -d github.com/canonical/microcluster/v2/cluster -e BgpInterface ID table=bgp_interfaces
+//go:generate mapper method -i -d github.com/canonical/microcluster/v2/cluster -e BgpInterface Exists table=bgp_interfaces
+//go:generate mapper method -i -d github.com/canonical/microcluster/v2/cluster -e BgpInterface Create table=bgp_interfaces
+//go:generate mapper method -i -d github.com/canonical/microcluster/v2/cluster -e BgpInterface DeleteMany-by-Member table=bgp_interfaces
+
+// BgpInterface is used to track names of the system interfaces that MicroOVN creates on a particular
+// server to redirect BGP traffic from an external interface.
+type BgpInterface struct {
+	ID       int
+	Member   string `db:"primary=yes&join=core_cluster_members.name&joinon=bgp_interfaces.member_id"`
+	Iface    string `db:"primary=yes"`
+	Redirect string
+	Peer     string
+}
+
+// BgpInterfaceFilter is a required struct for use with lxd-generate. It is used for filtering fields on database fetches.
+type BgpInterfaceFilter struct {
+	Member *string
+	Iface  *string
+}
@@ -0,0 +1,34 @@
+package database
+
+import (
+	"time"
+)
+
+//go:generate -command mapper lxd-generate db mapper -t facts.mapper.go
+//go:generate mapper reset
+//
+//go:generate mapper stmt -d github.com/canonical/microcluster/v2/cluster -e FactsRecord objects table=member_facts
+//go:generate mapper stmt -d github.com/canonical/microcluster/v2/cluster -e FactsRecord objects-by-Member table=member_facts
+//go:generate mapper stmt -d github.com/canonical/microcluster/v2/cluster -e FactsRecord id table=member_facts
+//go:generate mapper stmt -d github.com/canonical/microcluster/v2/cluster -e FactsRecord create table=member_facts
+//go:generate mapper stmt -d github.com/canonical/microcluster/v2/cluster -e FactsRecord update table=member_facts
+//
+//go:generate mapper method -i -d github.com/canonical/microcluster/v2/cluster -e FactsRecord GetMany table=member_facts
+//go:generate mapper method -i -d github.com/canonical/microcluster/v2/cluster -e FactsRecord GetOne table=member_facts
+//go:generate mapper method -i -d github.com/canonical/microcluster/v2/cluster -e FactsRecord ID table=member_facts
+//go:generate mapper method -i -d github.com/canonical/microcluster/v2/cluster -e FactsRecord Exists table=member_facts
+//go:generate mapper method -i -d github.com/canonical/microcluster/v2/cluster -e FactsRecord Create table=member_facts
+//go:generate mapper method -i -d github.com/canonical/microcluster/v2/cluster -e FactsRecord Update table=member_facts
+
+// FactsRecord is used to track facts about OVN services that are periodically published by a particular server.
+type FactsRecord struct {
+	ID      int
+	Member  string `db:"primary=yes&join=core_cluster_members.name&joinon=member_facts.member_id"`
+	Facts   string
+	Updated time.Time
+}
+
+// FactsRecordFilter is a required struct for use with lxd-generate. It is used for filtering fields on database fetches.
+type FactsRecordFilter struct {
+	Member *string
+}
@@ -0,0 +1,34 @@
+package database
+
+//go:generate -command mapper lxd-generate db mapper -t host_port.mapper.go
+//go:generate mapper reset
+//
+//go:generate mapper stmt -d github.com/canonical/microcluster/v2/cluster -e HostPort objects table=host_ports
+//go:generate mapper stmt -d github.com/canonical/microcluster/v2/cluster -e HostPort objects-by-Member table=host_ports
+//go:generate mapper stmt -d github.com/canonical/microcluster/v2/cluster -e HostPort objects-by-Member-and-LogicalSwitch table=host_ports
+//go:generate mapper stmt -d github.com/canonical/microcluster/v2/cluster -e HostPort id table=host_ports
+//go:generate mapper stmt -d github.com/canonical/microcluster/v2/cluster -e HostPort create table=host_ports
+//go:generate mapper stmt -d github.com/canonical/microcluster/v2/cluster -e HostPort delete-by-Member-and-LogicalSwitch table=host_ports
+//
+//go:generate mapper method -i -d github.com/canonical/microcluster/v2/cluster -e HostPort GetMany table=host_ports
+//go:generate mapper method -i -d github.com/canonical/microcluster/v2/cluster -e HostPort GetOne table=host_ports
+//go:generate mapper method -i -d github.com/canonical/microcluster/v2/cluster -e HostPort ID table=host_ports
+//go:generate mapper method -i -d github.com/canonical/microcluster/v2/cluster -e HostPort Exists table=host_ports
+//go:generate mapper method -i -d github.com/canonical/microcluster/v2/cluster -e HostPort Create table=host_ports
+//go:generate mapper method -i -d github.com/canonical/microcluster/v2/cluster -e HostPort DeleteOne-by-Member-and-LogicalSwitch table=host_ports
+
+// HostPort is used to track host management ports that connect a particular server to an OVN Logical Switch.
+type HostPort struct {
+	ID            int
+	Member        string `db:"primary=yes&join=core_cluster_members.name&joinon=host_ports.member_id"`
+	LogicalSwitch string `db:"primary=yes"`
+	Iface         string
+	Mac           string
+	Addresses     string
+}
+
+// HostPortFilter is a required struct for use with lxd-generate. It is used for filtering fields on database fetches.
+type HostPortFilter struct {
+	Member        *string
+	LogicalSwitch *string
+}
@@ -0,0 +1,34 @@
+package database
+
+//go:generate -command mapper lxd-generate db mapper -t member_config.mapper.go
+//go:generate mapper reset
+//
+//go:generate mapper stmt -d github.com/canonical/microcluster/v2/cluster -e MemberConfigItem objects table=member_config
+//go:generate mapper stmt -d github.com/canonical/microcluster/v2/cluster -e MemberConfigItem objects-by-Member table=member_config
+//go:generate mapper stmt -d github.com/canonical/microcluster/v2/cluster -e MemberConfigItem objects-by-Member-and-Key table=member_config
+//go:generate mapper stmt -d github.com/canonical/microcluster/v2/cluster -e MemberConfigItem id table=member_config
+//go:generate mapper stmt -d github.com/canonical/microcluster/v2/cluster -e MemberConfigItem create table=member_config
+//go:generate mapper stmt -d github.com/canonical/microcluster/v2/cluster -e MemberConfigItem delete-by-Member-and-Key table=member_config
+//go:generate mapper stmt -d github.com/canonical/microcluster/v2/cluster -e MemberConfigItem update table=member_config
+//
+//go:generate mapper method -i -d github.com/canonical/microcluster/v2/cluster -e MemberConfigItem GetMany table=member_config
+//go:generate mapper method -i -d github.com/canonical/microcluster/v2/cluster -e MemberConfigItem GetOne table=member_config
+//go:generate mapper method -i -d github.com/canonical/microcluster/v2/cluster -e MemberConfigItem ID table=member_config
+//go:generate mapper method -i -d github.com/canonical/microcluster/v2/cluster -e MemberConfigItem Exists table=member_config
+//go:generate mapper method -i -d github.com/canonical/microcluster/v2/cluster -e MemberConfigItem Create table=member_config
+//go:generate mapper method -i -d github.com/canonical/microcluster/v2/cluster -e MemberConfigItem DeleteOne-by-Member-and-Key table=member_config
+//go:generate mapper method -i -d github.com/canonical/microcluster/v2/cluster -e MemberConfigItem Update table=member_config
+
+// MemberConfigItem is used to track the OVN configuration that applies only to a particular server.
+type MemberConfigItem struct {
+	ID     int
+	Member string `db:"primary=yes&join=core_cluster_members.name&joinon=member_config.member_id"`
+	Key    string `db:"primary=yes"`
+	Value  string
+}
+
+// MemberConfigItemFilter is a required struct for use with lxd-generate. It is used for filtering fields on database fetches.
+type MemberConfigItemFilter struct {
+	Member *string
+	Key    *string
+}
@@ -16,6 +16,10 @@ var SchemaExtensions = []schema.Update{
 	schemaUpdate1,
 	schemaUpdate2,
 	schemaUpdate3,
+	schemaUpdate4,
+	schemaUpdate5,
+	schemaUpdate6,
+	schemaUpdate7,
 }
 
 // getClusterTableName returns the name of the table that holds the record of cluster members from sqlite_master.
@@ -114,3 +118,84 @@ ALTER TABLE services_new RENAME TO services;
 
 	return err
 }
+
+// schemaUpdate4 adds the `member_config` table that holds configuration options which apply only
+// to a single cluster member.
+func schemaUpdate4(ctx context.Context, tx *sql.Tx) error {
+	stmt := `
+CREATE TABLE member_config (
+  id                            INTEGER  PRIMARY KEY AUTOINCREMENT NOT NULL,
+  member_id                     INTEGER  NOT  NULL,
+  key                           TEXT     NOT  NULL,
+  value                         TEXT     NOT  NULL,
+  FOREIGN KEY (member_id) REFERENCES "core_cluster_members" (id) ON DELETE CASCADE
+  UNIQUE(member_id, key)
+);
+	`
+
+	_, err := tx.ExecContext(ctx, stmt)
+
+	return err
+}
+
+// schemaUpdate5 adds the `bgp_interfaces` table that holds names of system interfaces used for
+// BGP redirect on each cluster member.
+func schemaUpdate5(ctx context.Context, tx *sql.Tx) error {
+	stmt := `
+CREATE TABLE bgp_interfaces (
+  id                            INTEGER  PRIMARY KEY AUTOINCREMENT NOT NULL,
+  member_id                     INTEGER  NOT  NULL,
+  iface                         TEXT     NOT  NULL,
+  redirect                      TEXT     NOT  NULL,
+  peer                          TEXT     NOT  NULL,
+  FOREIGN KEY (member_id) REFERENCES "core_cluster_members" (id) ON DELETE CASCADE
+  UNIQUE(member_id, iface)
+  UNIQUE(member_id, redirect)
+);
+	`
+
+	_, err := tx.ExecContext(ctx, stmt)
+
+	return err
+}
+
+// schemaUpdate6 adds the `member_facts` table that holds facts about OVN services periodically
+// published by each cluster member.
+func schemaUpdate6(ctx context.Context, tx *sql.Tx) error {
+	stmt := `
+CREATE TABLE member_facts (
+  id                            INTEGER  PRIMARY KEY AUTOINCREMENT NOT NULL,
+  member_id                     INTEGER  NOT  NULL,
+  facts                         TEXT     NOT  NULL,
+  updated                       DATETIME NOT  NULL,
+  FOREIGN KEY (member_id) REFERENCES "core_cluster_members" (id) ON DELETE CASCADE
+  UNIQUE(member_id)
+);
+	`
+
+	_, err := tx.ExecContext(ctx, stmt)
+
+	return err
+}
+
+// schemaUpdate7 adds the `host_ports` table that holds host management ports connecting each cluster
+// member to OVN Logical Switches.
+func schemaUpdate7(ctx context.Context, tx *sql.Tx) error {
+	stmt := `
+CREATE TABLE host_ports (
+  id                            INTEGER  PRIMARY KEY AUTOINCREMENT NOT NULL,
+  member_id                     INTEGER  NOT  NULL,
+  logical_switch                TEXT     NOT  NULL,
+  iface                         TEXT     NOT  NULL,
+  mac                           TEXT     NOT  NULL,
+  addresses                     TEXT     NOT  NULL,
+  FOREIGN KEY (member_id) REFERENCES "core_cluster_members" (id) ON DELETE CASCADE
+  UNIQUE(member_id, logical_switch)
+  UNIQUE(member_id, iface)
+);
+	`
+
+	_, err := tx.ExecContext(ctx, stmt)
+
+	return err
+}
@@ -0,0 +1,309 @@
+// Package facts implements collection of runtime facts about OVN services running on cluster members.
+// Every member periodically publishes its facts into the shared database, so that the cluster status
+// can be rendered without contacting each member individually.
+package facts
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v2/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/bgp"
+	"github.com/canonical/microovn/microovn/database"
+	"github.com/canonical/microovn/microovn/maintenance"
+	"github.com/canonical/microovn/microovn/node"
+	"github.com/canonical/microovn/microovn/ovn/certificates"
+	ovnCluster "github.com/canonical/microovn/microovn/ovn/cluster"
+	ovnCmd "github.com/canonical/microovn/microovn/ovn/cmd"
+	"github.com/canonical/microovn/microovn/ovn/environment"
+	"github.com/canonical/microovn/microovn/ovn/vswitch"
+	"github.com/canonical/microovn/microovn/snap"
+	"github.com/canonical/microovn/microovn/version"
+)
+
+// PublishInterval is a period in which each member collects and publishes its facts.
+const PublishInterval = 30 * time.Second
+
+// certificateServices is a list of services whose certificate expiration is included in the facts.
+var certificateServices = []string{"client", "ovnnb", "ovnsb", "ovn-northd", "ovn-controller", "ovs-manager"}
+
+// Collect gathers facts about OVN services running on the local member.
+func Collect(ctx context.Context, s state.State) (types.MemberFacts, error) {
+	facts := types.MemberFacts{
+		Member:            s.Name(),
+		MicroOvnVersion:   version.MicroOvnVersion,
+		OvnVersion:        version.OvnVersion,
+		OvsVersion:        version.OvsVersion,
+		Services:          make(map[string]string),
+		RaftRoles:         make(map[string]string),
+		CertificateExpiry: make(map[string]time.Time),
+	}
+
+	services, err := localServices(ctx, s)
+	if err != nil {
+		return facts, fmt.Errorf("failed to query local services: %w", err)
+	}
+
+	for _, service := range services {
+		for _, snapService := range node.SnapServices(service) {
+			active, err := snap.IsActive(snapService)
+			if err != nil {
+				logger.Debugf("Failed to get state of service '%s': %v", snapService, err)
+				facts.Services[snapService] = "unknown"
+				continue
+			}
+
+			if active {
+				facts.Services[snapService] = "active"
+			} else {
+				facts.Services[snapService] = "inactive"
+			}
+		}
+	}
+
+	if slices.Contains(services, types.SrvChassis) {
+		facts.EncapIP, err = encapIP(ctx, s)
+		if err != nil {
+			logger.Debugf("Failed to get encapsulation IP: %v", err)
+		}
+
+		facts.EncapOptions, err = vswitch.AppliedEncapOptions(ctx, s)
+		if err != nil {
+			logger.Debugf("Failed to get tunnel encapsulation options: %v", err)
+		}
+	}
+
+	if slices.Contains(services, types.SrvCentral) {
+		facts.RaftRoles, err = raftRoles(ctx, s)
+		if err != nil {
+			logger.Debugf("Failed to get RAFT roles: %v", err)
+		}
+		facts.VirtualIPs = ovnCluster.HeldVirtualIPs()
+		facts.ReadOnlyListeners, err = ovnCluster.ReadOnlyListenerTargets(ctx, s)
+		if err != nil {
+			logger.Debugf("Failed to get read-only listeners: %v", err)
+		}
+	}
+
+	isCentral := slices.Contains(services, types.SrvCentral)
+	facts.ExpectedSchemas, facts.ActiveSchemas, err = schemaVersions(ctx, s, isCentral)
+	if err != nil {
+		logger.Debugf("Failed to get OVSDB schema versions: %v", err)
+	}
+
+	if slices.Contains(services, types.SrvBgp) {
+		facts.BgpSessions, err = bgp.SessionSummary(ctx)
+		if err != nil {
+			logger.Debugf("Failed to get BGP session summary: %v", err)
+		}
+	}
+
+	for _, service := range certificateServices {
+		expiry, err := certificates.ServiceCertificateExpiry(service)
+		if err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				logger.Debugf("Failed to get expiration of %s certificate: %v", service, err)
+			}
+			continue
+		}
+		facts.CertificateExpiry[service] = expiry
+	}
+
+	facts.DeferredJobs = maintenance.Deferred()
+
+	return facts, nil
+}
+
+// Publish stores "facts" of the local member in the shared database, replacing previously published facts.
+func Publish(ctx context.Context, s state.State, facts types.MemberFacts) error {
+	rawFacts, err := json.Marshal(facts)
+	if err != nil {
+		return fmt.Errorf("failed to serialize member facts: %w", err)
+	}
+
+	record := database.FactsRecord{
+		Member:  s.Name(),
+		Facts:   string(rawFacts),
+		Updated: facts.Updated,
+	}
+
+	return s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		exists, err := database.FactsRecordExists(ctx, tx, record.Member)
+		if err != nil {
+			return err
+		}
+
+		if exists {
+			return database.UpdateFactsRecord(ctx, tx, record.Member, record)
+		}
+
+		_, err = database.CreateFactsRecord(ctx, tx, record)
+		return err
+	})
+}
+
+// List returns facts most recently published by every cluster member.
+func List(ctx context.Context, s state.State) (types.MemberFactsList, error) {
+	result := types.MemberFactsList{}
+	err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		records, err := database.GetFactsRecords(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		for _, record := range records {
+			facts := types.MemberFacts{}
+			err = json.Unmarshal([]byte(record.Facts), &facts)
+			if err != nil {
+				logger.Warnf("Failed to parse facts published by member '%s': %v", record.Member, err)
+				continue
+			}
+
+			facts.Member = record.Member
+			facts.Updated = record.Updated
+			result = append(result, facts)
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// Run periodically collects and publishes facts of the local member until the "ctx" is cancelled.
+func Run(ctx context.Context, s state.State) {
+	ticker := time.NewTicker(PublishInterval)
+	defer ticker.Stop()
+
+	for {
+		// Skip if the database isn't ready, the member might not be part of the cluster yet.
+		err := s.Database().IsOpen(ctx)
+		if err == nil {
+			err = CollectAndPublish(ctx, s)
+			if err != nil {
+				logger.Warnf("Failed to publish member facts: %v", err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CollectAndPublish collects facts of the local member and publishes them in the shared database.
+func CollectAndPublish(ctx context.Context, s state.State) error {
+	facts, err := Collect(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	facts.Updated = time.Now().UTC()
+	return Publish(ctx, s, facts)
+}
+
+// localServices returns list of MicroOVN services enabled on the local member.
+func localServices(ctx context.Context, s state.State) ([]types.SrvName, error) {
+	services, err := node.ListServices(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+
+	localServices := []types.SrvName{}
+	for _, service := range services {
+		if service.Location == s.Name() {
+			localServices = append(localServices, service.Service)
+		}
+	}
+
+	return localServices, nil
+}
+
+// encapIP returns IP address used by the local chassis for tunnel encapsulation.
+func encapIP(ctx context.Context, s state.State) (string, error) {
+	out, err := ovnCmd.VSCtl(ctx, s, "--if-exists", "get", "Open_vSwitch", ".", "external_ids:ovn-encap-ip")
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Trim(strings.TrimSpace(out), "\""), nil
+}
+
+// raftRoles returns RAFT roles of the local OVN Northbound and Southbound database servers. Roles are not
+// reported when the databases run in the standalone mode.
+func raftRoles(ctx context.Context, s state.State) (map[string]string, error) {
+	roles := make(map[string]string)
+
+	standalone, err := environment.IsStandaloneDatabase(ctx, s)
+	if err != nil || standalone {
+		return roles, err
+	}
+
+	var errs []error
+	for _, dbType := range []ovnCmd.OvsdbType{ovnCmd.OvsdbTypeNBLocal, ovnCmd.OvsdbTypeSBLocal} {
+		dbSpec, err := ovnCmd.NewOvsdbSpec(dbType)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		role, err := ovnCluster.RaftRole(ctx, s, dbType)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if role != "" {
+			roles[dbSpec.ShortName] = role
+		}
+	}
+
+	return roles, errors.Join(errs...)
+}
+
+// schemaVersions returns schema versions of the OVN Northbound and Southbound databases that are shipped with
+// the local OVN packages. If "isCentral" is true, schema versions currently used by the local database servers
+// are returned as well.
+func schemaVersions(ctx context.Context, s state.State, isCentral bool) (map[string]string, map[string]string, error) {
+	expected := make(map[string]string)
+	active := make(map[string]string)
+
+	var errs []error
+	for _, dbType := range []ovnCmd.OvsdbType{ovnCmd.OvsdbTypeNBLocal, ovnCmd.OvsdbTypeSBLocal} {
+		dbSpec, err := ovnCmd.NewOvsdbSpec(dbType)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		expected[dbSpec.ShortName], err = ovnCmd.OvsdbSchemaVersion(ctx, dbSpec)
+		if err != nil {
+			errs = append(errs, err)
+			delete(expected, dbSpec.ShortName)
+		}
+
+		if !isCentral {
+			continue
+		}
+
+		activeSchema, err := ovnCmd.OvsdbClient(ctx, s, dbSpec, 10, 5, "get-schema-version", dbSpec.SocketURL, dbSpec.Name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get active %s DB schema version: %w", dbSpec.FriendlyName, err))
+			continue
+		}
+		active[dbSpec.ShortName] = strings.TrimSpace(activeSchema)
+	}
+
+	return expected, active, errors.Join(errs...)
+}
@@ -0,0 +1,155 @@
+// Package maintenance implements maintenance windows. Disruptive jobs that MicroOVN runs automatically
+// (e.g. OVN database schema upgrade or certificate renewal) consult the configured windows and defer
+// themselves until the next window opens, unless they became urgent.
+package maintenance
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v2/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/config"
+)
+
+// WindowsConfigKey - cluster config option that defines maintenance windows in UTC. Value is a
+// semicolon-separated list of windows in the "<minute> <hour> <day of month> <month> <day of week> <duration>"
+// format. If the option is not set, disruptive jobs are allowed to run at any time.
+const WindowsConfigKey = "maintenance.windows"
+
+// UrgencyThresholdConfigKey - cluster config option that sets how long before its deadline a deferred job
+// becomes urgent and is allowed to run outside of maintenance windows.
+const UrgencyThresholdConfigKey = "maintenance.urgency-threshold"
+
+// DefaultUrgencyThreshold is used when the "maintenance.urgency-threshold" option is not set.
+const DefaultUrgencyThreshold = 24 * time.Hour
+
+// RecheckInterval is a recommended period in which deferred jobs should check again whether they are
+// allowed to run.
+const RecheckInterval = time.Minute
+
+// SchemaUpgradeJob returns name of the job that upgrades schema of the OVN database "dbName" (e.g. "nb").
+func SchemaUpgradeJob(dbName string) string {
+	return fmt.Sprintf("%s-schema-upgrade", dbName)
+}
+
+// deferredJobs holds jobs on the local member that are currently waiting for a maintenance window.
+var deferredJobs = struct {
+	sync.Mutex
+	jobs map[string]types.DeferredJob
+}{jobs: make(map[string]types.DeferredJob)}
+
+// ParseUrgencyThreshold parses value of the "maintenance.urgency-threshold" config option.
+func ParseUrgencyThreshold(value string) (time.Duration, error) {
+	threshold, err := time.ParseDuration(value)
+	if err != nil || threshold < 0 {
+		return 0, fmt.Errorf("threshold '%s' is not a non-negative duration (e.g. '24h')", value)
+	}
+	return threshold, nil
+}
+
+// configuredWindows returns maintenance windows defined by the "maintenance.windows" config option. Empty
+// list is returned if the option is not set.
+func configuredWindows(ctx context.Context, s state.State) ([]Window, error) {
+	item, err := config.GetConfig(ctx, s, WindowsConfigKey)
+	if err != nil || item == nil {
+		return []Window{}, err
+	}
+	return ParseWindows(item.Value)
+}
+
+// urgencyThreshold returns value of the "maintenance.urgency-threshold" config option or the default
+// threshold if the option is not set.
+func urgencyThreshold(ctx context.Context, s state.State) (time.Duration, error) {
+	item, err := config.GetConfig(ctx, s, UrgencyThresholdConfigKey)
+	if err != nil || item == nil {
+		return DefaultUrgencyThreshold, err
+	}
+	return ParseUrgencyThreshold(item.Value)
+}
+
+// decide returns true if a job with the "deadline" is allowed to run at time "now". If the job is not
+// allowed, start of the next maintenance window is returned as well. Zero "deadline" means that the job
+// never becomes urgent.
+func decide(windows []Window, threshold time.Duration, deadline time.Time, now time.Time) (bool, time.Time) {
+	if len(windows) == 0 || InWindow(windows, now) {
+		return true, time.Time{}
+	}
+
+	if !deadline.IsZero() && deadline.Sub(now) <= threshold {
+		return true, time.Time{}
+	}
+
+	return false, NextWindow(windows, now)
+}
+
+// Allowed returns true if the disruptive "job" is allowed to run now. Jobs are allowed to run if no maintenance
+// windows are configured, if any of the windows is currently open, or if the job's "deadline" (e.g. expiration
+// of a certificate) is closer than the urgency threshold. Zero "deadline" means that the job never becomes
+// urgent.
+//
+// If the job is not allowed to run, it is recorded as deferred and start of the next maintenance window is
+// returned.
+func Allowed(ctx context.Context, s state.State, job string, deadline time.Time) (bool, time.Time, error) {
+	windows, err := configuredWindows(ctx, s)
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("failed to get maintenance windows: %w", err)
+	}
+
+	threshold, err := urgencyThreshold(ctx, s)
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("failed to get maintenance urgency threshold: %w", err)
+	}
+
+	now := time.Now().UTC()
+	allowed, next := decide(windows, threshold, deadline, now)
+
+	deferredJobs.Lock()
+	defer deferredJobs.Unlock()
+	if allowed {
+		if _, ok := deferredJobs.jobs[job]; ok {
+			logger.Infof("Deferred job '%s' is allowed to run", job)
+			delete(deferredJobs.jobs, job)
+		}
+		return true, time.Time{}, nil
+	}
+
+	deferred, ok := deferredJobs.jobs[job]
+	if !ok {
+		logger.Infof("Job '%s' deferred until next maintenance window (%s)", job, next.Format(time.DateTime))
+		deferred = types.DeferredJob{Job: job, Since: now}
+	}
+	deferred.NextWindow = next
+	if !deadline.IsZero() {
+		deferred.Deadline = deadline.UTC()
+	}
+	deferredJobs.jobs[job] = deferred
+
+	return false, next, nil
+}
+
+// Deferred returns jobs on the local member that are currently waiting for a maintenance window.
+func Deferred() []types.DeferredJob {
+	deferredJobs.Lock()
+	defer deferredJobs.Unlock()
+
+	jobs := make([]types.DeferredJob, 0, len(deferredJobs.jobs))
+	for _, job := range deferredJobs.jobs {
+		jobs = append(jobs, job)
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].Job < jobs[j].Job })
+	return jobs
+}
+
+// Clear removes "job" from the list of deferred jobs. It should be called when a deferred job is no longer
+// needed (e.g. because it was already carried out by another member).
+func Clear(job string) {
+	deferredJobs.Lock()
+	defer deferredJobs.Unlock()
+	delete(deferredJobs.jobs, job)
+}
@@ -0,0 +1,255 @@
+package maintenance
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxWindowDuration is the longest allowed duration of a single maintenance window.
+const maxWindowDuration = 7 * 24 * time.Hour
+
+// nextWindowSearchLimit limits how far into the future is searched for the start of the next maintenance window.
+// It's long enough to find windows that open only on the 29th of February, which may be up to eight years apart.
+const nextWindowSearchLimit = 8 * 366 * 24 * time.Hour
+
+// cronField represents set of allowed values for a single field of the cron expression.
+type cronField map[int]bool
+
+// cronSchedule represents parsed cron expression with five fields (minute, hour, day of month, month
+// and day of week).
+type cronSchedule struct {
+	Minute     cronField
+	Hour       cronField
+	DayOfMonth cronField
+	Month      cronField
+	DayOfWeek  cronField
+	anyDom     bool // True if the day of month field is "*"
+	anyDow     bool // True if the day of week field is "*"
+}
+
+// Window represents a recurring maintenance window. It starts at every time that matches the cron expression
+// and lasts for the specified duration.
+type Window struct {
+	schedule cronSchedule
+	Duration time.Duration
+	raw      string
+}
+
+// String returns the original definition of the window.
+func (w Window) String() string {
+	return w.raw
+}
+
+// dayOfWeekNames maps names of the days, accepted in the day of week field, to their numbers.
+var dayOfWeekNames = map[string]int{"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6}
+
+// parseCronValue parses single value of the cron field. Day names are accepted if "names" is not nil.
+func parseCronValue(value string, names map[string]int) (int, error) {
+	if names != nil {
+		if number, ok := names[strings.ToUpper(value)]; ok {
+			return number, nil
+		}
+	}
+	return strconv.Atoi(value)
+}
+
+// parseCronField parses single field of the cron expression. Supported syntax is "*", single values, ranges
+// ("1-5"), lists ("1,3,5") and steps ("*/15", "0-30/10").
+func parseCronField(field string, minValue int, maxValue int, names map[string]int) (cronField, error) {
+	result := make(cronField)
+	for _, part := range strings.Split(field, ",") {
+		rangePart, rawStep, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(rawStep)
+			if err != nil || step < 1 {
+				return nil, fmt.Errorf("invalid step '%s'", rawStep)
+			}
+		}
+
+		start, end := minValue, maxValue
+		if rangePart != "*" {
+			rawStart, rawEnd, isRange := strings.Cut(rangePart, "-")
+			var err error
+			start, err = parseCronValue(rawStart, names)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value '%s'", rawStart)
+			}
+
+			end = start
+			if isRange {
+				end, err = parseCronValue(rawEnd, names)
+				if err != nil {
+					return nil, fmt.Errorf("invalid value '%s'", rawEnd)
+				}
+			} else if hasStep {
+				end = maxValue
+			}
+		}
+
+		if start < minValue || end > maxValue || start > end {
+			return nil, fmt.Errorf("value '%s' is out of range %d-%d", part, minValue, maxValue)
+		}
+
+		for value := start; value <= end; value += step {
+			result[value] = true
+		}
+	}
+	return result, nil
+}
+
+// parseCronSchedule parses cron expression with five fields.
+func parseCronSchedule(fields []string) (cronSchedule, error) {
+	if len(fields) != 5 {
+		return cronSchedule{}, fmt.Errorf("cron expression must have 5 fields, got %d", len(fields))
+	}
+
+	var schedule cronSchedule
+	var err error
+	fieldSpecs := []struct {
+		name     string
+		target   *cronField
+		minValue int
+		maxValue int
+		names    map[string]int
+	}{
+		{"minute", &schedule.Minute, 0, 59, nil},
+		{"hour", &schedule.Hour, 0, 23, nil},
+		{"day of month", &schedule.DayOfMonth, 1, 31, nil},
+		{"month", &schedule.Month, 1, 12, nil},
+		{"day of week", &schedule.DayOfWeek, 0, 7, dayOfWeekNames},
+	}
+
+	for i, spec := range fieldSpecs {
+		*spec.target, err = parseCronField(fields[i], spec.minValue, spec.maxValue, spec.names)
+		if err != nil {
+			return cronSchedule{}, fmt.Errorf("invalid %s field '%s': %w", spec.name, fields[i], err)
+		}
+	}
+
+	// Both 0 and 7 represent Sunday
+	if schedule.DayOfWeek[7] {
+		schedule.DayOfWeek[0] = true
+	}
+	schedule.anyDom = fields[2] == "*"
+	schedule.anyDow = fields[4] == "*"
+
+	return schedule, nil
+}
+
+// matches returns true if the time "t" (truncated to minutes) matches the schedule. Same as in cron, if both
+// day of month and day of week fields are restricted, the time matches if either of them matches.
+func (c cronSchedule) matches(t time.Time) bool {
+	return c.Minute[t.Minute()] && c.Hour[t.Hour()] && c.matchesDay(t)
+}
+
+// matchesDay returns true if the date of time "t" matches month, day of month and day of week fields of the
+// schedule.
+func (c cronSchedule) matchesDay(t time.Time) bool {
+	if !c.Month[int(t.Month())] {
+		return false
+	}
+
+	domMatch := c.DayOfMonth[t.Day()]
+	dowMatch := c.DayOfWeek[int(t.Weekday())]
+	switch {
+	case c.anyDom && c.anyDow:
+		return true
+	case c.anyDom:
+		return dowMatch
+	case c.anyDow:
+		return domMatch
+	default:
+		return domMatch || dowMatch
+	}
+}
+
+// ParseWindows parses value of the "maintenance.windows" config option. Windows are separated by semicolons
+// and each window is defined by a cron expression with five fields, followed by the duration of the window
+// (e.g. "0 2 * * SAT 4h").
+func ParseWindows(value string) ([]Window, error) {
+	windows := []Window{}
+	for _, rawWindow := range strings.Split(value, ";") {
+		rawWindow = strings.TrimSpace(rawWindow)
+		fields := strings.Fields(rawWindow)
+		if len(fields) != 6 {
+			return nil, fmt.Errorf(
+				"window '%s' does not conform to the '<minute> <hour> <day of month> <month> <day of week> <duration>' format",
+				rawWindow,
+			)
+		}
+
+		schedule, err := parseCronSchedule(fields[:5])
+		if err != nil {
+			return nil, fmt.Errorf("invalid window '%s': %w", rawWindow, err)
+		}
+
+		duration, err := time.ParseDuration(fields[5])
+		if err != nil || duration < time.Minute || duration > maxWindowDuration {
+			return nil, fmt.Errorf("invalid window '%s': duration must be between 1m and %s", rawWindow, maxWindowDuration)
+		}
+
+		window := Window{schedule: schedule, Duration: duration, raw: rawWindow}
+		if window.nextStart(time.Now()).IsZero() {
+			return nil, fmt.Errorf("invalid window '%s': it never opens", rawWindow)
+		}
+
+		windows = append(windows, window)
+	}
+	return windows, nil
+}
+
+// activeAt returns true if the window is open at time "t".
+func (w Window) activeAt(t time.Time) bool {
+	t = t.UTC().Truncate(time.Minute)
+	for start := t; t.Sub(start) < w.Duration; start = start.Add(-time.Minute) {
+		if w.schedule.matches(start) {
+			return true
+		}
+	}
+	return false
+}
+
+// nextStart returns the first time after "t" at which the window opens. Zero time is returned if the window
+// does not open within the search limit.
+func (w Window) nextStart(t time.Time) time.Time {
+	start := t.UTC().Truncate(time.Minute).Add(time.Minute)
+	for candidate := start; candidate.Sub(start) < nextWindowSearchLimit; {
+		if !w.schedule.matchesDay(candidate) {
+			// Skip to the midnight of the following day
+			candidate = time.Date(candidate.Year(), candidate.Month(), candidate.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+
+		if w.schedule.matches(candidate) {
+			return candidate
+		}
+		candidate = candidate.Add(time.Minute)
+	}
+	return time.Time{}
+}
+
+// InWindow returns true if any of the "windows" is open at time "t".
+func InWindow(windows []Window, t time.Time) bool {
+	for _, window := range windows {
+		if window.activeAt(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// NextWindow returns the earliest time after "t" at which any of the "windows" opens.
+func NextWindow(windows []Window, t time.Time) time.Time {
+	var next time.Time
+	for _, window := range windows {
+		start := window.nextStart(t)
+		if !start.IsZero() && (next.IsZero() || start.Before(next)) {
+			next = start
+		}
+	}
+	return next
+}
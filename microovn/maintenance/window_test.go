@@ -0,0 +1,176 @@
+package maintenance
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseWindows(t *testing.T) {
+	windows, err := ParseWindows("0 2 * * SAT 4h; 30 */6 1,15 1-6 * 30m")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(windows) != 2 {
+		t.Fatalf("Expected 2 windows, got %d", len(windows))
+	}
+
+	if windows[0].Duration != 4*time.Hour || windows[0].String() != "0 2 * * SAT 4h" {
+		t.Errorf("Unexpected first window: '%s' (%s)", windows[0], windows[0].Duration)
+	}
+
+	invalidValues := []string{
+		"",
+		"0 2 * * 6",
+		"0 2 * * 6 4h extra",
+		"60 2 * * 6 4h",
+		"0 24 * * 6 4h",
+		"0 2 0 * 6 4h",
+		"0 2 * 13 6 4h",
+		"0 2 * * 8 4h",
+		"0 2 * * FOO 4h",
+		"0 2 * * 6 30s",
+		"0 2 * * 6 200h",
+		"0 5-2 * * 6 4h",
+		"*/0 2 * * 6 4h",
+		"0 2 * * 6 4h;",
+		// Windows that never open
+		"0 0 30 2 * 1h",
+		"0 0 31 4 * 1h",
+	}
+	for _, value := range invalidValues {
+		_, err = ParseWindows(value)
+		if err == nil {
+			t.Errorf("Expected value '%s' to be rejected", value)
+		}
+	}
+}
+
+func TestInWindow(t *testing.T) {
+	// Saturday 02:00 - 06:00 UTC
+	windows, err := ParseWindows("0 2 * * 6 4h")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		time     time.Time
+		expected bool
+	}{
+		{time.Date(2024, 1, 6, 1, 59, 0, 0, time.UTC), false},
+		{time.Date(2024, 1, 6, 2, 0, 0, 0, time.UTC), true},
+		{time.Date(2024, 1, 6, 5, 59, 59, 0, time.UTC), true},
+		{time.Date(2024, 1, 6, 6, 0, 0, 0, time.UTC), false},
+		{time.Date(2024, 1, 7, 3, 0, 0, 0, time.UTC), false},
+		// Times in other time zones are converted to UTC
+		{time.Date(2024, 1, 6, 3, 0, 0, 0, time.FixedZone("CET", 3600)), true},
+	}
+
+	for _, testCase := range testCases {
+		result := InWindow(windows, testCase.time)
+		if result != testCase.expected {
+			t.Errorf("Expected InWindow at %s to be %t, got %t", testCase.time, testCase.expected, result)
+		}
+	}
+}
+
+func TestInWindow_DayOfMonthOrWeek(t *testing.T) {
+	// Same as in cron, restricted day of month and day of week match if either of them matches
+	windows, err := ParseWindows("0 0 1 * SUN 1h")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !InWindow(windows, time.Date(2024, 2, 1, 0, 30, 0, 0, time.UTC)) {
+		t.Errorf("Expected window to be open on the first day of the month")
+	}
+
+	if !InWindow(windows, time.Date(2024, 1, 7, 0, 30, 0, 0, time.UTC)) {
+		t.Errorf("Expected window to be open on Sunday")
+	}
+
+	if InWindow(windows, time.Date(2024, 1, 8, 0, 30, 0, 0, time.UTC)) {
+		t.Errorf("Expected window to be closed on Monday")
+	}
+}
+
+func TestNextWindow(t *testing.T) {
+	windows, err := ParseWindows("0 2 * * 6 4h;30 22 * * 3 1h")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Monday
+	next := NextWindow(windows, time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	expected := time.Date(2024, 1, 3, 22, 30, 0, 0, time.UTC)
+	if !next.Equal(expected) {
+		t.Errorf("Expected next window at %s, got %s", expected, next)
+	}
+
+	// Inside the Saturday window, next start is the following Wednesday
+	next = NextWindow(windows, time.Date(2024, 1, 6, 2, 0, 0, 0, time.UTC))
+	expected = time.Date(2024, 1, 10, 22, 30, 0, 0, time.UTC)
+	if !next.Equal(expected) {
+		t.Errorf("Expected next window at %s, got %s", expected, next)
+	}
+
+	// 29th of February happens only in leap years
+	windows, err = ParseWindows("0 0 29 2 * 1h")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	next = NextWindow(windows, time.Date(2097, 1, 1, 0, 0, 0, 0, time.UTC))
+	expected = time.Date(2104, 2, 29, 0, 0, 0, 0, time.UTC)
+	if !next.Equal(expected) {
+		t.Errorf("Expected next window at %s, got %s", expected, next)
+	}
+
+	// 30th of February never happens
+	schedule, err := parseCronSchedule(strings.Fields("0 0 30 2 *"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	next = NextWindow([]Window{{schedule: schedule, Duration: time.Hour}}, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	if !next.IsZero() {
+		t.Errorf("Expected no next window, got %s", next)
+	}
+}
+
+func TestUnexported_decide(t *testing.T) {
+	windows, err := ParseWindows("0 2 * * 6 4h")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Monday, outside of the window
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	nextWindow := time.Date(2024, 1, 6, 2, 0, 0, 0, time.UTC)
+
+	allowed, _ := decide([]Window{}, DefaultUrgencyThreshold, time.Time{}, now)
+	if !allowed {
+		t.Errorf("Expected job to be allowed when no windows are configured")
+	}
+
+	allowed, next := decide(windows, DefaultUrgencyThreshold, time.Time{}, now)
+	if allowed || !next.Equal(nextWindow) {
+		t.Errorf("Expected job to be deferred until %s, got allowed=%t, next=%s", nextWindow, allowed, next)
+	}
+
+	allowed, _ = decide(windows, DefaultUrgencyThreshold, now.Add(48*time.Hour), now)
+	if allowed {
+		t.Errorf("Expected job with distant deadline to be deferred")
+	}
+
+	allowed, _ = decide(windows, DefaultUrgencyThreshold, now.Add(12*time.Hour), now)
+	if !allowed {
+		t.Errorf("Expected urgent job to be allowed")
+	}
+
+	allowed, _ = decide(windows, DefaultUrgencyThreshold, time.Time{}, nextWindow.Add(time.Hour))
+	if !allowed {
+		t.Errorf("Expected job to be allowed inside the window")
+	}
+}
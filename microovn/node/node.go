@@ -6,14 +6,17 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"net"
 	"os"
 
 	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v2/client"
 	"github.com/canonical/microcluster/v2/cluster"
 	"github.com/canonical/microcluster/v2/state"
 
 	"github.com/canonical/microovn/microovn/api/types"
 	"github.com/canonical/microovn/microovn/bgp"
+	microovnClient "github.com/canonical/microovn/microovn/client"
 	"github.com/canonical/microovn/microovn/database"
 	"github.com/canonical/microovn/microovn/ovn/certificates"
 	ovnCluster "github.com/canonical/microovn/microovn/ovn/cluster"
@@ -101,6 +104,15 @@ func EnableService(ctx context.Context, s state.State, service types.SrvName, ex
 		return errors.New("service does not exist")
 	}
 
+	// Standalone OVN databases need to be converted to clustered databases before
+	// another central member can join them.
+	if service == types.SrvCentral {
+		err = ensureClusteredDatabases(ctx, s)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
 		_, err := database.CreateService(ctx, tx, database.Service{Member: s.Name(), Service: service})
 		return err
@@ -246,6 +258,54 @@ func ServiceWarnings(ctx context.Context, s state.State) (types.WarningSet, erro
 	return output, nil
 }
 
+// ensureClusteredDatabases requests conversion of standalone OVN databases to clustered databases
+// from the member that runs them. It has no effect if the databases are already clustered, or if
+// there's no other member with central service enabled.
+func ensureClusteredDatabases(ctx context.Context, s state.State) error {
+	standalone, err := environment.IsStandaloneDatabase(ctx, s)
+	if err != nil {
+		return fmt.Errorf("failed to get OVN database mode: %w", err)
+	}
+
+	if !standalone {
+		return nil
+	}
+
+	centrals, err := FindService(ctx, s, types.SrvCentral)
+	if err != nil {
+		return err
+	}
+
+	if len(centrals) == 0 {
+		return nil
+	}
+
+	centralAddrs := make(map[string]string, len(centrals))
+	for _, central := range centrals {
+		centralAddrs[central.Address] = central.Name
+	}
+
+	clusterClient, err := s.Cluster(false)
+	if err != nil {
+		return fmt.Errorf("failed to get a client for every cluster member: %w", err)
+	}
+
+	return clusterClient.Query(ctx, false, func(ctx context.Context, c *client.Client) error {
+		clientURL := c.URL()
+		memberName, ok := centralAddrs[net.JoinHostPort(clientURL.Hostname(), clientURL.Port())]
+		if !ok {
+			return nil
+		}
+
+		logger.Infof("Requesting conversion of standalone OVN databases on '%s'", memberName)
+		err := microovnClient.ConvertStandaloneDatabases(ctx, c)
+		if err != nil {
+			return fmt.Errorf("member '%s': %w", memberName, err)
+		}
+		return nil
+	})
+}
+
 // joinCentral safely starts the central services child services while also
 // generating certificates to ensure secure connection with other central
 // nodes in the database
@@ -274,17 +334,25 @@ func joinCentral(ctx context.Context, s state.State) error {
 // leaveCentral safely stops the central service's child services, and leaves
 // the central database cluster safely.
 func leaveCentral(ctx context.Context, s state.State, lastMember bool) {
-	// Leave SB and NB clusters
-	logger.Info("Leaving OVN Northbound cluster")
-	_, err := ovnCmd.AppCtl(ctx, s, paths.OvnNBControlSock(), "cluster/leave", "OVN_Northbound")
+	// Standalone databases are not part of any cluster
+	standalone, err := environment.IsStandaloneDatabase(ctx, s)
 	if err != nil {
-		logger.Warnf("Failed to leave OVN Northbound cluster: %s", err)
+		logger.Warnf("Failed to get OVN database mode: %s", err)
 	}
 
-	logger.Info("Leaving OVN Southbound cluster")
-	_, err = ovnCmd.AppCtl(ctx, s, paths.OvnSBControlSock(), "cluster/leave", "OVN_Southbound")
-	if err != nil {
-		logger.Warnf("Failed to leave OVN Southbound cluster: %s", err)
+	if !standalone {
+		// Leave SB and NB clusters
+		logger.Info("Leaving OVN Northbound cluster")
+		_, err = ovnCmd.AppCtl(ctx, s, paths.OvnNBControlSock(), "cluster/leave", "OVN_Northbound")
+		if err != nil {
+			logger.Warnf("Failed to leave OVN Northbound cluster: %s", err)
+		}
+
+		logger.Info("Leaving OVN Southbound cluster")
+		_, err = ovnCmd.AppCtl(ctx, s, paths.OvnSBControlSock(), "cluster/leave", "OVN_Southbound")
+		if err != nil {
+			logger.Warnf("Failed to leave OVN Southbound cluster: %s", err)
+		}
 	}
 
 	if !lastMember {
@@ -356,6 +424,18 @@ func DisableAllServices(ctx context.Context, s state.State) error {
 	return nil
 }
 
+// SnapServices returns names of the snap services that implement the MicroOVN "service".
+func SnapServices(service types.SrvName) []string {
+	switch service {
+	case types.SrvCentral:
+		return []string{"ovn-ovsdb-server-nb", "ovn-ovsdb-server-sb", "ovn-northd"}
+	case types.SrvBgp:
+		return []string{bgp.BirdService}
+	default:
+		return []string{service}
+	}
+}
+
 func activateService(service types.SrvName, enable bool) error {
 	switch service {
 	case types.SrvCentral:
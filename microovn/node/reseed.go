@@ -0,0 +1,151 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v2/client"
+	"github.com/canonical/microcluster/v2/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	microovnClient "github.com/canonical/microovn/microovn/client"
+	ovnCluster "github.com/canonical/microovn/microovn/ovn/cluster"
+	ovnCmd "github.com/canonical/microovn/microovn/ovn/cmd"
+	"github.com/canonical/microovn/microovn/ovn/environment"
+)
+
+// reseedSyncTimeout is the time that reseeded databases have to catch up with the rest of the cluster.
+const reseedSyncTimeout = 5 * time.Minute
+
+// CheckCentral runs integrity checks of the local OVN Northbound and Southbound databases. Missing RAFT
+// connection to the member on the host "ignoredPeer" is not reported as a problem, if it's not empty.
+func CheckCentral(ctx context.Context, s state.State, ignoredPeer string) (types.OvsdbCheckReport, error) {
+	report := types.OvsdbCheckReport{}
+	for _, dbType := range []ovnCmd.OvsdbType{ovnCmd.OvsdbTypeNBLocal, ovnCmd.OvsdbTypeSBLocal} {
+		result, err := ovnCluster.CheckDatabase(ctx, s, dbType, ignoredPeer)
+		if err != nil {
+			return nil, err
+		}
+		report = append(report, result)
+	}
+	return report, nil
+}
+
+// ReseedCentral replaces local OVN Northbound and Southbound databases with fresh copies received from
+// healthy central members. Local database servers leave their RAFT clusters, database files are moved to
+// backups and the servers join the clusters again as new members. The function returns once both databases
+// caught up with the rest of the cluster.
+func ReseedCentral(ctx context.Context, s state.State) error {
+	hasCentral, err := HasServiceActive(ctx, s, types.SrvCentral)
+	if err != nil {
+		return err
+	}
+	if !hasCentral {
+		return errors.New("this node does not run 'central' service")
+	}
+
+	standalone, err := environment.IsStandaloneDatabase(ctx, s)
+	if err != nil {
+		return fmt.Errorf("failed to get OVN database mode: %w", err)
+	}
+	if standalone {
+		return errors.New("standalone databases have no cluster peers to reseed from")
+	}
+
+	err = checkCentralPeers(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Reseeding OVN Northbound and Southbound databases on '%s' from cluster peers", s.Name())
+	leaveCentral(ctx, s, false)
+
+	err = activateService(types.SrvCentral, true)
+	if err != nil {
+		return err
+	}
+
+	err = ovnCluster.UpdateOvnListenConfig(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	for _, dbType := range []ovnCmd.OvsdbType{ovnCmd.OvsdbTypeNBLocal, ovnCmd.OvsdbTypeSBLocal} {
+		err = ovnCluster.WaitForRaftSync(ctx, s, dbType, reseedSyncTimeout)
+		if err != nil {
+			dbSpec, _ := ovnCmd.NewOvsdbSpec(dbType)
+			return fmt.Errorf("reseeded %s database: %w", dbSpec.FriendlyName, err)
+		}
+	}
+
+	logger.Infof("OVN Northbound and Southbound databases on '%s' caught up with the cluster", s.Name())
+	return nil
+}
+
+// checkCentralPeers ensures that, once the local database servers leave their clusters, the remaining central
+// members have healthy majority in both Northbound and Southbound clusters. Without it, the local servers
+// would not be able to join the clusters again. Connections of the peers to the local member are ignored, as
+// they are usually missing when the local databases are broken.
+func checkCentralPeers(ctx context.Context, s state.State) error {
+	centrals, err := FindService(ctx, s, types.SrvCentral)
+	if err != nil {
+		return err
+	}
+
+	centralAddrs := make(map[string]string, len(centrals))
+	for _, central := range centrals {
+		if central.Name != s.Name() {
+			centralAddrs[central.Address] = central.Name
+		}
+	}
+
+	if len(centralAddrs) == 0 {
+		return errors.New("there are no other central members to reseed the databases from")
+	}
+
+	clusterClient, err := s.Cluster(false)
+	if err != nil {
+		return fmt.Errorf("failed to get a client for every cluster member: %w", err)
+	}
+
+	var lock sync.Mutex
+	healthyPeers := map[string]int{}
+	_ = clusterClient.Query(ctx, true, func(ctx context.Context, c *client.Client) error {
+		clientURL := c.URL()
+		memberName, ok := centralAddrs[net.JoinHostPort(clientURL.Hostname(), clientURL.Port())]
+		if !ok {
+			return nil
+		}
+
+		report, err := microovnClient.GetOvsdbCheck(ctx, c, s.Address().Hostname())
+		if err != nil {
+			logger.Warnf("Failed to check OVN databases on '%s': %s", memberName, err)
+			return nil
+		}
+
+		lock.Lock()
+		defer lock.Unlock()
+		for _, result := range report {
+			if result.Healthy {
+				healthyPeers[result.Database]++
+			}
+		}
+		return nil
+	})
+
+	required := len(centralAddrs)/2 + 1
+	for _, db := range []string{"nb", "sb"} {
+		if healthyPeers[db] < required {
+			return fmt.Errorf(
+				"only %d of %d central peers have healthy '%s' database, at least %d are required",
+				healthyPeers[db], len(centralAddrs), db, required,
+			)
+		}
+	}
+	return nil
+}
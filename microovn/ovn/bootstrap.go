@@ -9,6 +9,8 @@ import (
 	"github.com/canonical/microcluster/v2/state"
 
 	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/config"
+	"github.com/canonical/microovn/microovn/facts"
 	"github.com/canonical/microovn/microovn/node"
 	"github.com/canonical/microovn/microovn/ovn/certificates"
 	ovnCluster "github.com/canonical/microovn/microovn/ovn/cluster"
@@ -39,6 +41,7 @@ func Bootstrap(ctx context.Context, s state.State, initConfig map[string]string)
 	ovnEncapIP := s.Address().Hostname()
 	var certPem []byte
 	var keyPem []byte
+	databaseMode := ""
 	for k, v := range initConfig {
 		// Configure OVS to either use a custom encapsulation IP for the geneve tunel
 		// or the hostname of the node.
@@ -63,6 +66,16 @@ func Bootstrap(ctx context.Context, s state.State, initConfig map[string]string)
 			continue
 		}
 
+		// Select mode in which OVN central databases are created
+		if k == environment.DatabaseModeInitKey {
+			err = environment.ValidateDatabaseMode(v)
+			if err != nil {
+				return fmt.Errorf("failed to parse database mode: %w", err)
+			}
+			databaseMode = v
+			continue
+		}
+
 		// Get requested services
 		if k == "ovn-services" {
 			if v != "auto" {
@@ -74,6 +87,13 @@ func Bootstrap(ctx context.Context, s state.State, initConfig map[string]string)
 		}
 	}
 
+	if databaseMode != "" {
+		err = config.SetConfig(ctx, s, environment.DatabaseModeConfigKey, databaseMode)
+		if err != nil {
+			return fmt.Errorf("failed to store database mode: %w", err)
+		}
+	}
+
 	// Generate CA certificate and key
 	if len(certPem) != 0 && len(keyPem) != 0 {
 		_, err = certificates.SetNewCACertificate(ctx, s, string(certPem), string(keyPem))
@@ -160,5 +180,12 @@ func Bootstrap(ctx context.Context, s state.State, initConfig map[string]string)
 		}
 	}
 
+	// Publish facts right away, so that versions of this member are known to members that join the
+	// cluster before the facts are published periodically.
+	err = facts.CollectAndPublish(ctx, s)
+	if err != nil {
+		logger.Warnf("Failed to publish member facts: %v", err)
+	}
+
 	return nil
 }
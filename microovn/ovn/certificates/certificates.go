@@ -15,6 +15,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"net"
 	"os"
 	"time"
 
@@ -22,6 +23,7 @@ import (
 	"github.com/canonical/microcluster/v2/state"
 	"github.com/canonical/microovn/microovn/database"
 	"github.com/canonical/microovn/microovn/ovn/paths"
+	"github.com/canonical/microovn/microovn/ovn/vip"
 )
 
 // CACertRecordName    - Key used to store CA certificate in config DB table.
@@ -73,8 +75,10 @@ const (
 // "signer" argument must point to CA's private key. On the other hand if you want to generate self-signed certificate,
 // both "parent" and "signer" arguments must be empty (nil).
 //
+// Addresses in "ipAddresses" argument are included in the certificate as IP subject alternative names.
+//
 // This function returns PEM encoded certificate, private key and error (if any occurred).
-func issueCertificate(cn string, serviceName string, certType CertificateType, parent *x509.Certificate, signer any) ([]byte, []byte, error) {
+func issueCertificate(cn string, serviceName string, certType CertificateType, parent *x509.Certificate, signer any, ipAddresses []net.IP) ([]byte, []byte, error) {
 	var (
 		isCa     bool
 		keyUsage x509.KeyUsage
@@ -126,6 +130,8 @@ func issueCertificate(cn string, serviceName string, certType CertificateType, p
 		KeyUsage:              keyUsage,
 		BasicConstraintsValid: true,
 		IsCA:                  isCa,
+
+		IPAddresses: ipAddresses,
 	}
 
 	// If there's no parent, use certificate's own key to self-sign it.
@@ -155,7 +161,7 @@ func issueCertificate(cn string, serviceName string, certType CertificateType, p
 // GenerateNewCACertificate generates new CA certificate and private key and stores them in the shared MicroOVN
 // database.
 func GenerateNewCACertificate(ctx context.Context, s state.State) (bool, error) {
-	cert, key, err := issueCertificate("MicroOVN CA", "MicroOVN CA", CertificateTypeCA, nil, nil)
+	cert, key, err := issueCertificate("MicroOVN CA", "MicroOVN CA", CertificateTypeCA, nil, nil, nil)
 	if err != nil {
 		return false, err
 	}
@@ -268,7 +274,8 @@ func storeCA(ctx context.Context, s state.State, certPEM string, keyPEM string,
 }
 
 // DumpCA copies CA certificate from shared database and stores it in pre-defined file on disk. File path
-// to store CA certificate is defined in paths.PkiCaCertFile.
+// to store CA certificate is defined in paths.PkiCaCertFile. It also refreshes the bundle of CA certificates
+// trusted to issue certificates of the OVN database clients (see dumpClientCABundle).
 func DumpCA(ctx context.Context, s state.State) error {
 	var err error
 	var CACertRecord *database.ConfigItem
@@ -300,7 +307,8 @@ func DumpCA(ctx context.Context, s state.State) error {
 	if err != nil {
 		return fmt.Errorf("failed to write CA certificate into file %s: %w", certPath, err)
 	}
-	return nil
+
+	return dumpClientCABundle(ctx, s, CACertRecord.Value)
 }
 
 // IsCaRenewable returns true if CA certificate is managed by the MicroOVN
@@ -383,7 +391,8 @@ func GetCA(ctx context.Context, s state.State) (*x509.Certificate, any, error) {
 // GenerateNewServiceCertificate creates new certificate, signs it with CA certificate stored in the shared database
 // and writes resulting certificate and private key to files specified by certPath and keyPath arguments.
 // String from serviceName argument will be inserted in certificate's OU and is meant to more easily distinguish
-// between multiple certificates with same CN.
+// between multiple certificates with same CN. Certificates of the OVN database services also include virtual IP
+// address of the database, if one is configured.
 func GenerateNewServiceCertificate(ctx context.Context, s state.State, serviceName string, certType CertificateType) error {
 	certPath, keyPath, err := getServiceCertificatePaths(serviceName)
 	if err != nil {
@@ -417,7 +426,17 @@ func GenerateNewServiceCertificate(ctx context.Context, s state.State, serviceNa
 		return err
 	}
 
-	cert, key, err := issueCertificate(s.Name(), serviceName, certType, caCert, caKey)
+	virtualIPs, err := vip.CertificateAddresses(ctx, s, serviceName)
+	if err != nil {
+		return fmt.Errorf("failed to get virtual IP addresses for %s certificate: %w", serviceName, err)
+	}
+
+	ipAddresses := make([]net.IP, 0, len(virtualIPs))
+	for _, addr := range virtualIPs {
+		ipAddresses = append(ipAddresses, net.IP(addr.AsSlice()))
+	}
+
+	cert, key, err := issueCertificate(s.Name(), serviceName, certType, caCert, caKey, ipAddresses)
 	if err != nil {
 		return fmt.Errorf("failed to issue certificate for %s: %w", serviceName, err)
 	}
@@ -435,6 +454,32 @@ func GenerateNewServiceCertificate(ctx context.Context, s state.State, serviceNa
 	return nil
 }
 
+// ServiceCertificateExpiry returns expiration time of the certificate currently used by the "serviceName".
+// It returns os.ErrNotExist error if the service does not have a certificate on this member.
+func ServiceCertificateExpiry(serviceName string) (time.Time, error) {
+	certPath, _, err := getServiceCertificatePaths(serviceName)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	rawCert, err := os.ReadFile(certPath)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	certBlock, _ := pem.Decode(rawCert)
+	if certBlock == nil {
+		return time.Time{}, fmt.Errorf("failed to decode %s certificate from file %s", serviceName, certPath)
+	}
+
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse %s certificate: %w", serviceName, err)
+	}
+
+	return cert.NotAfter, nil
+}
+
 // getServiceCertificatePaths returns paths to certificate and private key based on service name
 func getServiceCertificatePaths(service string) (string, string, error) {
 	var (
@@ -454,6 +499,8 @@ func getServiceCertificatePaths(service string) (string, string, error) {
 		certPath, keyPath = paths.PkiOvnNorthdCertFiles()
 	case "ovn-controller":
 		certPath, keyPath = paths.PkiOvnControllerCertFiles()
+	case "ovs-manager":
+		certPath, keyPath = paths.PkiOvsManagerCertFiles()
 	default:
 		certPath = ""
 		keyPath = ""
@@ -0,0 +1,228 @@
+package certificates
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"database/sql"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/canonical/lxd/shared/api"
+	"github.com/canonical/microcluster/v2/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/database"
+	"github.com/canonical/microovn/microovn/ovn/paths"
+)
+
+// TrustedCAsRecordName - Key used to store additional trusted client CA certificates in config DB table.
+const TrustedCAsRecordName = "trusted_client_cas"
+
+// Fingerprint returns SHA-256 fingerprint of the certificate, encoded as a lowercase hex string.
+func Fingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// NormalizeFingerprint converts fingerprint in any of the commonly used formats (e.g.
+// "AB:CD:..." printed by openssl) into the format returned by Fingerprint.
+func NormalizeFingerprint(fingerprint string) string {
+	return strings.ToLower(strings.ReplaceAll(fingerprint, ":", ""))
+}
+
+// ParseCACertificates parses one or more PEM encoded CA certificates. An error is returned if the data
+// does not contain any certificate, or if any of the certificates is not a CA certificate.
+func ParseCACertificates(pemData string) ([]*x509.Certificate, error) {
+	var caCerts []*x509.Certificate
+
+	data := []byte(pemData)
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing X509 certificate: %w", err)
+		}
+
+		if !cert.IsCA {
+			return nil, fmt.Errorf("certificate '%s' is not a CA certificate", cert.Subject)
+		}
+
+		caCerts = append(caCerts, cert)
+	}
+
+	if len(caCerts) == 0 {
+		return nil, errors.New("no certificates found")
+	}
+	return caCerts, nil
+}
+
+// getTrustedCAs returns additional trusted client CA certificates stored in the shared database.
+func getTrustedCAs(ctx context.Context, tx *sql.Tx) ([]*x509.Certificate, error) {
+	record, err := database.GetConfigItem(ctx, tx, TrustedCAsRecordName)
+	if err != nil {
+		if api.StatusErrorCheck(err, http.StatusNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get trusted client CA certificates from the database: %w", err)
+	}
+
+	if record.Value == "" {
+		return nil, nil
+	}
+	return ParseCACertificates(record.Value)
+}
+
+// storeTrustedCAs replaces additional trusted client CA certificates stored in the shared database.
+func storeTrustedCAs(ctx context.Context, tx *sql.Tx, caCerts []*x509.Certificate) error {
+	var bundle strings.Builder
+	for _, cert := range caCerts {
+		err := pem.Encode(&bundle, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+		if err != nil {
+			return fmt.Errorf("failed to encode trusted client CA certificate: %w", err)
+		}
+	}
+
+	record := database.ConfigItem{Key: TrustedCAsRecordName, Value: bundle.String()}
+	exists, err := database.ConfigItemExists(ctx, tx, TrustedCAsRecordName)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		err = database.UpdateConfigItem(ctx, tx, TrustedCAsRecordName, record)
+	} else {
+		_, err = database.CreateConfigItem(ctx, tx, record)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to store trusted client CA certificates in the database: %w", err)
+	}
+	return nil
+}
+
+// ListTrustedCAs returns information about additional CA certificates that are trusted to issue client
+// certificates for the OVN Northbound and Southbound databases.
+func ListTrustedCAs(ctx context.Context, s state.State) ([]types.TrustedCa, error) {
+	var caCerts []*x509.Certificate
+	err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		caCerts, err = getTrustedCAs(ctx, tx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	trustedCAs := make([]types.TrustedCa, 0, len(caCerts))
+	for _, cert := range caCerts {
+		trustedCAs = append(trustedCAs, types.TrustedCa{
+			Fingerprint: Fingerprint(cert),
+			Subject:     cert.Subject.String(),
+			NotAfter:    cert.NotAfter,
+		})
+	}
+	return trustedCAs, nil
+}
+
+// AddTrustedCAs adds PEM encoded CA certificates to the trust bundle in the shared database and returns
+// fingerprints of the certificates that were not trusted before.
+func AddTrustedCAs(ctx context.Context, s state.State, pemData string) ([]string, error) {
+	newCerts, err := ParseCACertificates(pemData)
+	if err != nil {
+		return nil, err
+	}
+
+	added := []string{}
+	err = s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		caCerts, err := getTrustedCAs(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		for _, cert := range newCerts {
+			fingerprint := Fingerprint(cert)
+			if slices.ContainsFunc(caCerts, func(c *x509.Certificate) bool { return Fingerprint(c) == fingerprint }) {
+				continue
+			}
+			caCerts = append(caCerts, cert)
+			added = append(added, fingerprint)
+		}
+
+		if len(added) == 0 {
+			return nil
+		}
+		return storeTrustedCAs(ctx, tx, caCerts)
+	})
+
+	return added, err
+}
+
+// RemoveTrustedCA removes CA certificate with the "fingerprint" from the trust bundle in the shared database.
+// It returns false if no such certificate was trusted.
+func RemoveTrustedCA(ctx context.Context, s state.State, fingerprint string) (bool, error) {
+	fingerprint = NormalizeFingerprint(fingerprint)
+
+	removed := false
+	err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		caCerts, err := getTrustedCAs(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		remaining := slices.DeleteFunc(caCerts, func(c *x509.Certificate) bool { return Fingerprint(c) == fingerprint })
+		removed = len(remaining) != len(caCerts)
+		if !removed {
+			return nil
+		}
+		return storeTrustedCAs(ctx, tx, remaining)
+	})
+
+	return removed, err
+}
+
+// dumpClientCABundle writes MicroOVN CA certificate "caCertPEM" together with additional trusted client CA
+// certificates into the file defined in paths.PkiClientCaBundleFile. OVN database servers use this bundle to
+// verify certificates of their clients, while MicroOVN keeps issuing its own certificates with its CA.
+func dumpClientCABundle(ctx context.Context, s state.State, caCertPEM string) error {
+	var caCerts []*x509.Certificate
+	err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		caCerts, err = getTrustedCAs(ctx, tx)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	var bundle strings.Builder
+	bundle.WriteString(caCertPEM)
+	for _, cert := range caCerts {
+		err = pem.Encode(&bundle, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+		if err != nil {
+			return fmt.Errorf("failed to encode trusted client CA certificate: %w", err)
+		}
+	}
+
+	bundlePath := paths.PkiClientCaBundleFile()
+	err = os.WriteFile(bundlePath, []byte(bundle.String()), certFileMode)
+	if err != nil {
+		return fmt.Errorf("failed to write client CA bundle into file %s: %w", bundlePath, err)
+	}
+	return nil
+}
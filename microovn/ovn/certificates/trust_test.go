@@ -0,0 +1,42 @@
+package certificates
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseCACertificates(t *testing.T) {
+	caCert, caKey, err := issueCertificate("Test CA", "Test CA", CertificateTypeCA, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to issue CA certificate: %v", err)
+	}
+
+	otherCaCert, _, err := issueCertificate("Other CA", "Other CA", CertificateTypeCA, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to issue CA certificate: %v", err)
+	}
+
+	caCerts, err := ParseCACertificates(string(caCert) + string(caKey) + string(otherCaCert))
+	if err != nil {
+		t.Fatalf("Failed to parse CA bundle: %v", err)
+	}
+
+	if len(caCerts) != 2 || caCerts[0].Subject.CommonName != "Test CA" || caCerts[1].Subject.CommonName != "Other CA" {
+		t.Fatalf("Expected 'Test CA' and 'Other CA' certificates, got %v", caCerts)
+	}
+
+	fingerprint := Fingerprint(caCerts[0])
+	if len(fingerprint) != 64 {
+		t.Errorf("Expected SHA-256 fingerprint, got '%s'", fingerprint)
+	}
+
+	opensslFormat := strings.ToUpper(fingerprint[:2] + ":" + fingerprint[2:])
+	if NormalizeFingerprint(opensslFormat) != fingerprint {
+		t.Errorf("Expected '%s' to be normalized to '%s'", opensslFormat, fingerprint)
+	}
+
+	_, err = ParseCACertificates(string(caKey))
+	if err == nil {
+		t.Errorf("Expected error when parsing data without certificates")
+	}
+}
@@ -6,13 +6,18 @@ package cluster
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/canonical/microcluster/v2/state"
 	ovnCmd "github.com/canonical/microovn/microovn/ovn/cmd"
 	"github.com/canonical/microovn/microovn/ovn/environment"
+	"github.com/canonical/microovn/microovn/ovn/paths"
 )
 
-// UpdateOvnListenConfig configures the OVN NB and SB databases to listen on the appropriate ports.
+// UpdateOvnListenConfig configures the OVN NB and SB databases to listen on the appropriate ports. Besides
+// the read-write listeners, it also sets up read-only listeners configured by the "ovn.nb.read-only-listeners"
+// and "ovn.sb.read-only-listeners" config options.
 func UpdateOvnListenConfig(ctx context.Context, s state.State) error {
 	nbDB, err := ovnCmd.NewOvsdbSpec(ovnCmd.OvsdbTypeNBLocal)
 	if err != nil {
@@ -48,18 +53,32 @@ func UpdateOvnListenConfig(ctx context.Context, s state.State) error {
 		return fmt.Errorf("error setting ovn SB connection string: %s", err)
 	}
 
-	return nil
+	// "set-connection" replaces every Connection record, so the read-only ones have to be created again.
+	return ApplyReadOnlyListeners(ctx, s)
 }
 
 // UpdateOvnControllerRemoteConfig updates the value of "external_ids:remote-ovn" in the
 // Open vSwitch database. This value tells the OVN controller the location of OVN Southbound
-// database endpoints to which it should connect.
+// database endpoints to which it should connect. Order of the endpoints is determined by the
+// "ovn.remote-policy" config option.
 func UpdateOvnControllerRemoteConfig(ctx context.Context, s state.State) error {
 	// Reconfigure OVS to use OVN.
 	centralIps, err := environment.CentralIps(ctx, s)
 	if err != nil {
 		return fmt.Errorf("failed to get OVN central IPs: %w", err)
 	}
+
+	remoteOrder, err := newRemoteAddressOrder(ctx, s)
+	if err != nil {
+		return fmt.Errorf("failed to get OVN remote policy: %w", err)
+	}
+	centralIps = remoteOrder.Apply(centralIps)
+
+	centralIps, err = environment.ConnectAddresses(ctx, s, centralIps)
+	if err != nil {
+		return fmt.Errorf("failed to get OVN central connection addresses: %w", err)
+	}
+
 	sbConnect, err := environment.ConnectionString(ctx, s, centralIps, 6642)
 	if err != nil {
 		return fmt.Errorf("failed to get OVN SB connect string: %w", err)
@@ -91,3 +110,69 @@ func UpdateOvnControllerRemoteConfig(ctx context.Context, s state.State) error {
 
 	return nil
 }
+
+// SouthboundSessions returns number of client sessions currently established with the local
+// OVN Southbound database server. The value is parsed from the output of the "memory/show"
+// command of the database server. Sessions of all clients are counted, not only those of the
+// chassis, but also of ovn-northd and any other OVSDB clients.
+func SouthboundSessions(ctx context.Context, s state.State) (int, error) {
+	output, err := ovnCmd.AppCtl(ctx, s, paths.OvnSBControlSock(), "memory/show")
+	if err != nil {
+		return 0, fmt.Errorf("failed to query OVN Southbound database server: %w", err)
+	}
+
+	return parseMemorySessions(output)
+}
+
+// parseMemorySessions extracts the value of the "sessions" counter from the output of ovsdb-server's
+// "memory/show" command (e.g. "atoms:123 cells:456 monitors:3 sessions:5"). An error is returned
+// if the counter is missing, as the number of sessions is unknown in that case.
+func parseMemorySessions(output string) (int, error) {
+	for _, field := range strings.Fields(output) {
+		value, found := strings.CutPrefix(field, "sessions:")
+		if found {
+			return strconv.Atoi(value)
+		}
+	}
+	return 0, fmt.Errorf("number of sessions is missing in the output of 'memory/show': %s", strings.TrimSpace(output))
+}
+
+// raftControlSockets maps types of the local OVN databases to functions that return paths to the control
+// sockets of their servers.
+var raftControlSockets = map[ovnCmd.OvsdbType]func() string{
+	ovnCmd.OvsdbTypeNBLocal: paths.OvnNBControlSock,
+	ovnCmd.OvsdbTypeSBLocal: paths.OvnSBControlSock,
+}
+
+// RaftRole returns RAFT role (e.g. "leader" or "follower") of the local server of the OVN database "dbType"
+// (ovnCmd.OvsdbTypeNBLocal or ovnCmd.OvsdbTypeSBLocal).
+func RaftRole(ctx context.Context, s state.State, dbType ovnCmd.OvsdbType) (string, error) {
+	dbSpec, err := ovnCmd.NewOvsdbSpec(dbType)
+	if err != nil {
+		return "", err
+	}
+
+	ctlSock, ok := raftControlSockets[dbType]
+	if !ok {
+		return "", fmt.Errorf("database %s is not a local OVN database", dbSpec.FriendlyName)
+	}
+
+	out, err := ovnCmd.AppCtl(ctx, s, ctlSock(), "cluster/status", dbSpec.Name)
+	if err != nil {
+		return "", fmt.Errorf("failed to get OVN %s cluster status: %w", dbSpec.FriendlyName, err)
+	}
+
+	return parseRaftRole(out), nil
+}
+
+// parseRaftRole returns role of the server from the output of "cluster/status" ovn-appctl command.
+func parseRaftRole(output string) string {
+	for _, line := range strings.Split(output, "\n") {
+		role, found := strings.CutPrefix(strings.TrimSpace(line), "Role:")
+		if found {
+			return strings.TrimSpace(role)
+		}
+	}
+
+	return ""
+}
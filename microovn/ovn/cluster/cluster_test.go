@@ -0,0 +1,57 @@
+package cluster
+
+import (
+	"testing"
+)
+
+func TestUnexported_parseMemorySessions(t *testing.T) {
+	testCases := []struct {
+		output   string
+		expected int
+		isValid  bool
+	}{
+		{output: "cells:1234 monitors:3 sessions:12\n", expected: 12, isValid: true},
+		{output: "atoms:10 cells:20 monitors:0 raft-connections:4\n", isValid: false},
+		{output: "sessions:abc", isValid: false},
+	}
+
+	for _, tc := range testCases {
+		sessions, err := parseMemorySessions(tc.output)
+		if !tc.isValid {
+			if err == nil {
+				t.Errorf("Expected output '%s' to be rejected", tc.output)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Failed to parse output '%s': %s", tc.output, err)
+		}
+		if sessions != tc.expected {
+			t.Errorf("Expected %d sessions, got %d", tc.expected, sessions)
+		}
+	}
+}
+
+func TestUnexported_parseRaftRole(t *testing.T) {
+	output := `2f3e
+Name: OVN_Northbound
+Cluster ID: 7b1c (7b1c0a2e-5d43-4b9f-9d3c-8e0f1b2a3c4d)
+Server ID: 2f3e (2f3e4d5c-6b7a-4c8d-9e0f-1a2b3c4d5e6f)
+Address: ssl:10.0.0.1:6643
+Status: cluster member
+Role: leader
+Term: 3
+Leader: self
+Vote: self
+`
+	role := parseRaftRole(output)
+	if role != "leader" {
+		t.Errorf("Expected RAFT role 'leader', got '%s'", role)
+	}
+
+	role = parseRaftRole("")
+	if role != "" {
+		t.Errorf("Expected empty RAFT role for empty output, got '%s'", role)
+	}
+}
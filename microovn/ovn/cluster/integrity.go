@@ -0,0 +1,232 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/canonical/lxd/shared"
+	"github.com/canonical/microcluster/v2/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	ovnCmd "github.com/canonical/microovn/microovn/ovn/cmd"
+	"github.com/canonical/microovn/microovn/ovn/environment"
+	"github.com/canonical/microovn/microovn/ovn/paths"
+)
+
+// raftMemberStatus is the "Status" reported by the servers that are fully joined members of the RAFT cluster.
+const raftMemberStatus = "cluster member"
+
+// databaseFiles maps types of the local OVN databases to functions that return paths to their files.
+var databaseFiles = map[ovnCmd.OvsdbType]func() string{
+	ovnCmd.OvsdbTypeNBLocal: paths.CentralDBNBPath,
+	ovnCmd.OvsdbTypeSBLocal: paths.CentralDBSBPath,
+}
+
+// raftStatus holds selected fields from the output of the "cluster/status" ovn-appctl command.
+type raftStatus struct {
+	Status       string            // Membership status of the server (e.g. "cluster member" or "joining cluster")
+	Role         string            // RAFT role of the server (e.g. "leader" or "follower")
+	Leader       string            // ID of the cluster leader, or "unknown"
+	Unapplied    int               // Number of log entries that are not yet applied to the database
+	Disconnected []string          // IDs of cluster peers that the server is not connected to
+	Servers      map[string]string // RAFT addresses of cluster servers, keyed by server ID
+}
+
+// CheckDatabase runs integrity checks of the local OVN database "dbType" (ovnCmd.OvsdbTypeNBLocal or
+// ovnCmd.OvsdbTypeSBLocal). It verifies consistency of the database file with "ovsdb-tool" and, for
+// clustered databases, that the server is a connected member of the RAFT cluster. Missing connection to the
+// cluster peer on the host "ignoredPeer" is not reported as a problem, if the "ignoredPeer" is not empty.
+func CheckDatabase(ctx context.Context, s state.State, dbType ovnCmd.OvsdbType, ignoredPeer string) (types.OvsdbCheckResult, error) {
+	dbSpec, err := ovnCmd.NewOvsdbSpec(dbType)
+	if err != nil {
+		return types.OvsdbCheckResult{}, err
+	}
+
+	dbFile, ok := databaseFiles[dbType]
+	if !ok {
+		return types.OvsdbCheckResult{}, fmt.Errorf("database %s is not a local OVN database", dbSpec.FriendlyName)
+	}
+
+	result := types.OvsdbCheckResult{
+		Member:   s.Name(),
+		Database: dbSpec.ShortName,
+		Mode:     environment.DatabaseModeClustered,
+		Problems: []string{},
+	}
+
+	standalone, err := environment.IsStandaloneDatabase(ctx, s)
+	if err != nil {
+		return result, err
+	}
+	if standalone {
+		result.Mode = environment.DatabaseModeStandalone
+	}
+
+	_, err = os.Stat(dbFile())
+	if errors.Is(err, os.ErrNotExist) {
+		result.Problems = append(result.Problems, "database file does not exist")
+		return result, nil
+	}
+
+	// "check-cluster" reads every record of the clustered database file and checks RAFT log consistency.
+	// Standalone database has no RAFT log, so all of its records are just parsed by "show-log".
+	checkCmd := "check-cluster"
+	if standalone {
+		checkCmd = "show-log"
+	}
+	_, err = shared.RunCommandContext(ctx, "ovsdb-tool", checkCmd, dbFile())
+	if err != nil {
+		result.Problems = append(result.Problems, fmt.Sprintf("database file check failed: %s", err))
+	}
+
+	if standalone {
+		result.Status = "standalone"
+	} else {
+		status, err := clusterStatus(ctx, s, dbType)
+		if err != nil {
+			result.Problems = append(result.Problems, fmt.Sprintf("failed to get RAFT status: %s", err))
+		} else {
+			result.Status = status.Status
+			result.Role = status.Role
+			result.Problems = append(result.Problems, status.problems(ignoredPeer)...)
+		}
+	}
+
+	result.Healthy = len(result.Problems) == 0
+	return result, nil
+}
+
+// WaitForRaftSync waits until the local server of the OVN database "dbType" becomes a connected member of
+// the RAFT cluster and applies every log entry it received from the leader. It returns an error if the
+// server does not catch up within the "timeout".
+func WaitForRaftSync(ctx context.Context, s state.State, dbType ovnCmd.OvsdbType, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	lastProblem := ""
+	for {
+		status, err := clusterStatus(ctx, s, dbType)
+		if err != nil {
+			lastProblem = err.Error()
+		} else if problems := status.problems(""); len(problems) > 0 {
+			lastProblem = strings.Join(problems, ", ")
+		} else {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("database did not catch up with the cluster: %s", lastProblem)
+		case <-ticker.C:
+		}
+	}
+}
+
+// clusterStatus returns RAFT status of the local server of the OVN database "dbType".
+func clusterStatus(ctx context.Context, s state.State, dbType ovnCmd.OvsdbType) (raftStatus, error) {
+	dbSpec, err := ovnCmd.NewOvsdbSpec(dbType)
+	if err != nil {
+		return raftStatus{}, err
+	}
+
+	ctlSock, ok := raftControlSockets[dbType]
+	if !ok {
+		return raftStatus{}, fmt.Errorf("database %s is not a local OVN database", dbSpec.FriendlyName)
+	}
+
+	out, err := ovnCmd.AppCtl(ctx, s, ctlSock(), "cluster/status", dbSpec.Name)
+	if err != nil {
+		return raftStatus{}, fmt.Errorf("failed to get OVN %s cluster status: %w", dbSpec.FriendlyName, err)
+	}
+
+	return parseRaftStatus(out), nil
+}
+
+// parseRaftStatus parses output of the "cluster/status" ovn-appctl command.
+func parseRaftStatus(output string) raftStatus {
+	status := raftStatus{Servers: map[string]string{}}
+	for _, line := range strings.Split(output, "\n") {
+		// Each server in the "Servers" section is listed as "<id> (<id> at <address>) ...".
+		fields := strings.Fields(line)
+		if len(fields) >= 4 && fields[2] == "at" && strings.HasPrefix(fields[1], "(") {
+			status.Servers[fields[0]] = strings.TrimSuffix(fields[3], ")")
+			continue
+		}
+
+		key, value, found := strings.Cut(strings.TrimSpace(line), ":")
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "Status":
+			status.Status = value
+		case "Role":
+			status.Role = value
+		case "Leader":
+			status.Leader = value
+		case "Entries not yet applied":
+			status.Unapplied, _ = strconv.Atoi(value)
+		case "Connections":
+			// Connections that are not established are enclosed in parentheses (e.g. "(->1a2b)")
+			for _, connection := range strings.Fields(value) {
+				if strings.HasPrefix(connection, "(->") {
+					status.Disconnected = append(status.Disconnected, strings.TrimSuffix(connection[3:], ")"))
+				}
+			}
+		}
+	}
+
+	return status
+}
+
+// problems returns list of reasons why the server is not a healthy, up-to-date member of the RAFT cluster.
+// Missing connection to the peer on the host "ignoredPeer" is not considered a problem.
+func (r raftStatus) problems(ignoredPeer string) []string {
+	problems := []string{}
+	if r.Status != raftMemberStatus {
+		problems = append(problems, fmt.Sprintf("server status is '%s'", r.Status))
+	}
+	if r.Leader == "" || r.Leader == "unknown" {
+		problems = append(problems, "cluster leader is unknown")
+	}
+	if r.Unapplied > 0 {
+		problems = append(problems, fmt.Sprintf("%d log entries not yet applied", r.Unapplied))
+	}
+
+	disconnected := 0
+	for _, peer := range r.Disconnected {
+		if ignoredPeer == "" || raftAddressHost(r.Servers[peer]) != ignoredPeer {
+			disconnected++
+		}
+	}
+	if disconnected > 0 {
+		problems = append(problems, fmt.Sprintf("not connected to %d cluster peers", disconnected))
+	}
+	return problems
+}
+
+// raftAddressHost returns host part of the RAFT server address (e.g. "ssl:10.0.0.1:6643"), or an empty
+// string if the address can't be parsed.
+func raftAddressHost(address string) string {
+	_, hostPort, found := strings.Cut(address, ":")
+	if !found {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		return ""
+	}
+	return host
+}
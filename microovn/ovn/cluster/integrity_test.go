@@ -0,0 +1,116 @@
+package cluster
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestUnexported_parseRaftStatus(t *testing.T) {
+	output := `1a2b
+Name: OVN_Northbound
+Cluster ID: 3c4d (3c4d5e6f-0000-0000-0000-000000000000)
+Server ID: 1a2b (1a2b3c4d-0000-0000-0000-000000000000)
+Address: ssl:10.0.0.1:6643
+Status: cluster member
+Role: follower
+Term: 4
+Leader: 5e6f
+Vote: unknown
+
+Election timer: 16000
+Log: [2, 120]
+Entries not yet committed: 0
+Entries not yet applied: 3
+Connections: ->5e6f ->7a8b <-5e6f (->9c0d)
+Disconnections: 1
+Servers:
+    1a2b (1a2b at ssl:10.0.0.1:6643) (self)
+    5e6f (5e6f at ssl:10.0.0.2:6643)
+    7a8b (7a8b at ssl:10.0.0.3:6643)
+`
+	status := parseRaftStatus(output)
+	expected := raftStatus{
+		Status:       "cluster member",
+		Role:         "follower",
+		Leader:       "5e6f",
+		Unapplied:    3,
+		Disconnected: []string{"9c0d"},
+		Servers: map[string]string{
+			"1a2b": "ssl:10.0.0.1:6643",
+			"5e6f": "ssl:10.0.0.2:6643",
+			"7a8b": "ssl:10.0.0.3:6643",
+		},
+	}
+	if !reflect.DeepEqual(status, expected) {
+		t.Fatalf("Expected %+v, got %+v", expected, status)
+	}
+
+	expectedProblems := []string{"3 log entries not yet applied", "not connected to 1 cluster peers"}
+	if problems := status.problems(""); !slices.Equal(problems, expectedProblems) {
+		t.Errorf("Expected problems %v, got %v", expectedProblems, problems)
+	}
+
+	healthy := raftStatus{Status: "cluster member", Role: "leader", Leader: "self"}
+	if problems := healthy.problems(""); len(problems) != 0 {
+		t.Errorf("Expected no problems, got %v", problems)
+	}
+
+	joining := parseRaftStatus("Status: joining cluster\nRole: candidate\nLeader: unknown\n")
+	expectedProblems = []string{"server status is 'joining cluster'", "cluster leader is unknown"}
+	if problems := joining.problems(""); !slices.Equal(problems, expectedProblems) {
+		t.Errorf("Expected problems %v, got %v", expectedProblems, problems)
+	}
+}
+
+func TestUnexported_raftStatusProblemsIgnoredPeer(t *testing.T) {
+	// Peer of a member "10.0.0.1" that is down. It's healthy, except for the connection to that member.
+	output := `5e6f
+Name: OVN_Northbound
+Status: cluster member
+Role: leader
+Leader: self
+Entries not yet applied: 0
+Connections: (->1a2b) ->7a8b <-7a8b
+Servers:
+    1a2b (1a2b at ssl:10.0.0.1:6643)
+    5e6f (5e6f at ssl:10.0.0.2:6643) (self) next_index=121 match_index=120
+    7a8b (7a8b at ssl:10.0.0.3:6643) next_index=121 match_index=120
+`
+	status := parseRaftStatus(output)
+
+	testCases := []struct {
+		ignoredPeer string
+		expected    []string
+	}{
+		{ignoredPeer: "", expected: []string{"not connected to 1 cluster peers"}},
+		{ignoredPeer: "10.0.0.1", expected: []string{}},
+		{ignoredPeer: "10.0.0.3", expected: []string{"not connected to 1 cluster peers"}},
+	}
+
+	for _, tc := range testCases {
+		problems := status.problems(tc.ignoredPeer)
+		if !slices.Equal(problems, tc.expected) {
+			t.Errorf("Expected problems %v when ignoring peer '%s', got %v", tc.expected, tc.ignoredPeer, problems)
+		}
+	}
+}
+
+func TestUnexported_raftAddressHost(t *testing.T) {
+	testCases := []struct {
+		address  string
+		expected string
+	}{
+		{address: "ssl:10.0.0.1:6643", expected: "10.0.0.1"},
+		{address: "tcp:[fd00::1]:6644", expected: "fd00::1"},
+		{address: "", expected: ""},
+		{address: "ssl:10.0.0.1", expected: ""},
+	}
+
+	for _, tc := range testCases {
+		host := raftAddressHost(tc.address)
+		if host != tc.expected {
+			t.Errorf("Expected host '%s' of address '%s', got '%s'", tc.expected, tc.address, host)
+		}
+	}
+}
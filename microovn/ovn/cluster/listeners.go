@@ -0,0 +1,317 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/canonical/microcluster/v2/state"
+
+	"github.com/canonical/microovn/microovn/config"
+	ovnCmd "github.com/canonical/microovn/microovn/ovn/cmd"
+	"github.com/canonical/microovn/microovn/ovn/environment"
+)
+
+// NBReadOnlyListenersConfigKey - cluster config option that holds additional read-only listeners of the OVN
+// Northbound database.
+const NBReadOnlyListenersConfigKey = "ovn.nb.read-only-listeners"
+
+// SBReadOnlyListenersConfigKey - cluster config option that holds additional read-only listeners of the OVN
+// Southbound database.
+const SBReadOnlyListenersConfigKey = "ovn.sb.read-only-listeners"
+
+// reservedPorts are TCP ports used by the read-write listeners and RAFT connections of the OVN databases.
+var reservedPorts = []int{6641, 6642, 6643, 6644}
+
+// ReadOnlyListener represents a single listener from the value of the "ovn.nb.read-only-listeners" or
+// "ovn.sb.read-only-listeners" config option.
+type ReadOnlyListener struct {
+	Address netip.Addr // Address to listen on. Invalid (zero) address means all addresses
+	Port    int        // TCP port to listen on
+}
+
+// Target returns OVSDB connection target of the passive listener that uses "protocol" ("ssl" or "tcp").
+func (l ReadOnlyListener) Target(protocol string) string {
+	address := "[::]"
+	if l.Address.IsValid() {
+		address = l.Address.String()
+		if l.Address.Is6() {
+			address = "[" + address + "]"
+		}
+	}
+	return fmt.Sprintf("p%s:%d:%s", protocol, l.Port, address)
+}
+
+// ParseReadOnlyListeners parses value of the read-only listeners config option. The value is a comma-separated
+// list of "[<address>:]<port>" items, where IPv6 addresses have to be enclosed in square brackets (e.g.
+// "6645,192.0.2.10:6647,[2001:db8::10]:6647"). Listeners without address listen on all addresses.
+func ParseReadOnlyListeners(value string) ([]ReadOnlyListener, error) {
+	listeners := []ReadOnlyListener{}
+	if value == "" {
+		return listeners, nil
+	}
+
+	seen := make(map[ReadOnlyListener]bool)
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		listener := ReadOnlyListener{}
+
+		rawPort := item
+		if addrPort, err := netip.ParseAddrPort(item); err == nil {
+			listener.Address = addrPort.Addr()
+			rawPort = strconv.Itoa(int(addrPort.Port()))
+		} else if strings.Contains(item, ":") {
+			return nil, fmt.Errorf("listener '%s' does not conform to the '[<address>:]<port>' format", item)
+		}
+
+		if listener.Address.Zone() != "" {
+			return nil, fmt.Errorf("listener '%s' must not use IPv6 zone", item)
+		}
+
+		port, err := strconv.Atoi(rawPort)
+		if err != nil || port < 1 || port > 65535 {
+			return nil, fmt.Errorf("listener '%s' has invalid port '%s'", item, rawPort)
+		}
+
+		for _, reserved := range reservedPorts {
+			if port == reserved {
+				return nil, fmt.Errorf("listener '%s' uses port %d reserved for OVN databases", item, port)
+			}
+		}
+
+		listener.Port = port
+		if seen[listener] {
+			return nil, fmt.Errorf("listener '%s' is defined multiple times", item)
+		}
+		seen[listener] = true
+		listeners = append(listeners, listener)
+	}
+
+	return listeners, nil
+}
+
+// configuredReadOnlyListeners returns read-only listeners configured in the config option "key".
+func configuredReadOnlyListeners(ctx context.Context, s state.State, key string) ([]ReadOnlyListener, error) {
+	item, err := config.GetConfig(ctx, s, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if item == nil {
+		return []ReadOnlyListener{}, nil
+	}
+
+	listeners, err := ParseReadOnlyListeners(item.Value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value of '%s' config option: %w", key, err)
+	}
+	return listeners, nil
+}
+
+// readOnlyConnectionTag is a key in the "external_ids" of the Connection records created by MicroOVN for the
+// read-only listeners. Read-only Connection records without it are managed by the operator and left intact.
+const readOnlyConnectionTag = "microovn-read-only-listener"
+
+// readOnlyConnectionWhere is OVSDB condition that matches Connection records created by MicroOVN.
+var readOnlyConnectionWhere = []any{[]any{"external_ids", "includes", []any{"map", []any{[]any{readOnlyConnectionTag, "true"}}}}}
+
+// readOnlyConnectionsTransaction returns OVSDB transaction that replaces read-only Connection records of the
+// "globalTable" ("NB_Global" or "SB_Global") in the "database" with records for the "listeners". Records in
+// "current" are UUIDs of the Connection records created by MicroOVN that are currently referenced. The
+// transaction fails if they changed in the meantime, e.g. because other member updated them concurrently.
+// Unreferenced Connection records are removed by the database server.
+func readOnlyConnectionsTransaction(database string, globalTable string, protocol string, current []string, listeners []ReadOnlyListener) (string, error) {
+	oldConnections := []any{}
+	oldRows := []any{}
+	for _, connUUID := range current {
+		oldConnections = append(oldConnections, []any{"uuid", connUUID})
+		oldRows = append(oldRows, map[string]any{"_uuid": []any{"uuid", connUUID}})
+	}
+
+	operations := []any{
+		database,
+		map[string]any{
+			"op":      "wait",
+			"table":   "Connection",
+			"where":   readOnlyConnectionWhere,
+			"columns": []any{"_uuid"},
+			"until":   "==",
+			"rows":    oldRows,
+			"timeout": 0,
+		},
+	}
+
+	newConnections := []any{}
+	for i, listener := range listeners {
+		name := fmt.Sprintf("ro%d", i)
+		operations = append(operations, map[string]any{
+			"op":        "insert",
+			"table":     "Connection",
+			"uuid-name": name,
+			"row": map[string]any{
+				"target":       listener.Target(protocol),
+				"read_only":    true,
+				"external_ids": []any{"map", []any{[]any{readOnlyConnectionTag, "true"}}},
+			},
+		})
+		newConnections = append(newConnections, []any{"named-uuid", name})
+	}
+
+	operations = append(operations,
+		map[string]any{
+			"op":        "mutate",
+			"table":     globalTable,
+			"where":     []any{},
+			"mutations": []any{[]any{"connections", "delete", []any{"set", oldConnections}}},
+		},
+		map[string]any{
+			"op":        "mutate",
+			"table":     globalTable,
+			"where":     []any{},
+			"mutations": []any{[]any{"connections", "insert", []any{"set", newConnections}}},
+		},
+	)
+
+	transaction, err := json.Marshal(operations)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode OVSDB transaction: %w", err)
+	}
+	return string(transaction), nil
+}
+
+// readOnlyConnectionUUIDs parses output of the "ovsdb-client transact" command with single select of UUIDs
+// from the Connection table.
+func readOnlyConnectionUUIDs(output string) ([]string, error) {
+	var results []struct {
+		Rows []struct {
+			UUID []string `json:"_uuid"`
+		} `json:"rows"`
+		Error string `json:"error"`
+	}
+	err := json.Unmarshal([]byte(output), &results)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse OVSDB transaction result: %w", err)
+	}
+
+	if len(results) != 1 || results[0].Error != "" {
+		return nil, fmt.Errorf("failed to select read-only connections: %s", output)
+	}
+
+	uuids := []string{}
+	for _, row := range results[0].Rows {
+		if len(row.UUID) == 2 && row.UUID[0] == "uuid" {
+			uuids = append(uuids, row.UUID[1])
+		}
+	}
+	return uuids, nil
+}
+
+// ApplyReadOnlyListeners updates read-only Connection records of the OVN Northbound and Southbound
+// databases to match the "ovn.nb.read-only-listeners" and "ovn.sb.read-only-listeners" config options.
+// Only Connection records created by MicroOVN are replaced. Connection records are shared by the whole
+// database cluster, so every central member starts listening on the configured ports. This function can be
+// executed on any cluster member.
+func ApplyReadOnlyListeners(ctx context.Context, s state.State) error {
+	databases := []struct {
+		key         string
+		database    string
+		globalTable string
+		transact    func(context.Context, state.State, string) (string, error)
+	}{
+		{NBReadOnlyListenersConfigKey, "OVN_Northbound", "NB_Global", ovnCmd.NBTransact},
+		{SBReadOnlyListenersConfigKey, "OVN_Southbound", "SB_Global", ovnCmd.SBTransact},
+	}
+
+	protocol := environment.NetworkProtocol(ctx, s)
+	for _, db := range databases {
+		listeners, err := configuredReadOnlyListeners(ctx, s, db.key)
+		if err != nil {
+			return err
+		}
+
+		where, err := json.Marshal(readOnlyConnectionWhere)
+		if err != nil {
+			return fmt.Errorf("failed to encode OVSDB condition: %w", err)
+		}
+		selectTransaction := fmt.Sprintf(
+			`[%q, {"op": "select", "table": "Connection", "where": %s, "columns": ["_uuid"]}]`,
+			db.database, where,
+		)
+
+		// Every central member applies the listeners when it starts, so the records may be replaced by other
+		// member between the select and the update. In that case, the update is re-tried with fresh records.
+		var lastErr error
+		for attempts := 0; attempts < 3; attempts++ {
+			lastErr = nil
+			out, err := db.transact(ctx, s, selectTransaction)
+			if err != nil {
+				return fmt.Errorf("failed to look up read-only connections of %s: %w", db.database, err)
+			}
+
+			current, err := readOnlyConnectionUUIDs(out)
+			if err != nil {
+				return err
+			}
+
+			transaction, err := readOnlyConnectionsTransaction(db.database, db.globalTable, protocol, current, listeners)
+			if err != nil {
+				return err
+			}
+
+			out, err = db.transact(ctx, s, transaction)
+			if err != nil {
+				return fmt.Errorf("failed to update read-only connections of %s: %w", db.database, err)
+			}
+
+			if !strings.Contains(out, `"error"`) {
+				break
+			}
+			lastErr = fmt.Errorf("failed to update read-only connections of %s: %s", db.database, out)
+		}
+
+		if lastErr != nil {
+			return lastErr
+		}
+	}
+
+	return nil
+}
+
+// ReadOnlyListenerTargets returns targets of the read-only connections that the local OVN Northbound and
+// Southbound database servers listen on, keyed by the short names of the databases ("nb", "sb").
+func ReadOnlyListenerTargets(ctx context.Context, s state.State) (map[string][]string, error) {
+	targets := make(map[string][]string)
+	for db, dbType := range map[string]ovnCmd.OvsdbType{"nb": ovnCmd.OvsdbTypeNBLocal, "sb": ovnCmd.OvsdbTypeSBLocal} {
+		dbSpec, err := ovnCmd.NewOvsdbSpec(dbType)
+		if err != nil {
+			return nil, err
+		}
+
+		transaction := fmt.Sprintf(
+			`[%q, {"op": "select", "table": "Connection", "where": [["read_only", "==", true]], "columns": ["target"]}]`,
+			dbSpec.Name,
+		)
+		out, err := ovnCmd.OvsdbClient(ctx, s, dbSpec, 1, 5, "transact", dbSpec.SocketURL, transaction)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list read-only connections of %s: %w", dbSpec.FriendlyName, err)
+		}
+
+		var results []struct {
+			Rows []struct {
+				Target string `json:"target"`
+			} `json:"rows"`
+		}
+		err = json.Unmarshal([]byte(out), &results)
+		if err != nil || len(results) != 1 {
+			return nil, fmt.Errorf("failed to parse read-only connections of %s: %s", dbSpec.FriendlyName, out)
+		}
+
+		for _, row := range results[0].Rows {
+			targets[db] = append(targets[db], row.Target)
+		}
+	}
+	return targets, nil
+}
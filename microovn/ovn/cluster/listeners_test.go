@@ -0,0 +1,68 @@
+package cluster
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseReadOnlyListeners(t *testing.T) {
+	listeners, err := ParseReadOnlyListeners("6645, 192.0.2.10:6647,[2001:db8::10]:6647")
+	if err != nil {
+		t.Fatalf("Failed to parse read-only listeners: %v", err)
+	}
+
+	targets := []string{}
+	for _, listener := range listeners {
+		targets = append(targets, listener.Target("ssl"))
+	}
+
+	expected := []string{"pssl:6645:[::]", "pssl:6647:192.0.2.10", "pssl:6647:[2001:db8::10]"}
+	if !slices.Equal(targets, expected) {
+		t.Errorf("Expected targets %v, got %v", expected, targets)
+	}
+
+	invalidValues := []string{
+		"0",
+		"65536",
+		"port",
+		"6641",
+		"192.0.2.10:6644",
+		"2001:db8::10:6645",
+		"[fe80::1%eth0]:6645",
+		"192.0.2.10",
+		"6645,6645",
+	}
+	for _, value := range invalidValues {
+		_, err = ParseReadOnlyListeners(value)
+		if err == nil {
+			t.Errorf("Expected error when parsing read-only listeners '%s'", value)
+		}
+	}
+}
+
+func TestUnexported_readOnlyConnectionsTransaction(t *testing.T) {
+	listeners, err := ParseReadOnlyListeners("6645,6646")
+	if err != nil {
+		t.Fatalf("Failed to parse read-only listeners: %v", err)
+	}
+
+	transaction, err := readOnlyConnectionsTransaction("OVN_Southbound", "SB_Global", "ssl", []string{"f1e0c4a2-1b47-4c5e-9d3a-2a6f1c2b7e10"}, listeners[:1])
+	if err != nil {
+		t.Fatalf("Failed to build OVSDB transaction: %v", err)
+	}
+
+	for _, part := range []string{
+		`"OVN_Southbound"`,
+		`"op":"wait"`,
+		`"rows":[{"_uuid":["uuid","f1e0c4a2-1b47-4c5e-9d3a-2a6f1c2b7e10"]}]`,
+		`"where":[["external_ids","includes",["map",[["microovn-read-only-listener","true"]]]]]`,
+		`"row":{"external_ids":["map",[["microovn-read-only-listener","true"]]],"read_only":true,"target":"pssl:6645:[::]"}`,
+		`["connections","delete",["set",[["uuid","f1e0c4a2-1b47-4c5e-9d3a-2a6f1c2b7e10"]]]]`,
+		`["connections","insert",["set",[["named-uuid","ro0"]]]]`,
+	} {
+		if !strings.Contains(transaction, part) {
+			t.Errorf("OVSDB transaction is missing '%s':\n%s", part, transaction)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package cluster
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"net/netip"
+	"strings"
+
+	"github.com/canonical/microcluster/v2/cluster"
+	"github.com/canonical/microcluster/v2/state"
+
+	"github.com/canonical/microovn/microovn/config"
+)
+
+// RemotePolicy is a name of the policy that determines in which order are OVN Southbound
+// endpoints listed in the "ovn-remote" configuration of each chassis.
+type RemotePolicy = string
+
+const (
+	// RemotePolicyOrdered    - endpoints are listed in the same order on every chassis (default).
+	RemotePolicyOrdered RemotePolicy = "ordered"
+	// RemotePolicyRandom     - endpoints are shuffled, with the order being stable for each chassis.
+	RemotePolicyRandom RemotePolicy = "random"
+	// RemotePolicyLocalFirst - endpoint running on the chassis itself is listed first, followed
+	// by the rest of the endpoints in per-chassis random order.
+	RemotePolicyLocalFirst RemotePolicy = "local-first"
+	// RemotePolicyZone       - endpoints in the same zone as the chassis are listed first, followed
+	// by the rest of the endpoints. Both groups are in per-chassis random order.
+	RemotePolicyZone RemotePolicy = "zone"
+)
+
+// RemotePolicies is a list of all valid values of the "ovn.remote-policy" config option.
+var RemotePolicies = []RemotePolicy{RemotePolicyOrdered, RemotePolicyRandom, RemotePolicyLocalFirst, RemotePolicyZone}
+
+// ParseZones parses value of the "ovn.zones" config option. The expected format is a comma-separated
+// list of "<member>=<zone>" pairs, where "<member>" is either a name of the MicroOVN cluster member
+// or an IP address of the OVN central endpoint. Returned map is keyed by the "<member>".
+func ParseZones(value string) (map[string]string, error) {
+	zones := make(map[string]string)
+	for _, item := range strings.Split(value, ",") {
+		member, zone, found := strings.Cut(strings.TrimSpace(item), "=")
+		if !found || member == "" || zone == "" {
+			return nil, fmt.Errorf("zone definition '%s' does not conform to the '<member>=<zone>' format", item)
+		}
+		if _, exists := zones[member]; exists {
+			return nil, fmt.Errorf("zone for '%s' is defined multiple times", member)
+		}
+		zones[member] = zone
+	}
+	return zones, nil
+}
+
+// remoteAddressOrder contains all the inputs required to order OVN Southbound endpoints for
+// a single chassis.
+type remoteAddressOrder struct {
+	Policy        RemotePolicy      // Ordering policy
+	Chassis       string            // Name of the chassis, used to seed per-chassis random order
+	LocalAddrs    []string          // Addresses that belong to the chassis itself
+	Zones         map[string]string // Zone definitions as returned by ParseZones
+	MemberByAddr  map[string]string // Lookup table of MicroOVN member names by their address
+	ChassisMember string            // Name of the MicroOVN member on which the chassis runs
+}
+
+// zoneOf returns zone of the given address. The address is first looked up directly in the zone
+// definitions, then by the name of the MicroOVN member that owns it. Empty string is returned if
+// the zone is not known.
+func (o remoteAddressOrder) zoneOf(addr string) string {
+	if zone, ok := o.Zones[addr]; ok {
+		return zone
+	}
+	if member, ok := o.MemberByAddr[addr]; ok {
+		return o.Zones[member]
+	}
+	return ""
+}
+
+// shuffle returns a copy of "addrs" in a random order that is stable for the chassis.
+func (o remoteAddressOrder) shuffle(addrs []string) []string {
+	seed := fnv.New64a()
+	_, _ = seed.Write([]byte(o.Chassis))
+
+	shuffled := append([]string{}, addrs...)
+	rng := rand.New(rand.NewSource(int64(seed.Sum64())))
+	rng.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+	return shuffled
+}
+
+// Apply returns a copy of "addrs" ordered according to the policy.
+func (o remoteAddressOrder) Apply(addrs []string) []string {
+	var preferred, rest []string
+
+	switch o.Policy {
+	case RemotePolicyRandom:
+		return o.shuffle(addrs)
+	case RemotePolicyLocalFirst:
+		for _, addr := range addrs {
+			if containsAddr(o.LocalAddrs, addr) {
+				preferred = append(preferred, addr)
+			} else {
+				rest = append(rest, addr)
+			}
+		}
+		return append(preferred, o.shuffle(rest)...)
+	case RemotePolicyZone:
+		localZone := o.Zones[o.ChassisMember]
+		for _, addr := range addrs {
+			if localZone != "" && o.zoneOf(addr) == localZone {
+				preferred = append(preferred, addr)
+			} else {
+				rest = append(rest, addr)
+			}
+		}
+		return append(o.shuffle(preferred), o.shuffle(rest)...)
+	default:
+		return append([]string{}, addrs...)
+	}
+}
+
+// containsAddr returns true if "addr" is in the "addrList". Addresses are compared in their
+// canonical form, so that different notations of the same IPv6 address are considered equal.
+func containsAddr(addrList []string, addr string) bool {
+	needle, err := netip.ParseAddr(addr)
+	for _, item := range addrList {
+		if item == addr {
+			return true
+		}
+		if err != nil {
+			continue
+		}
+		parsedItem, itemErr := netip.ParseAddr(item)
+		if itemErr == nil && parsedItem == needle {
+			return true
+		}
+	}
+	return false
+}
+
+// newRemoteAddressOrder reads "ovn.remote-policy" and "ovn.zones" config options and prepares
+// remoteAddressOrder for the local chassis.
+func newRemoteAddressOrder(ctx context.Context, s state.State) (remoteAddressOrder, error) {
+	order := remoteAddressOrder{
+		Policy:        RemotePolicyOrdered,
+		Chassis:       s.Name(),
+		ChassisMember: s.Name(),
+		LocalAddrs:    []string{s.Address().Hostname()},
+		Zones:         map[string]string{},
+		MemberByAddr:  map[string]string{},
+	}
+
+	policyConfig, err := config.GetConfig(ctx, s, "ovn.remote-policy")
+	if err != nil {
+		return order, err
+	}
+	if policyConfig != nil {
+		order.Policy = policyConfig.Value
+	}
+
+	if order.Policy != RemotePolicyZone {
+		return order, nil
+	}
+
+	zonesConfig, err := config.GetConfig(ctx, s, "ovn.zones")
+	if err != nil {
+		return order, err
+	}
+	if zonesConfig != nil {
+		order.Zones, err = ParseZones(zonesConfig.Value)
+		if err != nil {
+			return order, err
+		}
+	}
+
+	err = s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		clusterMembers, err := cluster.GetCoreClusterMembers(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		for _, member := range clusterMembers {
+			addrPort, err := netip.ParseAddrPort(member.Address)
+			if err != nil {
+				return err
+			}
+			order.MemberByAddr[addrPort.Addr().String()] = member.Name
+		}
+		return nil
+	})
+
+	return order, err
+}
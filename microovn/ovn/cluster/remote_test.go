@@ -0,0 +1,104 @@
+package cluster
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseZones(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected map[string]string
+		isValid  bool
+	}{
+		{
+			value:    "micro1=rack1",
+			expected: map[string]string{"micro1": "rack1"},
+			isValid:  true,
+		},
+		{
+			value:    "micro1=rack1, micro2=rack2,10.0.0.1=rack2",
+			expected: map[string]string{"micro1": "rack1", "micro2": "rack2", "10.0.0.1": "rack2"},
+			isValid:  true,
+		},
+		// Missing zone
+		{value: "micro1=", isValid: false},
+		// Missing member
+		{value: "=rack1", isValid: false},
+		// Missing separator
+		{value: "micro1", isValid: false},
+		// Empty value
+		{value: "", isValid: false},
+		// Duplicate member
+		{value: "micro1=rack1,micro1=rack2", isValid: false},
+	}
+
+	for _, tc := range testCases {
+		zones, err := ParseZones(tc.value)
+		if !tc.isValid {
+			if err == nil {
+				t.Errorf("Expected value '%s' to be rejected", tc.value)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Failed to parse value '%s': %s", tc.value, err)
+			continue
+		}
+
+		for member, zone := range tc.expected {
+			if zones[member] != zone {
+				t.Errorf("Expected member '%s' to be in zone '%s', got '%s'", member, zone, zones[member])
+			}
+		}
+	}
+}
+
+func TestRemoteAddressOrderApply(t *testing.T) {
+	addrs := []string{"10.0.0.1", "10.0.0.2", "10.0.0.3", "10.0.0.4"}
+
+	// Default policy keeps original order
+	ordered := remoteAddressOrder{Policy: RemotePolicyOrdered, Chassis: "micro1"}
+	if result := ordered.Apply(addrs); !slices.Equal(result, addrs) {
+		t.Errorf("Expected '%s' policy to keep original order, got %v", RemotePolicyOrdered, result)
+	}
+
+	// Random policy is stable for the same chassis and contains all addresses
+	random := remoteAddressOrder{Policy: RemotePolicyRandom, Chassis: "micro1"}
+	first := random.Apply(addrs)
+	if second := random.Apply(addrs); !slices.Equal(first, second) {
+		t.Errorf("Expected '%s' policy to be stable, got %v and %v", RemotePolicyRandom, first, second)
+	}
+	sorted := slices.Clone(first)
+	slices.Sort(sorted)
+	if !slices.Equal(sorted, addrs) {
+		t.Errorf("Expected '%s' policy to keep all addresses, got %v", RemotePolicyRandom, first)
+	}
+
+	// Local-first policy puts local address first, regardless of notation
+	localFirst := remoteAddressOrder{
+		Policy:     RemotePolicyLocalFirst,
+		Chassis:    "micro3",
+		LocalAddrs: []string{"fe80::3"},
+	}
+	result := localFirst.Apply([]string{"fe80::1", "fe80::2", "fe80:0::3"})
+	if result[0] != "fe80:0::3" || len(result) != 3 {
+		t.Errorf("Expected local address to be listed first, got %v", result)
+	}
+
+	// Zone policy puts addresses from the same zone first
+	zone := remoteAddressOrder{
+		Policy:        RemotePolicyZone,
+		Chassis:       "micro4",
+		ChassisMember: "micro4",
+		Zones:         map[string]string{"micro4": "rack2", "micro3": "rack2", "10.0.0.4": "rack2", "micro1": "rack1"},
+		MemberByAddr:  map[string]string{"10.0.0.1": "micro1", "10.0.0.3": "micro3"},
+	}
+	result = zone.Apply(addrs)
+	preferred := slices.Clone(result[:2])
+	slices.Sort(preferred)
+	if !slices.Equal(preferred, []string{"10.0.0.3", "10.0.0.4"}) {
+		t.Errorf("Expected addresses from the same zone to be listed first, got %v", result)
+	}
+}
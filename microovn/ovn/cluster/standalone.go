@@ -0,0 +1,196 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/canonical/lxd/shared"
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v2/state"
+
+	"github.com/canonical/microovn/microovn/config"
+	ovnCmd "github.com/canonical/microovn/microovn/ovn/cmd"
+	"github.com/canonical/microovn/microovn/ovn/environment"
+	"github.com/canonical/microovn/microovn/ovn/paths"
+	"github.com/canonical/microovn/microovn/snap"
+)
+
+// centralElectionTimer is the RAFT election timer (in milliseconds) used by OVN central databases. It must
+// be kept in sync with the "ELECTION_TIMER" in the "ovn-central.env" file.
+const centralElectionTimer = 16000
+
+// standaloneDatabase describes a single OVN central database that can be converted from
+// the standalone to the clustered mode.
+type standaloneDatabase struct {
+	DBType   ovnCmd.OvsdbType // Type of the database
+	Service  string           // Name of the snap service that runs the database server
+	Path     string           // Path to the database file
+	RaftPort int              // Port used by the database for RAFT communication
+	CtlSock  string           // Path to the control socket of the database server
+}
+
+// standaloneDatabases returns list of OVN central databases that are subject to the conversion.
+func standaloneDatabases() []standaloneDatabase {
+	return []standaloneDatabase{
+		{
+			DBType:   ovnCmd.OvsdbTypeNBLocal,
+			Service:  "ovn-ovsdb-server-nb",
+			Path:     paths.CentralDBNBPath(),
+			RaftPort: 6643,
+			CtlSock:  paths.OvnNBControlSock(),
+		},
+		{
+			DBType:   ovnCmd.OvsdbTypeSBLocal,
+			Service:  "ovn-ovsdb-server-sb",
+			Path:     paths.CentralDBSBPath(),
+			RaftPort: 6644,
+			CtlSock:  paths.OvnSBControlSock(),
+		},
+	}
+}
+
+// isStandaloneDBFile returns true if the database file at "path" exists and is in the standalone format.
+func isStandaloneDBFile(ctx context.Context, path string) bool {
+	if !shared.PathExists(path) {
+		return false
+	}
+
+	_, err := shared.RunCommandContext(ctx, "ovsdb-tool", "db-is-standalone", path)
+	return err == nil
+}
+
+// convertDBFile converts standalone database file at "db.Path" to a single-member clustered database
+// that uses "raftAddr" for RAFT communication. The original standalone database file is kept as a backup
+// with ".standalone" suffix.
+func convertDBFile(ctx context.Context, db standaloneDatabase, raftAddr string) error {
+	backupPath := db.Path + ".standalone"
+	err := os.Rename(db.Path, backupPath)
+	if err != nil {
+		return fmt.Errorf("failed to back up standalone database '%s': %w", db.Path, err)
+	}
+
+	_, err = shared.RunCommandContext(ctx, "ovsdb-tool", "create-cluster", db.Path, backupPath, raftAddr)
+	if err != nil {
+		_ = os.Remove(db.Path)
+		restoreErr := os.Rename(backupPath, db.Path)
+		if restoreErr != nil {
+			return errors.Join(fmt.Errorf("failed to convert database '%s': %w", db.Path, err), restoreErr)
+		}
+		return fmt.Errorf("failed to convert database '%s': %w", db.Path, err)
+	}
+
+	return nil
+}
+
+// raiseElectionTimer gradually increases the election timer of a freshly converted clustered database to
+// the value used by MicroOVN. OVSDB allows the timer to be at most doubled in a single step.
+func raiseElectionTimer(ctx context.Context, s state.State, db standaloneDatabase, dbName string) error {
+	for timer := 2000; ; timer *= 2 {
+		if timer > centralElectionTimer {
+			timer = centralElectionTimer
+		}
+
+		_, err := ovnCmd.AppCtl(ctx, s, db.CtlSock, "cluster/change-election-timer", dbName, strconv.Itoa(timer))
+		if err != nil {
+			return err
+		}
+
+		if timer == centralElectionTimer {
+			return nil
+		}
+	}
+}
+
+// ConvertStandaloneDatabases converts OVN Northbound and Southbound databases running on this member from
+// the standalone mode to single-member clustered databases. Other central members can join them afterward.
+// Database servers are stopped for the duration of the conversion, and the cluster-wide database mode is
+// switched to "clustered" once both databases are converted.
+//
+// This function has no effect if the databases are already clustered.
+func ConvertStandaloneDatabases(ctx context.Context, s state.State) error {
+	standalone, err := environment.IsStandaloneDatabase(ctx, s)
+	if err != nil {
+		return fmt.Errorf("failed to get OVN database mode: %w", err)
+	}
+
+	if !standalone {
+		return nil
+	}
+
+	localAddr, err := environment.LocalRaftAddress(ctx, s)
+	if err != nil {
+		return fmt.Errorf("failed to get local RAFT address: %w", err)
+	}
+
+	databases := standaloneDatabases()
+	for _, db := range databases {
+		err = snap.Stop(db.Service, false)
+		if err != nil {
+			return fmt.Errorf("failed to stop '%s': %w", db.Service, err)
+		}
+	}
+
+	var convertErr error
+	for _, db := range databases {
+		if !isStandaloneDBFile(ctx, db.Path) {
+			continue
+		}
+
+		raftAddr := fmt.Sprintf("ssl:%s", net.JoinHostPort(localAddr, strconv.Itoa(db.RaftPort)))
+		logger.Infof("Converting standalone OVN database '%s' to clustered database (%s)", db.Path, raftAddr)
+		convertErr = convertDBFile(ctx, db, raftAddr)
+		if convertErr != nil {
+			break
+		}
+	}
+
+	if convertErr == nil {
+		convertErr = config.SetConfig(ctx, s, environment.DatabaseModeConfigKey, environment.DatabaseModeClustered)
+	}
+
+	// Regenerate environment and start database servers regardless of the conversion result. In case of
+	// failure, the databases are started in their original mode.
+	err = environment.GenerateEnvironment(ctx, s)
+	if err != nil {
+		return errors.Join(convertErr, fmt.Errorf("failed to generate the daemon configuration: %w", err))
+	}
+
+	var startErrors []string
+	for _, db := range databases {
+		err = snap.Start(db.Service, false)
+		if err != nil {
+			startErrors = append(startErrors, fmt.Sprintf("failed to start '%s': %v", db.Service, err))
+			continue
+		}
+
+		dbSpec, err := ovnCmd.NewOvsdbSpec(db.DBType)
+		if err != nil {
+			startErrors = append(startErrors, err.Error())
+			continue
+		}
+
+		err = ovnCmd.WaitForDBState(ctx, s, dbSpec, ovnCmd.OvsdbConnected, ovnCmd.DefaultDBConnectWait)
+		if err != nil {
+			startErrors = append(startErrors, err.Error())
+			continue
+		}
+
+		if convertErr == nil {
+			err = raiseElectionTimer(ctx, s, db, dbSpec.Name)
+			if err != nil {
+				logger.Warnf("Failed to set election timer of OVN %s database: %v", dbSpec.FriendlyName, err)
+			}
+		}
+	}
+
+	if len(startErrors) > 0 {
+		return errors.Join(convertErr, errors.New(strings.Join(startErrors, "; ")))
+	}
+
+	return convertErr
+}
@@ -0,0 +1,416 @@
+package cluster
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"sync"
+	"time"
+
+	"github.com/canonical/lxd/shared"
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v2/state"
+
+	"github.com/canonical/microovn/microovn/config"
+	"github.com/canonical/microovn/microovn/database"
+	ovnCmd "github.com/canonical/microovn/microovn/ovn/cmd"
+	"github.com/canonical/microovn/microovn/ovn/environment"
+	"github.com/canonical/microovn/microovn/ovn/vip"
+)
+
+// VirtualIPCheckInterval is a period in which every member checks whether it should hold virtual IPs of
+// the OVN databases.
+const VirtualIPCheckInterval = 2 * time.Second
+
+// VirtualIPConfigRefreshInterval is a period in which every member re-reads the configuration of virtual IPs
+// and verifies that the virtual IPs it holds are still assigned to its interfaces.
+const VirtualIPConfigRefreshInterval = 15 * time.Second
+
+// virtualIPReleaseTimeout is a time limit for removing the held virtual IPs when MicroOVN daemon stops.
+const virtualIPReleaseTimeout = 10 * time.Second
+
+// virtualIPAnnouncements is a number of gratuitous ARP requests or unsolicited Neighbor Advertisements sent
+// when a member takes over a virtual IP.
+const virtualIPAnnouncements = 3
+
+// virtualIPDatabases maps short names of the OVN databases to the types of their local servers.
+var virtualIPDatabases = map[string]ovnCmd.OvsdbType{
+	"nb": ovnCmd.OvsdbTypeNBLocal,
+	"sb": ovnCmd.OvsdbTypeSBLocal,
+}
+
+// heldVirtualIP describes virtual IP assigned to the local interface.
+type heldVirtualIP struct {
+	Prefix netip.Prefix // Virtual IP address with the length of its network prefix
+	Iface  string       // Name of the interface to which the virtual IP is assigned
+}
+
+// heldVirtualIPs holds virtual IPs currently assigned to the local interfaces, keyed by the short names of
+// the OVN databases.
+var heldVirtualIPs = struct {
+	sync.Mutex
+	ips map[string]heldVirtualIP
+}{ips: make(map[string]heldVirtualIP)}
+
+// localVirtualIP describes virtual IP configured for an OVN database, resolved to the local interface.
+type localVirtualIP struct {
+	Wanted heldVirtualIP // Virtual IP as it should be assigned to the local interface
+	Err    error         // Error that prevented resolution of the local interface
+}
+
+// virtualIPConfig holds configuration of virtual IPs relevant to the local member, cached between the checks.
+type virtualIPConfig struct {
+	VirtualIPs map[string]localVirtualIP // Virtual IPs keyed by the short names of the OVN databases
+	Standalone bool                      // Whether the OVN databases run in standalone mode
+}
+
+// interfacePrefixes holds addresses, with their network prefixes, assigned to a network interface.
+type interfacePrefixes struct {
+	Name     string
+	Prefixes []netip.Prefix
+}
+
+// HeldVirtualIPs returns virtual IP addresses currently held by the local member, keyed by the short names
+// of the OVN databases.
+func HeldVirtualIPs() map[string]string {
+	heldVirtualIPs.Lock()
+	defer heldVirtualIPs.Unlock()
+
+	result := make(map[string]string, len(heldVirtualIPs.ips))
+	for db, held := range heldVirtualIPs.ips {
+		result[db] = held.Prefix.Addr().String()
+	}
+	return result
+}
+
+// RunVirtualIPs periodically assigns virtual IPs of the OVN databases to the local member if it runs the
+// RAFT leader of the database, and removes them otherwise, until the "ctx" is cancelled. Configuration of
+// the virtual IPs is cached and re-read every VirtualIPConfigRefreshInterval, in between only the RAFT role
+// of the databases is checked, and the virtual IPs are moved only when the role changes. Held virtual IPs
+// are removed when the "ctx" is cancelled, because leadership can move to a different member while
+// MicroOVN daemon is stopped, and the virtual IP would be then held by two members.
+func RunVirtualIPs(ctx context.Context, s state.State) {
+	ticker := time.NewTicker(VirtualIPCheckInterval)
+	defer ticker.Stop()
+
+	var cachedConfig *virtualIPConfig
+	var refreshed time.Time
+	appliedRoles := make(map[string]string)
+	for {
+		// Skip if the database isn't ready, the member might not be part of the cluster yet.
+		err := s.Database().IsOpen(ctx)
+		if err == nil && (cachedConfig == nil || time.Since(refreshed) >= VirtualIPConfigRefreshInterval) {
+			cachedConfig, err = loadVirtualIPConfig(ctx, s)
+			refreshed = time.Now()
+			// Re-apply the virtual IPs with refreshed configuration, even if the roles didn't change
+			clear(appliedRoles)
+		}
+
+		if err == nil {
+			err = reconcileVirtualIPs(ctx, s, *cachedConfig, appliedRoles)
+		}
+
+		if err != nil && ctx.Err() == nil {
+			logger.Warnf("Failed to update virtual IPs of OVN databases: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			releaseCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), virtualIPReleaseTimeout)
+			err = releaseAllVirtualIPs(releaseCtx)
+			cancel()
+			if err != nil {
+				logger.Warnf("Failed to release virtual IPs of OVN databases: %v", err)
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// hasLocalCentral returns true if the "central" service is enabled on the local member.
+func hasLocalCentral(ctx context.Context, s state.State) (bool, error) {
+	hasCentral := false
+	err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		name := s.Name()
+		service := "central"
+		services, err := database.GetServices(ctx, tx, database.ServiceFilter{Member: &name, Service: &service})
+		hasCentral = len(services) > 0
+		return err
+	})
+	return hasCentral, err
+}
+
+// loadVirtualIPConfig reads configuration of the virtual IPs and resolves the local interfaces to which they
+// should be assigned. Members without the "central" service have no virtual IPs configured.
+func loadVirtualIPConfig(ctx context.Context, s state.State) (*virtualIPConfig, error) {
+	result := &virtualIPConfig{VirtualIPs: make(map[string]localVirtualIP)}
+	hasCentral, err := hasLocalCentral(ctx, s)
+	if err != nil || !hasCentral {
+		return result, err
+	}
+
+	virtualIPs, err := vip.Configured(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Standalone, err = environment.IsStandaloneDatabase(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+
+	ifaceName := ""
+	item, err := config.GetMemberConfig(ctx, s, s.Name(), vip.InterfaceConfigKey)
+	if err != nil {
+		return nil, err
+	}
+	if item != nil {
+		ifaceName = item.Value
+	}
+
+	ifaces, err := localInterfacePrefixes()
+	if err != nil {
+		return nil, err
+	}
+
+	for db, virtualIP := range virtualIPs {
+		iface, prefix, err := findVirtualIPInterface(ifaces, virtualIP, ifaceName)
+		result.VirtualIPs[db] = localVirtualIP{Wanted: heldVirtualIP{Prefix: prefix, Iface: iface}, Err: err}
+	}
+	return result, nil
+}
+
+// reconcileVirtualIPs assigns every configured virtual IP to the local member if its database server is the
+// RAFT leader (or if the databases run in standalone mode), and removes it otherwise. Virtual IPs of the
+// databases whose role is the same as in "appliedRoles" are left as they are, and "appliedRoles" is updated
+// with the roles that were successfully applied.
+func reconcileVirtualIPs(ctx context.Context, s state.State, cachedConfig virtualIPConfig, appliedRoles map[string]string) error {
+	var errs []error
+	for db, dbType := range virtualIPDatabases {
+		virtualIP, configured := cachedConfig.VirtualIPs[db]
+		if !configured {
+			delete(appliedRoles, db)
+			errs = append(errs, releaseVirtualIP(ctx, db, nil))
+			continue
+		}
+
+		role := "leader"
+		if !cachedConfig.Standalone {
+			var err error
+			role, err = RaftRole(ctx, s, dbType)
+			if err != nil {
+				// Keep the current state if the role can't be determined
+				errs = append(errs, err)
+				continue
+			}
+		}
+
+		if appliedRoles[db] == role {
+			continue
+		}
+
+		var err error
+		if role == "leader" {
+			err = virtualIP.Err
+			if err == nil {
+				err = acquireVirtualIP(ctx, db, virtualIP.Wanted)
+			}
+		} else {
+			stray := &virtualIP.Wanted
+			if virtualIP.Err != nil {
+				stray = nil
+			}
+			err = releaseVirtualIP(ctx, db, stray)
+		}
+
+		if err != nil {
+			delete(appliedRoles, db)
+			errs = append(errs, err)
+			continue
+		}
+		appliedRoles[db] = role
+	}
+
+	return errors.Join(errs...)
+}
+
+// localInterfacePrefixes returns addresses assigned to the network interfaces of the local system.
+func localInterfacePrefixes() ([]interfacePrefixes, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list network interfaces: %w", err)
+	}
+
+	result := make([]interfacePrefixes, 0, len(ifaces))
+	for _, iface := range ifaces {
+		addrs, err := iface.Addrs()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list addresses of interface '%s': %w", iface.Name, err)
+		}
+
+		prefixes := interfacePrefixes{Name: iface.Name}
+		for _, addr := range addrs {
+			prefix, err := netip.ParsePrefix(addr.String())
+			if err == nil {
+				prefixes.Prefixes = append(prefixes.Prefixes, netip.PrefixFrom(prefix.Addr().Unmap(), prefix.Bits()))
+			}
+		}
+		result = append(result, prefixes)
+	}
+	return result, nil
+}
+
+// findVirtualIPInterface selects the interface from "ifaces" to which the "virtualIP" should be assigned.
+// If "ifaceName" is not empty, that interface is used. Otherwise, the interface that has an address from
+// the subnet containing the virtual IP is selected. The virtual IP is returned with the length of its
+// network prefix, which is taken from the matching address of the interface if it's not configured.
+func findVirtualIPInterface(ifaces []interfacePrefixes, virtualIP vip.VirtualIP, ifaceName string) (string, netip.Prefix, error) {
+	for _, iface := range ifaces {
+		if ifaceName != "" && iface.Name != ifaceName {
+			continue
+		}
+
+		for _, prefix := range iface.Prefixes {
+			// Skip the virtual IP itself, it may have been assigned with full-length prefix
+			if prefix.Addr() == virtualIP.Address || !prefix.Masked().Contains(virtualIP.Address) {
+				continue
+			}
+
+			prefixLen := virtualIP.PrefixLen
+			if prefixLen < 0 {
+				prefixLen = prefix.Bits()
+			}
+			return iface.Name, netip.PrefixFrom(virtualIP.Address, prefixLen), nil
+		}
+
+		if ifaceName != "" {
+			prefixLen := virtualIP.PrefixLen
+			if prefixLen < 0 {
+				prefixLen = virtualIP.Address.BitLen()
+			}
+			return iface.Name, netip.PrefixFrom(virtualIP.Address, prefixLen), nil
+		}
+	}
+
+	if ifaceName != "" {
+		return "", netip.Prefix{}, fmt.Errorf("interface '%s' not found", ifaceName)
+	}
+	return "", netip.Prefix{}, fmt.Errorf(
+		"no interface with subnet containing virtual IP %s found. Select interface with '%s' config option",
+		virtualIP.Address, vip.InterfaceConfigKey,
+	)
+}
+
+// hasInterfaceAddress returns true if the "addr" is assigned to the local interface "ifaceName".
+func hasInterfaceAddress(ifaceName string, addr netip.Addr) bool {
+	ifaces, err := localInterfacePrefixes()
+	if err != nil {
+		return false
+	}
+
+	for _, iface := range ifaces {
+		if iface.Name != ifaceName {
+			continue
+		}
+
+		for _, prefix := range iface.Prefixes {
+			if prefix.Addr() == addr {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// acquireVirtualIP assigns the "wanted" virtual IP of the OVN database "db" to the local interface and
+// announces it to the network. This function has no effect if the virtual IP is already held by the local member.
+func acquireVirtualIP(ctx context.Context, db string, wanted heldVirtualIP) error {
+	heldVirtualIPs.Lock()
+	defer heldVirtualIPs.Unlock()
+
+	ifaceName, prefix := wanted.Iface, wanted.Prefix
+	held, isHeld := heldVirtualIPs.ips[db]
+	if isHeld && held == wanted && hasInterfaceAddress(ifaceName, prefix.Addr()) {
+		return nil
+	}
+
+	if isHeld && held != wanted {
+		err := removeInterfaceAddress(ctx, held)
+		if err != nil {
+			return err
+		}
+		delete(heldVirtualIPs.ips, db)
+	}
+
+	logger.Infof("Taking over virtual IP %s of OVN %s database on interface '%s'", prefix, db, ifaceName)
+	if !hasInterfaceAddress(ifaceName, prefix.Addr()) {
+		args := []string{"address", "add", prefix.String(), "dev", ifaceName}
+		if prefix.Addr().Is6() {
+			// Skip duplicate address detection, the address is expected to move between members
+			args = append(args, "nodad")
+		}
+
+		_, err := shared.RunCommandContext(ctx, "ip", args...)
+		if err != nil {
+			return fmt.Errorf("failed to assign virtual IP %s to interface '%s': %w", prefix, ifaceName, err)
+		}
+	}
+	heldVirtualIPs.ips[db] = wanted
+
+	err := vip.Announce(ifaceName, prefix.Addr(), virtualIPAnnouncements)
+	if err != nil {
+		return fmt.Errorf("failed to announce virtual IP %s: %w", prefix, err)
+	}
+	return nil
+}
+
+// releaseVirtualIP removes the virtual IP of the OVN database "db" from the local interface. If the local
+// member does not know that it holds the virtual IP (e.g. after crash of the daemon), but the "stray" virtual
+// IP is assigned to the local interface anyway, it's removed as well.
+func releaseVirtualIP(ctx context.Context, db string, stray *heldVirtualIP) error {
+	heldVirtualIPs.Lock()
+	defer heldVirtualIPs.Unlock()
+
+	held, isHeld := heldVirtualIPs.ips[db]
+	if !isHeld {
+		if stray == nil || !hasInterfaceAddress(stray.Iface, stray.Prefix.Addr()) {
+			return nil
+		}
+		held = *stray
+	}
+
+	logger.Infof("Releasing virtual IP %s of OVN %s database from interface '%s'", held.Prefix, db, held.Iface)
+	err := removeInterfaceAddress(ctx, held)
+	if err != nil {
+		return err
+	}
+
+	delete(heldVirtualIPs.ips, db)
+	return nil
+}
+
+// releaseAllVirtualIPs removes all virtual IPs held by the local member from the local interfaces.
+func releaseAllVirtualIPs(ctx context.Context) error {
+	var errs []error
+	for db := range virtualIPDatabases {
+		errs = append(errs, releaseVirtualIP(ctx, db, nil))
+	}
+	return errors.Join(errs...)
+}
+
+// removeInterfaceAddress removes the "held" virtual IP from its interface, if it's still assigned to it.
+func removeInterfaceAddress(ctx context.Context, held heldVirtualIP) error {
+	if !hasInterfaceAddress(held.Iface, held.Prefix.Addr()) {
+		return nil
+	}
+
+	_, err := shared.RunCommandContext(ctx, "ip", "address", "del", held.Prefix.String(), "dev", held.Iface)
+	if err != nil {
+		return fmt.Errorf("failed to remove virtual IP %s from interface '%s': %w", held.Prefix, held.Iface, err)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package cluster
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/canonical/microovn/microovn/ovn/vip"
+)
+
+func TestUnexported_findVirtualIPInterface(t *testing.T) {
+	ifaces := []interfacePrefixes{
+		{Name: "lo", Prefixes: []netip.Prefix{netip.MustParsePrefix("127.0.0.1/8")}},
+		{Name: "eth0", Prefixes: []netip.Prefix{
+			netip.MustParsePrefix("192.0.2.10/24"),
+			netip.MustParsePrefix("192.0.2.100/32"),
+			netip.MustParsePrefix("2001:db8::10/64"),
+		}},
+		{Name: "eth1", Prefixes: []netip.Prefix{netip.MustParsePrefix("198.51.100.10/24")}},
+	}
+
+	testCases := []struct {
+		virtualIP string
+		ifaceName string
+		expected  string
+		prefix    string
+		isValid   bool
+	}{
+		// Interface and prefix length taken from the subnet that contains virtual IP
+		{virtualIP: "192.0.2.100", expected: "eth0", prefix: "192.0.2.100/24", isValid: true},
+		{virtualIP: "2001:db8::100", expected: "eth0", prefix: "2001:db8::100/64", isValid: true},
+		// Explicit prefix length
+		{virtualIP: "198.51.100.100/32", expected: "eth1", prefix: "198.51.100.100/32", isValid: true},
+		// Explicit interface without matching subnet
+		{virtualIP: "203.0.113.100", ifaceName: "eth1", expected: "eth1", prefix: "203.0.113.100/32", isValid: true},
+		// Explicit interface with matching subnet
+		{virtualIP: "198.51.100.100", ifaceName: "eth1", expected: "eth1", prefix: "198.51.100.100/24", isValid: true},
+		// No interface with matching subnet
+		{virtualIP: "203.0.113.100", isValid: false},
+		// Unknown interface
+		{virtualIP: "192.0.2.100", ifaceName: "eth2", isValid: false},
+	}
+
+	for _, tc := range testCases {
+		virtualIP, err := vip.Parse(tc.virtualIP)
+		if err != nil {
+			t.Fatalf("Failed to parse virtual IP '%s': %v", tc.virtualIP, err)
+		}
+
+		iface, prefix, err := findVirtualIPInterface(ifaces, virtualIP, tc.ifaceName)
+		if !tc.isValid {
+			if err == nil {
+				t.Errorf("Expected no interface for virtual IP '%s' (interface '%s'), got '%s'", tc.virtualIP, tc.ifaceName, iface)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Unexpected error for virtual IP '%s': %v", tc.virtualIP, err)
+			continue
+		}
+
+		if iface != tc.expected || prefix.String() != tc.prefix {
+			t.Errorf("Expected virtual IP '%s' on '%s' as %s, got '%s' as %s", tc.virtualIP, tc.expected, tc.prefix, iface, prefix)
+		}
+	}
+}
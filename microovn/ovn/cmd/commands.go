@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/canonical/lxd/shared"
 	"github.com/canonical/lxd/shared/logger"
@@ -90,6 +91,17 @@ func NewOvsdbSpec(dbType OvsdbType) (*OvsdbSpec, error) {
 	return dbSpec, err
 }
 
+// OvsdbSchemaVersion returns version of the database schema stored in the schema file of the "dbSpec". This is
+// the schema version that was shipped with current OVN/OVS packages.
+func OvsdbSchemaVersion(ctx context.Context, dbSpec *OvsdbSpec) (string, error) {
+	schemaVersion, err := shared.RunCommandContext(ctx, "ovsdb-tool", "schema-version", dbSpec.Schema)
+	if err != nil {
+		return "", fmt.Errorf("failed to get DB schema version from file '%s': '%s'", dbSpec.Schema, err)
+	}
+
+	return strings.TrimSpace(schemaVersion), nil
+}
+
 // WaitForDBState as the name suggests, waits for specified ovsdb database to settle in
 // specified state. If database does not reach this state within timeout, this function returns error.
 // SocketURL specified in "db" parameter does not need to necessarily exist before this function is executed,
@@ -239,6 +251,53 @@ func NBCtlCluster(ctx context.Context, s state.State, args ...string) (string, e
 	return "", err
 }
 
+// NBTransact executes raw OVSDB "transaction" (JSON array of operations, starting with the database name)
+// against the OVN Northbound database cluster. See clusterTransact for details.
+func NBTransact(ctx context.Context, s state.State, transaction string) (string, error) {
+	return clusterTransact(ctx, s, "OVN_Northbound", 6641, transaction)
+}
+
+// SBTransact executes raw OVSDB "transaction" (JSON array of operations, starting with the database name)
+// against the OVN Southbound database cluster. See clusterTransact for details.
+func SBTransact(ctx context.Context, s state.State, transaction string) (string, error) {
+	return clusterTransact(ctx, s, "OVN_Southbound", 6642, transaction)
+}
+
+// clusterTransact executes raw OVSDB "transaction" against the database "db" in the "central" cluster. It
+// iterates over the hosts in the OVN central cluster and returns output of the "ovsdb-client transact" command
+// from the first host that it successfully connects to at the specified 'port'.
+//
+// Note that the output may contain errors of individual operations, even if the command itself succeeds.
+func clusterTransact(ctx context.Context, s state.State, db string, port int, transaction string) (string, error) {
+	nbIPs, err := environment.CentralIps(ctx, s)
+	if err != nil {
+		return "", fmt.Errorf("failed to get central IPs: %v", err)
+	}
+
+	if len(nbIPs) == 0 {
+		return "", errors.New("no OVN central hosts found")
+	}
+
+	// Same as in WaitForClusterDBState, hosts are tried one by one to work around a bug in the ovsdb-client
+	for _, ip := range nbIPs {
+		socketURL := fmt.Sprintf("ssl:%s:%d", ip, port)
+		var output string
+		output, err = shared.RunCommandContext(
+			ctx,
+			filepath.Join(paths.Wrappers(), "ovsdb-client"),
+			"-t", "30",
+			"transact",
+			socketURL,
+			transaction,
+		)
+		if err == nil {
+			return output, nil
+		}
+		logger.Warnf("Failed to execute transaction on %s database at %s: %v", db, socketURL, err)
+	}
+	return "", fmt.Errorf("failed to execute transaction on %s database cluster: %w", db, err)
+}
+
 // SBCtl is a convenience function for execution of ovn-sbctl command against
 // OVN NB local unix socket. The command is re-tried up to 3 times.
 // If command arguments do not specify timeout (-t or
@@ -319,6 +378,25 @@ func ControllerCtl(ctx context.Context, _ state.State, args ...string) (string,
 	return stdout, err
 }
 
+// SwitchCtl is a wrapper function that executes 'ovs-appctl' command specifically
+// targeted at running Open vSwitch daemon (ovs-vswitchd). The '-t' argument of 'ovs-appctl'
+// will be configured automatically. Any arguments supplied in 'args' will be passed to the
+// 'ovs-appctl' unchanged.
+func SwitchCtl(ctx context.Context, _ state.State, args ...string) (string, error) {
+	arguments := []string{"-t", "ovs-vswitchd"}
+	arguments = append(arguments, args...)
+
+	stdout, _, err := shared.RunCommandSplit(
+		ctx,
+		append(os.Environ(), fmt.Sprintf("OVS_RUNDIR=%s", paths.SwitchRuntimeDir())),
+		nil,
+		"ovs-appctl",
+		arguments...,
+	)
+
+	return stdout, err
+}
+
 // OvsdbClient is a wrapper function that executes 'ovsdb-client' command. It first ensures that the database
 // is connected and returns error if the database is not connected within <connectTimeout> seconds. Then it runs
 // "ovsdb-client" command with timeout of <resultTimeout> seconds.
@@ -0,0 +1,343 @@
+// Package copp manages Control Plane Protection (CoPP) profile that MicroOVN applies to OVN
+// Logical Routers.
+package copp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/canonical/microcluster/v2/state"
+
+	"github.com/canonical/microovn/microovn/config"
+	ovnCmd "github.com/canonical/microovn/microovn/ovn/cmd"
+)
+
+// RatesConfigKey - cluster config option that overrides default rate limits (in packets per second)
+// of the MicroOVN CoPP profile. Value is a comma-separated list of "<protocol>=<rate>" pairs.
+const RatesConfigKey = "ovn.copp.rates"
+
+// AllRoutersConfigKey - cluster config option that attaches MicroOVN CoPP profile to every Logical
+// Router in the OVN Northbound database, not just to the routers created by MicroOVN.
+const AllRoutersConfigKey = "ovn.copp.all-routers"
+
+// ProfileName - name of the Copp record in the OVN Northbound database managed by MicroOVN.
+const ProfileName = "microovn"
+
+// autoAttachedTag - a key used in "external_ids" column of Logical Routers to which the CoPP profile was
+// attached only because the "ovn.copp.all-routers" option is enabled.
+const autoAttachedTag = "microovn-copp-auto"
+
+// DefaultRates holds default rate limits (in packets per second) for control plane protocols that are
+// punted to ovn-controller.
+var DefaultRates = map[string]int{
+	"arp":           100,
+	"arp-resolve":   100,
+	"nd-na":         100,
+	"nd-ns":         100,
+	"nd-ns-resolve": 100,
+	"nd-ra-opts":    100,
+	"icmp4-error":   100,
+	"icmp6-error":   100,
+	"tcp-reset":     100,
+	"reject":        100,
+	"dhcpv4-opts":   100,
+	"dhcpv6-opts":   100,
+	"dns":           100,
+	"igmp":          100,
+	"event-elb":     100,
+	"svc-monitor":   100,
+	"bfd":           500,
+}
+
+// getMeterName returns name of the OVN Meter that limits "protocol" traffic in the MicroOVN CoPP profile.
+func getMeterName(protocol string) string {
+	return fmt.Sprintf("microovn-copp-%s", protocol)
+}
+
+// ParseRates parses value of the "ovn.copp.rates" config option. It returns map of protocol names and their
+// rate limits. Rate "0" disables the limit for the protocol.
+func ParseRates(value string) (map[string]int, error) {
+	rates := make(map[string]int)
+	if value == "" {
+		return rates, nil
+	}
+
+	for _, item := range strings.Split(value, ",") {
+		protocol, rawRate, found := strings.Cut(strings.TrimSpace(item), "=")
+		if !found {
+			return nil, fmt.Errorf("value '%s' does not conform to the '<protocol>=<rate>' format", item)
+		}
+
+		if _, ok := DefaultRates[protocol]; !ok {
+			return nil, fmt.Errorf("unknown protocol '%s'. Supported protocols are: %s", protocol, strings.Join(protocols(), ", "))
+		}
+
+		if _, ok := rates[protocol]; ok {
+			return nil, fmt.Errorf("protocol '%s' is specified multiple times", protocol)
+		}
+
+		rate, err := strconv.Atoi(rawRate)
+		if err != nil || rate < 0 {
+			return nil, fmt.Errorf("rate '%s' for protocol '%s' is not a non-negative number", rawRate, protocol)
+		}
+
+		rates[protocol] = rate
+	}
+
+	return rates, nil
+}
+
+// effectiveRates returns rate limits that should be used in the CoPP profile, combining default rates with
+// the "overrides". Protocols with rate "0" are omitted.
+func effectiveRates(overrides map[string]int) map[string]int {
+	rates := make(map[string]int, len(DefaultRates))
+	for protocol, rate := range DefaultRates {
+		if override, ok := overrides[protocol]; ok {
+			rate = override
+		}
+
+		if rate > 0 {
+			rates[protocol] = rate
+		}
+	}
+
+	return rates
+}
+
+// protocols returns sorted list of protocols supported by the MicroOVN CoPP profile.
+func protocols() []string {
+	result := make([]string, 0, len(DefaultRates))
+	for protocol := range DefaultRates {
+		result = append(result, protocol)
+	}
+	slices.Sort(result)
+	return result
+}
+
+// configuredRates returns rate limits of the CoPP profile based on the "ovn.copp.rates" config option.
+func configuredRates(ctx context.Context, s state.State) (map[string]int, error) {
+	item, err := config.GetConfig(ctx, s, RatesConfigKey)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides := map[string]int{}
+	if item != nil {
+		overrides, err = ParseRates(item.Value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return effectiveRates(overrides), nil
+}
+
+// allRoutersEnabled returns true if the "ovn.copp.all-routers" config option is enabled.
+func allRoutersEnabled(ctx context.Context, s state.State) (bool, error) {
+	item, err := config.GetConfig(ctx, s, AllRoutersConfigKey)
+	if err != nil {
+		return false, err
+	}
+
+	return item != nil && item.Value == "true", nil
+}
+
+// nbctlFind runs "find" command of the ovn-nbctl and returns list of non-empty values in the
+// requested "column" of records that match the "conditions".
+func nbctlFind(ctx context.Context, s state.State, table string, column string, conditions ...string) ([]string, error) {
+	args := []string{"--bare", "--columns", column, "find", table}
+	args = append(args, conditions...)
+	out, err := ovnCmd.NBCtlCluster(ctx, s, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var values []string
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			values = append(values, line)
+		}
+	}
+	return values, nil
+}
+
+// ensureMeter creates OVN Meter for the "protocol" with the rate limit "rate", or updates rate limit of
+// the existing meter.
+func ensureMeter(ctx context.Context, s state.State, protocol string, rate int) error {
+	meterName := getMeterName(protocol)
+	bands, err := nbctlFind(ctx, s, "Meter", "bands", fmt.Sprintf("name=%s", meterName))
+	if err != nil {
+		return fmt.Errorf("failed to look up meter '%s': %w", meterName, err)
+	}
+
+	if len(bands) == 0 {
+		_, err = ovnCmd.NBCtlCluster(ctx, s, "meter-add", meterName, "drop", strconv.Itoa(rate), "pktps")
+		if err == nil {
+			return nil
+		}
+
+		// Meter names are unique, so the creation fails if another member created the same meter in the
+		// meantime. In that case, only its rate limit is updated.
+		bands, lookupErr := nbctlFind(ctx, s, "Meter", "bands", fmt.Sprintf("name=%s", meterName))
+		if lookupErr != nil || len(bands) == 0 {
+			return fmt.Errorf("failed to create meter '%s': %w", meterName, err)
+		}
+		return updateMeterBands(ctx, s, meterName, bands[0], rate)
+	}
+
+	return updateMeterBands(ctx, s, meterName, bands[0], rate)
+}
+
+// updateMeterBands sets rate limit of every band in the "bands" of the meter "meterName" to "rate".
+func updateMeterBands(ctx context.Context, s state.State, meterName string, bands string, rate int) error {
+	for _, band := range strings.Fields(bands) {
+		_, err := ovnCmd.NBCtlCluster(ctx, s, "set", "Meter_Band", band, fmt.Sprintf("rate=%d", rate))
+		if err != nil {
+			return fmt.Errorf("failed to update meter '%s': %w", meterName, err)
+		}
+	}
+	return nil
+}
+
+// profileProtocols returns list of protocols that are currently rate limited by the MicroOVN CoPP profile.
+func profileProtocols(ctx context.Context, s state.State) ([]string, error) {
+	meters, err := nbctlFind(ctx, s, "Copp", "meters", fmt.Sprintf("name=%s", ProfileName))
+	if err != nil || len(meters) == 0 {
+		return nil, err
+	}
+
+	return parseCoppMeters(meters[0]), nil
+}
+
+// parseCoppMeters parses "meters" column of the Copp record, printed with "--bare" formatting, and returns
+// list of protocols present in it.
+func parseCoppMeters(value string) []string {
+	var result []string
+	for _, item := range strings.Fields(value) {
+		protocol, _, found := strings.Cut(item, "=")
+		if found {
+			result = append(result, protocol)
+		}
+	}
+	return result
+}
+
+// ensureProfile creates or updates the MicroOVN CoPP profile and meters associated with it, so that they
+// match the current configuration.
+func ensureProfile(ctx context.Context, s state.State) error {
+	rates, err := configuredRates(ctx, s)
+	if err != nil {
+		return fmt.Errorf("failed to get CoPP rates: %w", err)
+	}
+
+	currentProtocols, err := profileProtocols(ctx, s)
+	if err != nil {
+		return fmt.Errorf("failed to look up CoPP profile '%s': %w", ProfileName, err)
+	}
+
+	var errs []error
+	for _, protocol := range protocols() {
+		rate, enabled := rates[protocol]
+		if !enabled {
+			if slices.Contains(currentProtocols, protocol) {
+				_, err = ovnCmd.NBCtlCluster(ctx, s, "copp-del", ProfileName, protocol)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("failed to remove protocol '%s' from CoPP profile: %w", protocol, err))
+				}
+			}
+			continue
+		}
+
+		err = ensureMeter(ctx, s, protocol, rate)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		_, err = ovnCmd.NBCtlCluster(ctx, s, "copp-add", ProfileName, protocol, getMeterName(protocol))
+		if err != nil {
+			// The profile is created by the first "copp-add" and its name is unique, so the command fails
+			// if another member created the profile in the meantime. Retrying updates the existing profile.
+			_, err = ovnCmd.NBCtlCluster(ctx, s, "copp-add", ProfileName, protocol, getMeterName(protocol))
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to add protocol '%s' to CoPP profile: %w", protocol, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// AttachToRouter ensures that the MicroOVN CoPP profile exists and attaches it to the Logical Router
+// "lrName".
+func AttachToRouter(ctx context.Context, s state.State, lrName string) error {
+	err := ensureProfile(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	_, err = ovnCmd.NBCtlCluster(ctx, s, "lr-copp-add", ProfileName, lrName)
+	if err != nil {
+		return fmt.Errorf("failed to attach CoPP profile to Logical Router '%s': %w", lrName, err)
+	}
+	return nil
+}
+
+// Apply brings the MicroOVN CoPP profile in line with the current configuration. If the "ovn.copp.all-routers"
+// option is enabled, the profile is attached to every Logical Router that doesn't have other CoPP profile.
+// If the option is disabled, the profile is detached from routers to which it was attached automatically.
+func Apply(ctx context.Context, s state.State) error {
+	err := ensureProfile(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	allRouters, err := allRoutersEnabled(ctx, s)
+	if err != nil {
+		return fmt.Errorf("failed to get '%s' config: %w", AllRoutersConfigKey, err)
+	}
+
+	var errs []error
+	if allRouters {
+		routers, err := nbctlFind(ctx, s, "Logical_Router", "_uuid", "copp=[]")
+		if err != nil {
+			return fmt.Errorf("failed to look up Logical Routers: %w", err)
+		}
+
+		for _, router := range routers {
+			_, err = ovnCmd.NBCtlCluster(ctx, s,
+				"--",
+				"lr-copp-add", ProfileName, router,
+				"--",
+				"set", "Logical_Router", router, fmt.Sprintf("external-ids:%s=true", autoAttachedTag),
+			)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to attach CoPP profile to Logical Router '%s': %w", router, err))
+			}
+		}
+	} else {
+		routers, err := nbctlFind(ctx, s, "Logical_Router", "_uuid", fmt.Sprintf("external-ids:%s=true", autoAttachedTag))
+		if err != nil {
+			return fmt.Errorf("failed to look up Logical Routers: %w", err)
+		}
+
+		for _, router := range routers {
+			_, err = ovnCmd.NBCtlCluster(ctx, s,
+				"--",
+				"lr-copp-del", router,
+				"--",
+				"remove", "Logical_Router", router, "external-ids", autoAttachedTag,
+			)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to detach CoPP profile from Logical Router '%s': %w", router, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
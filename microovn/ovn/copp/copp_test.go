@@ -0,0 +1,54 @@
+package copp
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestParseRates(t *testing.T) {
+	rates, err := ParseRates("arp=200, bfd=1000,igmp=0")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := map[string]int{"arp": 200, "bfd": 1000, "igmp": 0}
+	if !maps.Equal(rates, expected) {
+		t.Errorf("Expected rates %v, got %v", expected, rates)
+	}
+
+	rates, err = ParseRates("")
+	if err != nil || len(rates) != 0 {
+		t.Errorf("Expected no rates for empty value, got %v (%v)", rates, err)
+	}
+
+	invalidValues := []string{"arp", "foo=100", "arp=-1", "arp=fast", "arp=100,arp=200"}
+	for _, value := range invalidValues {
+		_, err = ParseRates(value)
+		if err == nil {
+			t.Errorf("Expected value '%s' to be rejected", value)
+		}
+	}
+}
+
+func TestUnexported_effectiveRates(t *testing.T) {
+	rates := effectiveRates(map[string]int{"arp": 200, "igmp": 0})
+	if rates["arp"] != 200 {
+		t.Errorf("Expected overridden 'arp' rate 200, got %d", rates["arp"])
+	}
+
+	if _, ok := rates["igmp"]; ok {
+		t.Errorf("Expected 'igmp' to be omitted from rates")
+	}
+
+	if rates["bfd"] != DefaultRates["bfd"] {
+		t.Errorf("Expected default 'bfd' rate %d, got %d", DefaultRates["bfd"], rates["bfd"])
+	}
+}
+
+func TestUnexported_parseCoppMeters(t *testing.T) {
+	protocols := parseCoppMeters("arp=microovn-copp-arp bfd=microovn-copp-bfd")
+	if !slices.Equal(protocols, []string{"arp", "bfd"}) {
+		t.Errorf("Unexpected protocols: %v", protocols)
+	}
+}
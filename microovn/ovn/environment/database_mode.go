@@ -0,0 +1,73 @@
+package environment
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/canonical/microcluster/v2/state"
+
+	"github.com/canonical/microovn/microovn/config"
+)
+
+// DatabaseMode is a mode in which OVN Northbound and Southbound databases are running.
+type DatabaseMode = string
+
+const (
+	// DatabaseModeClustered  - databases run as RAFT clusters (default).
+	DatabaseModeClustered DatabaseMode = "clustered"
+	// DatabaseModeStandalone - databases run in standalone mode on a single central member.
+	DatabaseModeStandalone DatabaseMode = "standalone"
+)
+
+// DatabaseModes is a list of all valid database modes.
+var DatabaseModes = []DatabaseMode{DatabaseModeClustered, DatabaseModeStandalone}
+
+// DatabaseModeConfigKey - cluster config item that holds current mode of OVN central databases.
+// It's set during the bootstrap and MicroOVN changes it automatically when standalone databases
+// are converted to clustered ones.
+const DatabaseModeConfigKey = "ovn.database-mode"
+
+// DatabaseModeInitKey - name of the bootstrap option that selects initial mode of OVN central databases.
+const DatabaseModeInitKey = "ovn-database-mode"
+
+// ValidateDatabaseMode returns error if "mode" is not a valid database mode.
+func ValidateDatabaseMode(mode string) error {
+	if !slices.Contains(DatabaseModes, mode) {
+		return fmt.Errorf("unknown database mode '%s'. Supported values are: %s", mode, strings.Join(DatabaseModes, ", "))
+	}
+	return nil
+}
+
+// GetDatabaseMode returns current mode of OVN central databases. Databases are considered clustered
+// unless they were explicitly bootstrapped in the standalone mode.
+func GetDatabaseMode(ctx context.Context, s state.State) (DatabaseMode, error) {
+	mode, err := config.GetConfig(ctx, s, DatabaseModeConfigKey)
+	if err != nil {
+		return "", err
+	}
+
+	if mode == nil {
+		return DatabaseModeClustered, nil
+	}
+	return mode.Value, nil
+}
+
+// IsStandaloneDatabase returns true if OVN central databases are running in the standalone mode.
+func IsStandaloneDatabase(ctx context.Context, s state.State) (bool, error) {
+	mode, err := GetDatabaseMode(ctx, s)
+	return mode == DatabaseModeStandalone, err
+}
+
+// LocalRaftAddress returns address that the local member uses for RAFT communication between
+// OVN central databases.
+func LocalRaftAddress(ctx context.Context, s state.State) (string, error) {
+	centralIps, err := CentralIps(ctx, s)
+	if err != nil {
+		return "", err
+	}
+
+	_, localAddr, err := raftAddressConfig(ctx, s, centralIps)
+	return localAddr, err
+}
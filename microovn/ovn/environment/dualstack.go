@@ -0,0 +1,285 @@
+package environment
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/netip"
+
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v2/cluster"
+	"github.com/canonical/microcluster/v2/state"
+
+	"github.com/canonical/microovn/microovn/config"
+	"github.com/canonical/microovn/microovn/database"
+)
+
+// AddressFamily is a name of the IP address family ("ipv4" or "ipv6").
+type AddressFamily = string
+
+const (
+	// FamilyIPv4 - IPv4 address family
+	FamilyIPv4 AddressFamily = "ipv4"
+	// FamilyIPv6 - IPv6 address family
+	FamilyIPv6 AddressFamily = "ipv6"
+)
+
+// AddressFamilies is a list of all valid values of config options that select address family.
+var AddressFamilies = []AddressFamily{FamilyIPv4, FamilyIPv6}
+
+// DualStackConfigKey - cluster config option that enables dual-stack OVN control plane connections.
+const DualStackConfigKey = "ovn.dual-stack"
+
+// SecondaryAddressConfigKey - member config option that holds address of the member from the address
+// family that is different from the family of its MicroOVN cluster address.
+const SecondaryAddressConfigKey = "ovn.secondary-address"
+
+// PreferredFamilyConfigKey - member config option that selects address family which is preferred
+// by the member when it connects to the OVN central services.
+const PreferredFamilyConfigKey = "ovn.preferred-family"
+
+// RaftFamilyConfigKey - cluster config option that selects address family used by the OVN Northbound
+// and Southbound database clusters for RAFT communication.
+const RaftFamilyConfigKey = "ovn.raft-family"
+
+// CentralEndpoint holds addresses, in each address family, on which a single OVN central node is reachable.
+type CentralEndpoint struct {
+	Member    string                   // Name of the MicroOVN cluster member
+	Addresses map[AddressFamily]string // Addresses of the member keyed by their address family
+}
+
+// Family returns address family of the "addr". Empty string is returned if "addr" is not a valid IP address.
+func Family(addr string) AddressFamily {
+	parsedAddr, err := netip.ParseAddr(addr)
+	if err != nil {
+		return ""
+	}
+
+	if parsedAddr.Unmap().Is4() {
+		return FamilyIPv4
+	}
+	return FamilyIPv6
+}
+
+// IsDualStackEnabled returns true if the "ovn.dual-stack" config option is enabled.
+func IsDualStackEnabled(ctx context.Context, s state.State) (bool, error) {
+	dualStack, err := config.GetConfig(ctx, s, DualStackConfigKey)
+	if err != nil {
+		return false, err
+	}
+	return dualStack != nil && dualStack.Value == "true", nil
+}
+
+// CentralEndpoints returns addresses of every MicroOVN cluster member with "central" service enabled.
+// Each member is reachable on its MicroOVN cluster address and, optionally, on the address configured
+// in its "ovn.secondary-address" config option.
+func CentralEndpoints(ctx context.Context, s state.State) ([]CentralEndpoint, error) {
+	var endpoints []CentralEndpoint
+	err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		serviceName := "central"
+		servers, err := database.GetServices(ctx, tx, database.ServiceFilter{Service: &serviceName})
+		if err != nil {
+			return err
+		}
+
+		clusterMembers, err := cluster.GetCoreClusterMembers(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		for _, clusterMember := range clusterMembers {
+			for _, server := range servers {
+				if server.Member != clusterMember.Name {
+					continue
+				}
+
+				parsedAddr, err := netip.ParseAddrPort(clusterMember.Address)
+				if err != nil {
+					return err
+				}
+
+				primaryAddr := parsedAddr.Addr().String()
+				endpoint := CentralEndpoint{
+					Member:    clusterMember.Name,
+					Addresses: map[AddressFamily]string{Family(primaryAddr): primaryAddr},
+				}
+
+				secondaryExists, err := database.MemberConfigItemExists(ctx, tx, clusterMember.Name, SecondaryAddressConfigKey)
+				if err != nil {
+					return err
+				}
+
+				if secondaryExists {
+					secondary, err := database.GetMemberConfigItem(ctx, tx, clusterMember.Name, SecondaryAddressConfigKey)
+					if err != nil {
+						return err
+					}
+
+					secondaryFamily := Family(secondary.Value)
+					if _, exists := endpoint.Addresses[secondaryFamily]; !exists && secondaryFamily != "" {
+						endpoint.Addresses[secondaryFamily] = secondary.Value
+					}
+				}
+
+				endpoints = append(endpoints, endpoint)
+				break
+			}
+		}
+
+		return nil
+	})
+	return endpoints, err
+}
+
+// ValidateDualStack verifies that every OVN central node is reachable on addresses from both
+// address families.
+func ValidateDualStack(ctx context.Context, s state.State) error {
+	endpoints, err := CentralEndpoints(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	return validateDualStackEndpoints(endpoints)
+}
+
+// validateDualStackEndpoints returns an error if any of the "endpoints" does not have an address from
+// both address families.
+func validateDualStackEndpoints(endpoints []CentralEndpoint) error {
+	for _, endpoint := range endpoints {
+		for _, family := range AddressFamilies {
+			if _, ok := endpoint.Addresses[family]; !ok {
+				return fmt.Errorf(
+					"central member '%s' does not have %s address. Set it with 'microovn config set --node %s %s <ADDRESS>'",
+					endpoint.Member, family, endpoint.Member, SecondaryAddressConfigKey,
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+// PreferredFamily returns address family that the local member prefers when connecting to OVN
+// central services. It's either set explicitly by the "ovn.preferred-family" config option, or it
+// defaults to the family of the local MicroOVN cluster address.
+func PreferredFamily(ctx context.Context, s state.State) (AddressFamily, error) {
+	preferred, err := config.GetMemberConfig(ctx, s, s.Name(), PreferredFamilyConfigKey)
+	if err != nil {
+		return "", err
+	}
+
+	if preferred != nil {
+		return preferred.Value, nil
+	}
+	return Family(s.Address().Hostname()), nil
+}
+
+// RaftFamily returns address family used by the OVN central databases for RAFT communication. It's
+// either set explicitly by the "ovn.raft-family" config option, or it defaults to the family of the
+// local MicroOVN cluster address.
+func RaftFamily(ctx context.Context, s state.State) (AddressFamily, error) {
+	raftFamily, err := config.GetConfig(ctx, s, RaftFamilyConfigKey)
+	if err != nil {
+		return "", err
+	}
+
+	if raftFamily != nil {
+		return raftFamily.Value, nil
+	}
+	return Family(s.Address().Hostname()), nil
+}
+
+// endpointByAddr returns a lookup table of CentralEndpoints keyed by each of their addresses.
+func endpointByAddr(endpoints []CentralEndpoint) map[string]CentralEndpoint {
+	lookup := make(map[string]CentralEndpoint)
+	for _, endpoint := range endpoints {
+		for _, addr := range endpoint.Addresses {
+			lookup[addr] = endpoint
+		}
+	}
+	return lookup
+}
+
+// dualStackAddresses expands list of central addresses to include addresses from both address families.
+// Addresses from the "preferred" family are listed first, followed by addresses from the other family.
+// Order of the central nodes in the "addrList" is preserved within each group. Addresses that do not belong
+// to any of the "endpoints" (e.g. external OVN central) are kept in the preferred group unchanged.
+func dualStackAddresses(addrList []string, endpoints []CentralEndpoint, preferred AddressFamily) []string {
+	lookup := endpointByAddr(endpoints)
+	var preferredAddrs, otherAddrs []string
+
+	for _, addr := range addrList {
+		endpoint, ok := lookup[addr]
+		if !ok {
+			preferredAddrs = append(preferredAddrs, addr)
+			continue
+		}
+
+		for _, family := range AddressFamilies {
+			endpointAddr, ok := endpoint.Addresses[family]
+			if !ok {
+				continue
+			}
+
+			if family == preferred {
+				preferredAddrs = append(preferredAddrs, endpointAddr)
+			} else {
+				otherAddrs = append(otherAddrs, endpointAddr)
+			}
+		}
+	}
+
+	return append(preferredAddrs, otherAddrs...)
+}
+
+// raftAddresses translates list of central addresses to their counterparts in the "family" address family.
+// Addresses that do not have counterpart in the requested family are kept unchanged.
+func raftAddresses(addrList []string, endpoints []CentralEndpoint, family AddressFamily) []string {
+	lookup := endpointByAddr(endpoints)
+	result := make([]string, 0, len(addrList))
+
+	for _, addr := range addrList {
+		raftAddr := addr
+		if endpoint, ok := lookup[addr]; ok {
+			if familyAddr, ok := endpoint.Addresses[family]; ok {
+				raftAddr = familyAddr
+			} else {
+				logger.Warnf("Central member '%s' has no %s address, using '%s' for RAFT", endpoint.Member, family, addr)
+			}
+		}
+		result = append(result, raftAddr)
+	}
+
+	return result
+}
+
+// ConnectAddresses returns list of central addresses that should be used by the local member to connect
+// to OVN central services. If dual-stack is not enabled, the "addrList" is returned unchanged. Otherwise,
+// it's expanded to include addresses from both address families, with addresses from the locally
+// preferred family listed first. A warning is logged if any central member lacks address from either
+// address family.
+func ConnectAddresses(ctx context.Context, s state.State, addrList []string) ([]string, error) {
+	dualStack, err := IsDualStackEnabled(ctx, s)
+	if err != nil || !dualStack {
+		return addrList, err
+	}
+
+	endpoints, err := CentralEndpoints(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+
+	// Dual-stack config is validated only when it's enabled. Central members or their secondary
+	// addresses may change afterward, so the validation is repeated every time the addresses are used.
+	err = validateDualStackEndpoints(endpoints)
+	if err != nil {
+		logger.Warnf("Dual-stack OVN control plane is not fully configured: %v", err)
+	}
+
+	preferred, err := PreferredFamily(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+
+	return dualStackAddresses(addrList, endpoints, preferred), nil
+}
@@ -30,6 +30,7 @@ OVN_INITIAL_SB="{{ .sbInitial }}"
 OVN_NB_CONNECT="{{ .nbConnect }}"
 OVN_SB_CONNECT="{{ .sbConnect }}"
 OVN_LOCAL_IP="{{ .localAddr }}"
+OVN_DB_MODE="{{ .dbMode }}"
 `))
 
 // NetworkProtocol returns appropriate network protocol that should be used
@@ -129,8 +130,13 @@ func CentralIps(ctx context.Context, s state.State) ([]string, error) {
 // initialNbSbHost returns an IP address or a hostname that should be used by
 // a Northbound and Southbound database to connect to the rest of the cluster.
 func initialNbSbHost(s state.State, addrList []string) (string, error) {
+	return initialHost(s.Address().Hostname(), addrList)
+}
+
+// initialHost returns an IP address or a hostname from the "addrList" that should be used by
+// a Northbound and Southbound database, running on "localAddr", to connect to the rest of the cluster.
+func initialHost(localAddr string, addrList []string) (string, error) {
 	var initialNode string
-	localAddr := s.Address().Hostname()
 
 	// With only a single central node enabled, there are no other cluster members to connect to.
 	// Setting the "initial node" to the address of the only node with the central enabled will
@@ -184,21 +190,36 @@ func GenerateEnvironment(ctx context.Context, s state.State) error {
 		return fmt.Errorf("failed to get OVN central IPs: %w", err)
 	}
 
-	nbConnect, err := ConnectionString(ctx, s, centralIps, 6641)
+	connectIps, err := ConnectAddresses(ctx, s, centralIps)
+	if err != nil {
+		return fmt.Errorf("failed to get OVN central connection addresses: %w", err)
+	}
+
+	nbConnect, err := ConnectionString(ctx, s, connectIps, 6641)
 	if err != nil {
 		return err
 	}
 
-	sbConnect, err := ConnectionString(ctx, s, centralIps, 6642)
+	sbConnect, err := ConnectionString(ctx, s, connectIps, 6642)
 	if err != nil {
 		return err
 	}
 
-	initialNbSb, err := initialNbSbHost(s, centralIps)
+	raftIps, localAddr, err := raftAddressConfig(ctx, s, centralIps)
+	if err != nil {
+		return fmt.Errorf("failed to get OVN central RAFT addresses: %w", err)
+	}
+
+	initialNbSb, err := initialHost(localAddr, raftIps)
 	if err != nil {
 		return err
 	}
 
+	dbMode, err := GetDatabaseMode(ctx, s)
+	if err != nil {
+		return fmt.Errorf("failed to get OVN database mode: %w", err)
+	}
+
 	// Generate ovn.env.
 	fd, err := os.OpenFile(paths.OvnEnvFile(), os.O_CREATE|os.O_TRUNC|os.O_RDWR, 0644)
 	if err != nil {
@@ -206,7 +227,6 @@ func GenerateEnvironment(ctx context.Context, s state.State) error {
 	}
 	defer fd.Close()
 
-	localAddr := s.Address().Hostname()
 	if ip, err := netip.ParseAddr(localAddr); err == nil && ip.Is6() {
 		localAddr = "[" + localAddr + "]"
 	}
@@ -217,6 +237,7 @@ func GenerateEnvironment(ctx context.Context, s state.State) error {
 		"sbInitial": initialNbSb,
 		"nbConnect": nbConnect,
 		"sbConnect": sbConnect,
+		"dbMode":    dbMode,
 	})
 	if err != nil {
 		return fmt.Errorf("couldn't render ovn.env: %w", err)
@@ -225,6 +246,42 @@ func GenerateEnvironment(ctx context.Context, s state.State) error {
 	return nil
 }
 
+// raftAddressConfig returns list of central addresses and the local address that should be used by the
+// OVN Northbound and Southbound databases for RAFT communication. Unless dual-stack is enabled, these
+// are unchanged "addrList" and MicroOVN cluster address of the local member. With dual-stack enabled,
+// addresses from the family selected by "ovn.raft-family" config option are used.
+func raftAddressConfig(ctx context.Context, s state.State, addrList []string) ([]string, string, error) {
+	localAddr := s.Address().Hostname()
+
+	dualStack, err := IsDualStackEnabled(ctx, s)
+	if err != nil || !dualStack {
+		return addrList, localAddr, err
+	}
+
+	raftFamily, err := RaftFamily(ctx, s)
+	if err != nil {
+		return nil, "", err
+	}
+
+	endpoints, err := CentralEndpoints(ctx, s)
+	if err != nil {
+		return nil, "", err
+	}
+
+	for _, endpoint := range endpoints {
+		if endpoint.Member != s.Name() {
+			continue
+		}
+
+		if familyAddr, ok := endpoint.Addresses[raftFamily]; ok {
+			localAddr = familyAddr
+		}
+		break
+	}
+
+	return raftAddresses(addrList, endpoints, raftFamily), localAddr, nil
+}
+
 // CreatePaths creates the required directories for OVN.
 func CreatePaths() error {
 	// Create our various paths.
@@ -2,6 +2,7 @@ package environment
 
 import (
 	"net/url"
+	"slices"
 	"testing"
 
 	"github.com/canonical/lxd/shared/api"
@@ -80,3 +81,79 @@ func TestUnexported_initialNbSbHost(t *testing.T) {
 		}
 	}
 }
+
+func TestFamily(t *testing.T) {
+	testCases := map[string]AddressFamily{
+		localNodeIPv4:     FamilyIPv4,
+		localNodeIPv6:     FamilyIPv6,
+		"::ffff:10.0.0.1": FamilyIPv4,
+		"not-an-ip":       "",
+	}
+
+	for addr, expected := range testCases {
+		if family := Family(addr); family != expected {
+			t.Errorf("Expected address '%s' to be from family '%s', got '%s'", addr, expected, family)
+		}
+	}
+}
+
+func TestUnexported_dualStackAddresses(t *testing.T) {
+	endpoints := []CentralEndpoint{
+		{Member: "micro1", Addresses: map[AddressFamily]string{FamilyIPv4: localNodeIPv4, FamilyIPv6: localNodeIPv6}},
+		{Member: "micro2", Addresses: map[AddressFamily]string{FamilyIPv4: remoteNodeIPv4, FamilyIPv6: remoteNodeIPv6}},
+	}
+	centralIps := []string{remoteNodeIPv4, localNodeIPv4}
+
+	// Preferred family is listed first, order of central nodes is preserved
+	result := dualStackAddresses(centralIps, endpoints, FamilyIPv6)
+	expected := []string{remoteNodeIPv6, localNodeIPv6, remoteNodeIPv4, localNodeIPv4}
+	if !slices.Equal(result, expected) {
+		t.Errorf("Expected addresses %v, got %v", expected, result)
+	}
+
+	result = dualStackAddresses(centralIps, endpoints, FamilyIPv4)
+	expected = []string{remoteNodeIPv4, localNodeIPv4, remoteNodeIPv6, localNodeIPv6}
+	if !slices.Equal(result, expected) {
+		t.Errorf("Expected addresses %v, got %v", expected, result)
+	}
+
+	// Unknown addresses are kept unchanged
+	result = dualStackAddresses([]string{"10.0.0.100"}, endpoints, FamilyIPv6)
+	if !slices.Equal(result, []string{"10.0.0.100"}) {
+		t.Errorf("Expected unknown address to be kept, got %v", result)
+	}
+}
+
+func TestUnexported_validateDualStackEndpoints(t *testing.T) {
+	endpoints := []CentralEndpoint{
+		{Member: "micro1", Addresses: map[AddressFamily]string{FamilyIPv4: localNodeIPv4, FamilyIPv6: localNodeIPv6}},
+		{Member: "micro2", Addresses: map[AddressFamily]string{FamilyIPv4: remoteNodeIPv4, FamilyIPv6: remoteNodeIPv6}},
+	}
+	err := validateDualStackEndpoints(endpoints)
+	if err != nil {
+		t.Errorf("Expected endpoints with both address families to be valid, got: %s", err)
+	}
+
+	// Central member without secondary address
+	endpoints = append(endpoints, CentralEndpoint{
+		Member: "micro3", Addresses: map[AddressFamily]string{FamilyIPv4: "10.0.0.100"},
+	})
+	err = validateDualStackEndpoints(endpoints)
+	if err == nil {
+		t.Errorf("Expected endpoint without IPv6 address to be rejected")
+	}
+}
+
+func TestUnexported_raftAddresses(t *testing.T) {
+	endpoints := []CentralEndpoint{
+		{Member: "micro1", Addresses: map[AddressFamily]string{FamilyIPv4: localNodeIPv4, FamilyIPv6: localNodeIPv6}},
+		{Member: "micro2", Addresses: map[AddressFamily]string{FamilyIPv4: remoteNodeIPv4}},
+	}
+
+	// Addresses without counterpart in the requested family are kept unchanged
+	result := raftAddresses([]string{localNodeIPv4, remoteNodeIPv4}, endpoints, FamilyIPv6)
+	expected := []string{localNodeIPv6, remoteNodeIPv4}
+	if !slices.Equal(result, expected) {
+		t.Errorf("Expected addresses %v, got %v", expected, result)
+	}
+}
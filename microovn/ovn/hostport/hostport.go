@@ -0,0 +1,434 @@
+// Package hostport manages host management ports. A host management port is an internal interface on the
+// OVN integration bridge, bound to a Logical Switch Port, that gives the host an IP address on an OVN logical
+// network.
+package hostport
+
+import (
+	"context"
+	"crypto/md5"
+	"database/sql"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"net/netip"
+	"regexp"
+	"strings"
+
+	"github.com/canonical/lxd/shared"
+	"github.com/canonical/lxd/shared/api"
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v2/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/database"
+	"github.com/canonical/microovn/microovn/node"
+	ovnCmd "github.com/canonical/microovn/microovn/ovn/cmd"
+)
+
+// ManagedTag - a key used in "external_ids" column of OVN and OVS tables to mark records that belong to
+// host management ports. Its value is the name of the cluster member that owns the port.
+const ManagedTag = "microovn-host-port"
+
+// ifaceNameMaxLen - maximum length of the Linux interface name (IFNAMSIZ without the trailing null byte).
+const ifaceNameMaxLen = 15
+
+// ifaceNameChars - characters of the Logical Switch name that can be used in the interface name as they are.
+var ifaceNameChars = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// ErrNotFound is returned when the host management port does not exist.
+var ErrNotFound = errors.New("host port not found")
+
+// ErrExists is returned when the host management port for the Logical Switch already exists on the member.
+var ErrExists = errors.New("host port already exists")
+
+// ErrSwitchNotFound is returned when the Logical Switch on which the host management port should be created
+// does not exist.
+var ErrSwitchNotFound = errors.New("logical switch not found")
+
+// ParseAddresses parses IP addresses in CIDR notation that should be configured on the host management port.
+func ParseAddresses(addresses []string) ([]netip.Prefix, error) {
+	prefixes := []netip.Prefix{}
+	seen := make(map[netip.Addr]bool)
+	for _, address := range addresses {
+		prefix, err := netip.ParsePrefix(strings.TrimSpace(address))
+		if err != nil {
+			return nil, fmt.Errorf("address '%s' is not an IP address in CIDR notation", address)
+		}
+
+		if seen[prefix.Addr()] {
+			return nil, fmt.Errorf("address '%s' is specified multiple times", prefix.Addr())
+		}
+		seen[prefix.Addr()] = true
+		prefixes = append(prefixes, prefix)
+	}
+	return prefixes, nil
+}
+
+// getLspName returns name of the Logical Switch Port bound to the host management port of the "member" on
+// the "logicalSwitch". This name is unique and consistent for each host and Logical Switch. Member names can't
+// contain underscore, so the first underscore in the name always separates the member from the Logical Switch.
+func getLspName(member string, logicalSwitch string) string {
+	return fmt.Sprintf("host-%s_%s", member, logicalSwitch)
+}
+
+// getIfaceName returns name of the internal interface used as a host management port on the "logicalSwitch".
+// The name is derived directly from the Logical Switch name if it fits into the kernel limit and contains only
+// characters that are safe in interface names. Otherwise, a short hash of the Logical Switch name is used instead.
+func getIfaceName(logicalSwitch string) string {
+	ifaceName := fmt.Sprintf("mh-%s", logicalSwitch)
+	if len(ifaceName) <= ifaceNameMaxLen && ifaceNameChars.MatchString(logicalSwitch) {
+		return ifaceName
+	}
+
+	nameHash := fnv.New32a()
+	_, _ = nameHash.Write([]byte(logicalSwitch))
+	return fmt.Sprintf("mh-%08x", nameHash.Sum32())
+}
+
+// generateMac returns a local unicast MAC address based on the Logical Switch Port name. The returned
+// address will always be same for given port name.
+// Warning: There is no guarantee that the address won't conflict with other MAC addresses present in the
+// Logical Switch.
+func generateMac(lspName string) string {
+	macAddr := "02:"
+	nameHash := md5.Sum([]byte(lspName))
+	for i := 0; i < 5; i++ {
+		macAddr += fmt.Sprintf("%02x:", nameHash[i])
+	}
+	return strings.TrimRight(macAddr, ":")
+}
+
+// lspAddresses returns value of the "addresses" column of the Logical Switch Port with "mac" and IP
+// addresses from "prefixes".
+func lspAddresses(mac string, prefixes []netip.Prefix) string {
+	addresses := []string{mac}
+	for _, prefix := range prefixes {
+		addresses = append(addresses, prefix.Addr().String())
+	}
+	return strings.Join(addresses, " ")
+}
+
+// newHostPort converts database record of the host management port to its API representation.
+func newHostPort(record database.HostPort) types.HostPort {
+	addresses := []string{}
+	if record.Addresses != "" {
+		addresses = strings.Split(record.Addresses, ",")
+	}
+
+	return types.HostPort{
+		Member:        record.Member,
+		LogicalSwitch: record.LogicalSwitch,
+		LogicalPort:   getLspName(record.Member, record.LogicalSwitch),
+		Iface:         record.Iface,
+		Mac:           record.Mac,
+		Addresses:     addresses,
+	}
+}
+
+// getOvnIntegrationBridge returns name of the OVN integration bridge configured in the local Open vSwitch.
+func getOvnIntegrationBridge(ctx context.Context, s state.State) (string, error) {
+	out, err := ovnCmd.VSCtl(ctx, s, "--if-exists", "get", "Open_vSwitch", ".", "external_ids:ovn-bridge")
+	if err != nil {
+		return "", fmt.Errorf("failed to lookup integration bridge: %w", err)
+	}
+
+	brName := strings.Trim(strings.TrimSpace(out), "\"")
+	if brName == "" {
+		brName = "br-int"
+	}
+	return brName, nil
+}
+
+// nbRecordExists returns true if the record identified by "name" exists in the "table" of the OVN Northbound
+// database.
+func nbRecordExists(ctx context.Context, s state.State, table string, name string) (bool, error) {
+	out, err := ovnCmd.NBCtlCluster(ctx, s, "--if-exists", "get", table, name, "_uuid")
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(out) != "", nil
+}
+
+// localRecords returns database records of the host management ports of the local member.
+func localRecords(ctx context.Context, s state.State) ([]database.HostPort, error) {
+	member := s.Name()
+	var records []database.HostPort
+	err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		records, err = database.GetHostPorts(ctx, tx, database.HostPortFilter{Member: &member})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list host ports: %w", err)
+	}
+	return records, nil
+}
+
+// List returns host management ports of the local member.
+func List(ctx context.Context, s state.State) ([]types.HostPort, error) {
+	records, err := localRecords(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+
+	hostPorts := []types.HostPort{}
+	for _, record := range records {
+		hostPorts = append(hostPorts, newHostPort(record))
+	}
+	return hostPorts, nil
+}
+
+// Add creates host management port of the local member on the "logicalSwitch". It creates Logical Switch Port
+// in the OVN Northbound database and an internal interface bound to it on the OVN integration bridge, and it
+// configures IP "addresses" (in CIDR notation) on the interface. The port is recorded in the MicroOVN
+// database, so that the addresses can be restored when the member restarts.
+//
+// The "chassis" service has to be enabled on this member.
+func Add(ctx context.Context, s state.State, logicalSwitch string, addresses []string) (types.HostPort, error) {
+	hasChassis, err := node.HasServiceActive(ctx, s, types.SrvChassis)
+	if err != nil {
+		return types.HostPort{}, fmt.Errorf("failed to query local services: %w", err)
+	}
+	if !hasChassis {
+		return types.HostPort{}, errors.New("this member does not run 'chassis' service")
+	}
+
+	prefixes, err := ParseAddresses(addresses)
+	if err != nil {
+		return types.HostPort{}, err
+	}
+
+	switchExists, err := nbRecordExists(ctx, s, "Logical_Switch", logicalSwitch)
+	if err != nil {
+		return types.HostPort{}, fmt.Errorf("failed to look up Logical Switch '%s': %w", logicalSwitch, err)
+	}
+	if !switchExists {
+		return types.HostPort{}, ErrSwitchNotFound
+	}
+
+	rawPrefixes := make([]string, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		rawPrefixes = append(rawPrefixes, prefix.String())
+	}
+
+	lspName := getLspName(s.Name(), logicalSwitch)
+	record := database.HostPort{
+		Member:        s.Name(),
+		LogicalSwitch: logicalSwitch,
+		Iface:         getIfaceName(logicalSwitch),
+		Mac:           generateMac(lspName),
+		Addresses:     strings.Join(rawPrefixes, ","),
+	}
+
+	err = s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		_, err := database.CreateHostPort(ctx, tx, record)
+		return err
+	})
+	if api.StatusErrorCheck(err, http.StatusConflict) {
+		return types.HostPort{}, ErrExists
+	}
+	if err != nil {
+		return types.HostPort{}, fmt.Errorf("failed to record host port: %w", err)
+	}
+
+	_, err = ovnCmd.NBCtlCluster(ctx, s,
+		"lsp-add", logicalSwitch, lspName,
+		"--", "lsp-set-addresses", lspName, lspAddresses(record.Mac, prefixes),
+		"--", "set", "Logical_Switch_Port", lspName, fmt.Sprintf("external_ids:%s=%s", ManagedTag, s.Name()),
+	)
+	if err == nil {
+		err = apply(ctx, s, record)
+	}
+	if err != nil {
+		cleanupErr := remove(ctx, s, record)
+		if cleanupErr != nil {
+			logger.Warnf("Failed to clean up host port on Logical Switch '%s': %v", logicalSwitch, cleanupErr)
+		}
+		return types.HostPort{}, fmt.Errorf("failed to create host port on Logical Switch '%s': %w", logicalSwitch, err)
+	}
+
+	logger.Infof("Created host port '%s' on Logical Switch '%s'", record.Iface, logicalSwitch)
+	return newHostPort(record), nil
+}
+
+// Remove deletes host management port of the local member from the "logicalSwitch". Both the Logical Switch
+// Port and the internal interface on the host are removed.
+func Remove(ctx context.Context, s state.State, logicalSwitch string) error {
+	var record *database.HostPort
+	err := s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		record, err = database.GetHostPort(ctx, tx, s.Name(), logicalSwitch)
+		return err
+	})
+	if api.StatusErrorCheck(err, http.StatusNotFound) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get host port: %w", err)
+	}
+
+	err = remove(ctx, s, *record)
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Removed host port '%s' from Logical Switch '%s'", record.Iface, logicalSwitch)
+	return nil
+}
+
+// ApplyAll re-configures every host management port of the local member. IP addresses configured on
+// internal interfaces do not survive restart of the host, so they have to be restored. Host ports that can't
+// work anymore, because the "chassis" service is not enabled or their Logical Switch Port was removed from
+// the OVN Northbound database (e.g. together with its Logical Switch), are removed instead.
+func ApplyAll(ctx context.Context, s state.State) error {
+	records, err := localRecords(ctx, s)
+	if err != nil || len(records) == 0 {
+		return err
+	}
+
+	hasChassis, err := node.HasServiceActive(ctx, s, types.SrvChassis)
+	if err != nil {
+		return fmt.Errorf("failed to query local services: %w", err)
+	}
+
+	errs := []error{}
+	for _, record := range records {
+		lspExists := false
+		if hasChassis {
+			lspExists, err = nbRecordExists(ctx, s, "Logical_Switch_Port", getLspName(record.Member, record.LogicalSwitch))
+			if err != nil {
+				errs = append(errs, fmt.Errorf("host port on Logical Switch '%s': %w", record.LogicalSwitch, err))
+				continue
+			}
+		}
+
+		if lspExists {
+			err = apply(ctx, s, record)
+		} else {
+			logger.Warnf("Removing stale host port '%s' of Logical Switch '%s'", record.Iface, record.LogicalSwitch)
+			err = remove(ctx, s, record)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("host port on Logical Switch '%s': %w", record.LogicalSwitch, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// RemoveAll deletes every host management port of the local member. Besides the host ports recorded in the
+// MicroOVN database, it also removes internal interfaces and Logical Switch Ports tagged with ManagedTag,
+// because the database records are already gone when the member is being removed from the cluster.
+func RemoveAll(ctx context.Context, s state.State) error {
+	records, err := localRecords(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	errs := []error{}
+	for _, record := range records {
+		err = remove(ctx, s, record)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("host port on Logical Switch '%s': %w", record.LogicalSwitch, err))
+			continue
+		}
+		logger.Infof("Removed host port '%s' from Logical Switch '%s'", record.Iface, record.LogicalSwitch)
+	}
+
+	tag := fmt.Sprintf("external_ids:%s=%s", ManagedTag, s.Name())
+	out, err := ovnCmd.VSCtl(ctx, s, "--bare", "--columns", "name", "find", "Interface", tag)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to look up host port interfaces: %w", err))
+	} else {
+		for _, iface := range strings.Fields(out) {
+			_, err = ovnCmd.VSCtl(ctx, s, "--if-exists", "del-port", iface)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to remove interface '%s': %w", iface, err))
+			}
+		}
+	}
+
+	out, err = ovnCmd.NBCtlCluster(ctx, s, "--bare", "--columns", "_uuid", "find", "Logical_Switch_Port", tag)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to look up host port Logical Switch Ports: %w", err))
+	} else {
+		for _, lspUUID := range strings.Fields(out) {
+			_, err = ovnCmd.NBCtlCluster(ctx, s, "--if-exists", "lsp-del", lspUUID)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to remove Logical Switch Port '%s': %w", lspUUID, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// apply creates internal interface of the host management port on the OVN integration bridge, if it does
+// not exist yet, and configures its IP addresses.
+func apply(ctx context.Context, s state.State, record database.HostPort) error {
+	brInt, err := getOvnIntegrationBridge(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	_, err = ovnCmd.VSCtl(ctx, s,
+		"--may-exist", "add-port", brInt, record.Iface,
+		"--", "set", "Interface", record.Iface, "type=internal",
+		fmt.Sprintf("mac=\"%s\"", record.Mac),
+		fmt.Sprintf("external_ids:iface-id=%s", getLspName(record.Member, record.LogicalSwitch)),
+		fmt.Sprintf("external_ids:%s=%s", ManagedTag, record.Member),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create interface '%s': %w", record.Iface, err)
+	}
+
+	if record.Addresses != "" {
+		for _, address := range strings.Split(record.Addresses, ",") {
+			_, err = shared.RunCommandContext(ctx, "ip", "address", "replace", address, "dev", record.Iface)
+			if err != nil {
+				return fmt.Errorf("failed to configure address '%s' on interface '%s': %w", address, record.Iface, err)
+			}
+		}
+	}
+
+	_, err = shared.RunCommandContext(ctx, "ip", "link", "set", "dev", record.Iface, "up")
+	if err != nil {
+		return fmt.Errorf("failed to bring up interface '%s': %w", record.Iface, err)
+	}
+
+	return nil
+}
+
+// remove deletes internal interface, Logical Switch Port and database record of the host management port.
+func remove(ctx context.Context, s state.State, record database.HostPort) error {
+	_, err := ovnCmd.VSCtl(ctx, s, "--if-exists", "del-port", record.Iface)
+	if err != nil {
+		return fmt.Errorf("failed to remove interface '%s': %w", record.Iface, err)
+	}
+
+	// Logical Switch Port is removed only if it belongs to the host port, e.g. when the host port failed to be
+	// created because a port with the same name already existed, that port is left intact.
+	lspName := getLspName(record.Member, record.LogicalSwitch)
+	owner, err := ovnCmd.NBCtlCluster(ctx, s, "--if-exists", "get", "Logical_Switch_Port", lspName,
+		fmt.Sprintf("external_ids:%s", ManagedTag),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to look up Logical Switch Port on Logical Switch '%s': %w", record.LogicalSwitch, err)
+	}
+
+	if strings.Trim(strings.TrimSpace(owner), "\"") == record.Member {
+		_, err = ovnCmd.NBCtlCluster(ctx, s, "--if-exists", "lsp-del", lspName)
+		if err != nil {
+			return fmt.Errorf("failed to remove Logical Switch Port from Logical Switch '%s': %w", record.LogicalSwitch, err)
+		}
+	}
+
+	err = s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		return database.DeleteHostPort(ctx, tx, record.Member, record.LogicalSwitch)
+	})
+	if err != nil && !api.StatusErrorCheck(err, http.StatusNotFound) {
+		return fmt.Errorf("failed to forget host port: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package hostport
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func TestParseAddresses(t *testing.T) {
+	testCases := []struct {
+		addresses []string
+		expected  []string
+		isValid   bool
+	}{
+		{addresses: []string{}, expected: []string{}, isValid: true},
+		{addresses: []string{"10.0.0.10/24"}, expected: []string{"10.0.0.10/24"}, isValid: true},
+		{addresses: []string{"10.0.0.10/24", "fd00::10/64"}, expected: []string{"10.0.0.10/24", "fd00::10/64"}, isValid: true},
+		{addresses: []string{"10.0.0.10"}, isValid: false},
+		{addresses: []string{"10.0.0.10/33"}, isValid: false},
+		{addresses: []string{"not-an-address/24"}, isValid: false},
+		{addresses: []string{"10.0.0.10/24", "10.0.0.10/16"}, isValid: false},
+	}
+
+	for _, tc := range testCases {
+		prefixes, err := ParseAddresses(tc.addresses)
+		if !tc.isValid {
+			if err == nil {
+				t.Errorf("Expected addresses %v to be rejected, got %v", tc.addresses, prefixes)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Failed to parse addresses %v: %s", tc.addresses, err)
+			continue
+		}
+		if len(prefixes) != len(tc.expected) {
+			t.Errorf("Expected %d addresses, got %d", len(tc.expected), len(prefixes))
+			continue
+		}
+		for i, prefix := range prefixes {
+			if prefix.String() != tc.expected[i] {
+				t.Errorf("Expected address '%s', got '%s'", tc.expected[i], prefix.String())
+			}
+		}
+	}
+}
+
+func TestUnexported_getLspName(t *testing.T) {
+	testCases := []struct {
+		member        string
+		logicalSwitch string
+		expected      string
+	}{
+		{member: "micro1", logicalSwitch: "ls0", expected: "host-micro1_ls0"},
+		{member: "a-b", logicalSwitch: "c", expected: "host-a-b_c"},
+		{member: "a", logicalSwitch: "b-c", expected: "host-a_b-c"},
+	}
+
+	for _, tc := range testCases {
+		lspName := getLspName(tc.member, tc.logicalSwitch)
+		if lspName != tc.expected {
+			t.Errorf("Expected Logical Switch Port name '%s', got '%s'", tc.expected, lspName)
+		}
+	}
+}
+
+func TestUnexported_getIfaceName(t *testing.T) {
+	testCases := []struct {
+		logicalSwitch string
+		expected      string
+	}{
+		{logicalSwitch: "ls0", expected: "mh-ls0"},
+		{logicalSwitch: "monitoring", expected: "mh-monitoring"},
+	}
+
+	for _, tc := range testCases {
+		ifaceName := getIfaceName(tc.logicalSwitch)
+		if ifaceName != tc.expected {
+			t.Errorf("Expected interface name '%s', got '%s'", tc.expected, ifaceName)
+		}
+	}
+
+	longName := getIfaceName("storage-frontend-network")
+	if len(longName) > ifaceNameMaxLen {
+		t.Errorf("Interface name '%s' exceeds %d characters", longName, ifaceNameMaxLen)
+	}
+	if longName != getIfaceName("storage-frontend-network") {
+		t.Errorf("Interface name of the same Logical Switch is not consistent")
+	}
+	if longName == getIfaceName("storage-backend-network") {
+		t.Errorf("Interface names of different Logical Switches are the same")
+	}
+
+	for _, logicalSwitch := range []string{"ls/0", "ls 0", "ls:0", "ls\"0"} {
+		ifaceName := getIfaceName(logicalSwitch)
+		if !ifaceNameChars.MatchString(ifaceName) || len(ifaceName) > ifaceNameMaxLen {
+			t.Errorf("Expected valid interface name for Logical Switch '%s', got '%s'", logicalSwitch, ifaceName)
+		}
+	}
+	if getIfaceName("ls/0") == getIfaceName("ls 0") {
+		t.Errorf("Interface names of different Logical Switches are the same")
+	}
+}
+
+func TestUnexported_generateMac(t *testing.T) {
+	mac := generateMac("host-node1_ls0")
+	if mac != generateMac("host-node1_ls0") {
+		t.Errorf("MAC address of the same Logical Switch Port is not consistent")
+	}
+	if mac == generateMac("host-node2_ls0") {
+		t.Errorf("MAC addresses of different Logical Switch Ports are the same")
+	}
+	if len(mac) != 17 || mac[:3] != "02:" {
+		t.Errorf("Expected local unicast MAC address, got '%s'", mac)
+	}
+}
+
+func TestUnexported_lspAddresses(t *testing.T) {
+	prefixes := []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.10/24"),
+		netip.MustParsePrefix("fd00::10/64"),
+	}
+
+	expected := "02:00:00:00:00:01 10.0.0.10 fd00::10"
+	addresses := lspAddresses("02:00:00:00:00:01", prefixes)
+	if addresses != expected {
+		t.Errorf("Expected addresses '%s', got '%s'", expected, addresses)
+	}
+
+	expected = "02:00:00:00:00:01"
+	addresses = lspAddresses("02:00:00:00:00:01", nil)
+	if addresses != expected {
+		t.Errorf("Expected addresses '%s', got '%s'", expected, addresses)
+	}
+}
@@ -10,11 +10,14 @@ import (
 
 	"github.com/canonical/microovn/microovn/api/types"
 	"github.com/canonical/microovn/microovn/database"
+	"github.com/canonical/microovn/microovn/facts"
 	"github.com/canonical/microovn/microovn/node"
 	"github.com/canonical/microovn/microovn/ovn/certificates"
 	ovnCluster "github.com/canonical/microovn/microovn/ovn/cluster"
 	ovnCmd "github.com/canonical/microovn/microovn/ovn/cmd"
 	"github.com/canonical/microovn/microovn/ovn/environment"
+	"github.com/canonical/microovn/microovn/ovn/ovsdb"
+	"github.com/canonical/microovn/microovn/ovn/vswitch"
 )
 
 // Join will join an existing OVN deployment.
@@ -29,6 +32,16 @@ func Join(ctx context.Context, s state.State, initConfig map[string]string) erro
 		return err
 	}
 
+	// Refuse to join if our versions are too far from the versions of existing members, unless
+	// explicitly allowed.
+	compatErr := ovsdb.CheckJoinCompatibility(ctx, s)
+	if compatErr != nil {
+		if initConfig[ovsdb.AllowVersionSkewConfigKey] != "true" {
+			return compatErr
+		}
+		logger.Warnf("Joining the cluster despite version skew: %v", compatErr)
+	}
+
 	// Query existing core services.
 	srvCentral := 0
 	err = s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
@@ -145,11 +158,23 @@ func Join(ctx context.Context, s state.State, initConfig map[string]string) erro
 			return fmt.Errorf("error configuring OVS parameters: %s", err)
 		}
 
+		err = vswitch.UpdateEncapOptions(ctx, s)
+		if err != nil {
+			return err
+		}
+
 		err = ovnCluster.UpdateOvnControllerRemoteConfig(ctx, s)
 		if err != nil {
 			return err
 		}
 	}
 
+	// Publish facts right away, so that versions of this member are known to members that join the
+	// cluster before the facts are published periodically.
+	err = facts.CollectAndPublish(ctx, s)
+	if err != nil {
+		logger.Warnf("Failed to publish member facts: %v", err)
+	}
+
 	return nil
 }
@@ -8,10 +8,12 @@ import (
 
 	"github.com/canonical/microovn/microovn/node"
 	"github.com/canonical/microovn/microovn/ovn/environment"
+	"github.com/canonical/microovn/microovn/ovn/hostport"
 )
 
 // Leave function gracefully departs from the OVN cluster before the member is removed from MicroOVN
 // cluster. It ensures that:
+//   - Host ports of the member are removed from the host and from NB database
 //   - OVN chassis is stopped and removed from SB database
 //   - OVN NB cluster is cleanly departed
 //   - OVN SB cluster is cleanly departed
@@ -20,8 +22,14 @@ import (
 // for departing cluster member, so we'll try to exit/leave/stop all possible services
 // ignoring any errors from services that are not actually running.
 func Leave(ctx context.Context, s state.State, _ bool) error {
+	// Host ports need to be removed while NB database is still reachable from this member
+	err := hostport.RemoveAll(ctx, s)
+	if err != nil {
+		logger.Warnf("Failed to remove host ports: %v", err)
+	}
+
 	// Attempt to disable each service
-	err := node.DisableAllServices(ctx, s)
+	err = node.DisableAllServices(ctx, s)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,206 @@
+package ovsdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/canonical/microcluster/v2/cluster"
+	"github.com/canonical/microcluster/v2/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/facts"
+	ovnCmd "github.com/canonical/microovn/microovn/ovn/cmd"
+	"github.com/canonical/microovn/microovn/version"
+)
+
+// AllowVersionSkewConfigKey - init config option that allows member to join the cluster even if its versions
+// are not compatible with versions of the existing cluster members.
+const AllowVersionSkewConfigKey = "allow-version-skew"
+
+// memberVersions holds versions of a single cluster member that are relevant for its compatibility with the
+// rest of the cluster. Empty values mean that the version is not known.
+type memberVersions struct {
+	Member   string            // Name or address of the cluster member
+	MicroOvn string            // Version of MicroOVN
+	Ovn      string            // Version of OVN
+	Schemas  map[string]string // Expected OVSDB schema versions, keyed by the database friendly name
+}
+
+// CheckJoinCompatibility compares versions of the local member, that is joining the cluster, with versions of
+// the existing cluster members. An error describing every incompatibility is returned if:
+//   - major version of MicroOVN or OVN differs from any of the existing members
+//   - major version of the expected Northbound or Southbound database schema differs from any of the existing
+//     members
+//   - expected Northbound or Southbound database schema is older than schemas expected by all existing members
+//   - expected Northbound or Southbound database schema of any existing member is not known
+//
+// All versions of the existing members are taken from the facts that they published.
+func CheckJoinCompatibility(ctx context.Context, s state.State) error {
+	local := memberVersions{
+		Member:   s.Name(),
+		MicroOvn: version.MicroOvnVersion,
+		Ovn:      version.OvnVersion,
+		Schemas:  make(map[string]string),
+	}
+
+	dbSpecs := []*ovnCmd.OvsdbSpec{}
+	for _, dbType := range []ovnCmd.OvsdbType{ovnCmd.OvsdbTypeNBLocal, ovnCmd.OvsdbTypeSBLocal} {
+		dbSpec, err := ovnCmd.NewOvsdbSpec(dbType)
+		if err != nil {
+			return err
+		}
+
+		local.Schemas[dbSpec.FriendlyName], err = ExpectedOvsdbSchemaVersion(ctx, s, dbSpec)
+		if err != nil {
+			return err
+		}
+		dbSpecs = append(dbSpecs, dbSpec)
+	}
+
+	factsList, err := facts.List(ctx, s)
+	if err != nil {
+		return fmt.Errorf("failed to get versions of existing cluster members: %w", err)
+	}
+
+	memberFacts := make(map[string]types.MemberFacts, len(factsList))
+	for _, published := range factsList {
+		memberFacts[published.Member] = published
+	}
+
+	var members []cluster.CoreClusterMember
+	err = s.Database().Transaction(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		members, err = cluster.GetCoreClusterMembers(ctx, tx)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list existing cluster members: %w", err)
+	}
+
+	// Members that did not publish their facts yet have all versions unknown.
+	remote := []memberVersions{}
+	for _, member := range members {
+		if member.Name == s.Name() {
+			continue
+		}
+
+		published := memberFacts[member.Name]
+		versions := memberVersions{
+			Member:   member.Name,
+			MicroOvn: published.MicroOvnVersion,
+			Ovn:      published.OvnVersion,
+			Schemas:  make(map[string]string),
+		}
+		for _, dbSpec := range dbSpecs {
+			versions.Schemas[dbSpec.FriendlyName] = published.ExpectedSchemas[dbSpec.ShortName]
+		}
+		remote = append(remote, versions)
+	}
+
+	problems := versionSkew(local, remote)
+	if len(problems) > 0 {
+		return fmt.Errorf(
+			"member is not compatible with the existing cluster members: %s. Use '--%s' option to join anyway",
+			strings.Join(problems, "; "),
+			AllowVersionSkewConfigKey,
+		)
+	}
+
+	return nil
+}
+
+// versionSkew compares versions of the "local" member with versions of the "remote" members and returns
+// description of every incompatibility. Unknown MicroOVN and OVN versions of the remote members are skipped,
+// but unknown expected schema versions are reported, as the schema compatibility can't be verified without them.
+func versionSkew(local memberVersions, remote []memberVersions) []string {
+	problems := []string{}
+	oldestSchemas := make(map[string]string)
+
+	dbs := make([]string, 0, len(local.Schemas))
+	for db := range local.Schemas {
+		dbs = append(dbs, db)
+	}
+	slices.Sort(dbs)
+
+	for _, member := range remote {
+		if member.MicroOvn != "" && version.MajorVersion(member.MicroOvn) != version.MajorVersion(local.MicroOvn) {
+			problems = append(problems, fmt.Sprintf(
+				"member '%s' runs MicroOVN %s, this member runs MicroOVN %s", member.Member, member.MicroOvn, local.MicroOvn,
+			))
+		}
+
+		if member.Ovn != "" && version.MajorVersion(member.Ovn) != version.MajorVersion(local.Ovn) {
+			problems = append(problems, fmt.Sprintf(
+				"member '%s' runs OVN %s, this member runs OVN %s", member.Member, member.Ovn, local.Ovn,
+			))
+		}
+
+		for _, db := range dbs {
+			schema := member.Schemas[db]
+			localSchema := local.Schemas[db]
+			if schema == "" {
+				problems = append(problems, fmt.Sprintf(
+					"expected OVN %s DB schema of member '%s' is unknown", db, member.Member,
+				))
+				continue
+			}
+
+			if schemaMajorVersion(schema) != schemaMajorVersion(localSchema) {
+				problems = append(problems, fmt.Sprintf(
+					"member '%s' expects OVN %s DB schema %s, this member expects schema %s", member.Member, db, schema, localSchema,
+				))
+				continue
+			}
+
+			oldest, ok := oldestSchemas[db]
+			if !ok || compareSchemaVersions(schema, oldest) < 0 {
+				oldestSchemas[db] = schema
+			}
+		}
+	}
+
+	for _, db := range dbs {
+		oldest, ok := oldestSchemas[db]
+		if ok && compareSchemaVersions(local.Schemas[db], oldest) < 0 {
+			problems = append(problems, fmt.Sprintf(
+				"this member expects OVN %s DB schema %s that is older than schemas expected by all existing members",
+				db, local.Schemas[db],
+			))
+		}
+	}
+
+	return problems
+}
+
+// schemaMajorVersion returns major component of the OVSDB schema version "<major>.<minor>.<patch>".
+// Schema versions with different major component are not compatible.
+func schemaMajorVersion(schemaVersion string) string {
+	major, _, _ := strings.Cut(schemaVersion, ".")
+	return major
+}
+
+// compareSchemaVersions compares two OVSDB schema versions and returns negative number if "a" is older than
+// "b", positive number if "a" is newer than "b" and 0 if they are equal. Non-numeric components compare as 0.
+func compareSchemaVersions(a string, b string) int {
+	aParts := strings.Split(a, ".")
+	bParts := strings.Split(b, ".")
+
+	for i := 0; i < max(len(aParts), len(bParts)); i++ {
+		var aNum, bNum int
+		if i < len(aParts) {
+			aNum, _ = strconv.Atoi(aParts[i])
+		}
+		if i < len(bParts) {
+			bNum, _ = strconv.Atoi(bParts[i])
+		}
+
+		if aNum != bNum {
+			return aNum - bNum
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,101 @@
+package ovsdb
+
+import (
+	"testing"
+)
+
+func TestUnexported_versionSkew(t *testing.T) {
+	local := memberVersions{
+		Member:   "movn4",
+		MicroOvn: "24.03.2+snap1",
+		Ovn:      "24.03.4",
+		Schemas:  map[string]string{"Northbound": "7.3.0", "Southbound": "20.33.0"},
+	}
+
+	schemas := func(nb string, sb string) map[string]string {
+		return map[string]string{"Northbound": nb, "Southbound": sb}
+	}
+
+	tests := []struct {
+		name     string
+		remote   []memberVersions
+		problems int
+	}{
+		{
+			name: "same versions",
+			remote: []memberVersions{
+				{Member: "movn1", MicroOvn: "24.03.1+snap2", Ovn: "24.03.2", Schemas: schemas("7.3.0", "20.33.0")},
+			},
+		},
+		{
+			name: "unknown MicroOVN and OVN versions are skipped",
+			remote: []memberVersions{
+				{Member: "movn1", Schemas: schemas("7.3.0", "20.33.0")},
+			},
+		},
+		{
+			name: "unknown schema versions",
+			remote: []memberVersions{
+				{Member: "movn1", MicroOvn: "24.03.1+snap2", Ovn: "24.03.2"},
+			},
+			problems: 2,
+		},
+		{
+			name: "different major versions",
+			remote: []memberVersions{
+				{Member: "movn1", MicroOvn: "22.03.3+snap1", Ovn: "22.03.5", Schemas: schemas("7.3.0", "20.33.0")},
+			},
+			problems: 2,
+		},
+		{
+			name: "different schema major version",
+			remote: []memberVersions{
+				{Member: "movn1", Schemas: schemas("6.3.0", "20.33.0")},
+			},
+			problems: 1,
+		},
+		{
+			name: "schema older than all members",
+			remote: []memberVersions{
+				{Member: "movn1", Schemas: schemas("7.3.0", "20.34.0")},
+				{Member: "movn2", Schemas: schemas("7.3.0", "20.33.1")},
+			},
+			problems: 1,
+		},
+		{
+			name: "schema between members during upgrade",
+			remote: []memberVersions{
+				{Member: "movn1", Schemas: schemas("7.3.0", "20.34.0")},
+				{Member: "movn2", Schemas: schemas("7.3.0", "20.32.0")},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := versionSkew(local, tt.remote)
+			if len(problems) != tt.problems {
+				t.Errorf("expected %d problems, got %d: %v", tt.problems, len(problems), problems)
+			}
+		})
+	}
+}
+
+func TestUnexported_compareSchemaVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		sign int
+	}{
+		{"7.3.0", "7.3.0", 0},
+		{"7.3.0", "7.10.0", -1},
+		{"20.33.1", "20.33.0", 1},
+		{"7.3", "7.3.0", 0},
+	}
+
+	for _, tt := range tests {
+		result := compareSchemaVersions(tt.a, tt.b)
+		if (result < 0 && tt.sign >= 0) || (result > 0 && tt.sign <= 0) || (result == 0 && tt.sign != 0) {
+			t.Errorf("compareSchemaVersions(%q, %q) = %d, expected sign %d", tt.a, tt.b, result, tt.sign)
+		}
+	}
+}
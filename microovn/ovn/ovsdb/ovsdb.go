@@ -15,6 +15,7 @@ import (
 
 	"github.com/canonical/microovn/microovn/api/types"
 	microovnClient "github.com/canonical/microovn/microovn/client"
+	"github.com/canonical/microovn/microovn/maintenance"
 	"github.com/canonical/microovn/microovn/node"
 	ovnCmd "github.com/canonical/microovn/microovn/ovn/cmd"
 )
@@ -39,17 +40,7 @@ type schemaStatus struct {
 // packages. This value can be used to check whether current OVN/OVS processes are using up-to-date database
 // schemas.
 func ExpectedOvsdbSchemaVersion(ctx context.Context, _ state.State, dbSpec *ovnCmd.OvsdbSpec) (string, error) {
-	targetDbVersion, err := shared.RunCommandContext(
-		ctx,
-		"ovsdb-tool",
-		"schema-version",
-		dbSpec.Schema,
-	)
-	if err != nil {
-		return "", fmt.Errorf("failed to get DB schema version from file '%s': '%s'", dbSpec.Schema, err)
-	}
-
-	return strings.TrimSpace(targetDbVersion), nil
+	return ovnCmd.OvsdbSchemaVersion(ctx, dbSpec)
 }
 
 // getLiveSchemaStatus returns information about schema status of the database specified by the "dbSpec" argument.
@@ -235,6 +226,7 @@ func UpgradeCentralDB(ctx context.Context, s state.State, dbType ovnCmd.OvsdbTyp
 			if !dbStatus.UpgradeRequired {
 				// If upgrade is not required, break out of the loop
 				logger.Infof("OVN %s DB is at expected version. No upgrade needed", dbSpec.FriendlyName)
+				maintenance.Clear(maintenance.SchemaUpgradeJob(dbSpec.ShortName))
 				break
 			}
 			logger.Infof("OVN %s DB schema needs upgrade.", dbSpec.FriendlyName)
@@ -261,19 +253,40 @@ func UpgradeCentralDB(ctx context.Context, s state.State, dbType ovnCmd.OvsdbTyp
 					)
 				} else if upgradeReady {
 
-					// If the cluster is ready, an upgrade is triggered. Otherwise, the loop continues.
-					logger.Infof("Triggering OVN %s schema upgrade.", dbSpec.FriendlyName)
-					_, err = ovnCmd.OvsdbClient(
-						ctx,
-						s,
-						dbSpec,
-						10,
-						60,
-						"convert",
-						dbSpec.SocketURL,
-						dbSpec.Schema,
+					// Schema conversion is disruptive, so it waits for a maintenance window if any are configured.
+					allowed, nextWindow, err := maintenance.Allowed(
+						ctx, s, maintenance.SchemaUpgradeJob(dbSpec.ShortName), time.Time{},
 					)
-					return err
+					if err != nil {
+						logger.Warnf(
+							"Failed to check maintenance windows for OVN %s schema upgrade: '%s' (retrying in %d ms)",
+							dbSpec.FriendlyName,
+							err,
+							backOffMs,
+						)
+					} else if !allowed {
+						logger.Infof(
+							"OVN %s schema upgrade deferred until next maintenance window (%s UTC)",
+							dbSpec.FriendlyName,
+							nextWindow.Format(time.DateTime),
+						)
+						time.Sleep(maintenance.RecheckInterval)
+						continue
+					} else {
+						// If the cluster is ready, an upgrade is triggered. Otherwise, the loop continues.
+						logger.Infof("Triggering OVN %s schema upgrade.", dbSpec.FriendlyName)
+						_, err = ovnCmd.OvsdbClient(
+							ctx,
+							s,
+							dbSpec,
+							10,
+							60,
+							"convert",
+							dbSpec.SocketURL,
+							dbSpec.Schema,
+						)
+						return err
+					}
 				}
 			} else {
 				// Nodes that are not designated to trigger the upgrade, continue looping. This ensures that in case
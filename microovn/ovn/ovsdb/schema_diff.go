@@ -0,0 +1,243 @@
+package ovsdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/canonical/microcluster/v2/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	ovnCmd "github.com/canonical/microovn/microovn/ovn/cmd"
+)
+
+// databaseSchema is a subset of the OVSDB schema (RFC 7047, section 3.2) that is relevant for comparing
+// two versions of the same database schema.
+type databaseSchema struct {
+	Name    string                 `json:"name"`
+	Version string                 `json:"version"`
+	Tables  map[string]tableSchema `json:"tables"`
+}
+
+// tableSchema is a definition of a single table in the OVSDB schema.
+type tableSchema struct {
+	Columns map[string]columnSchema `json:"columns"`
+	MaxRows *int                    `json:"maxRows"`
+	IsRoot  bool                    `json:"isRoot"`
+	Indexes [][]string              `json:"indexes"`
+}
+
+// columnSchema is a definition of a single column in the OVSDB schema.
+type columnSchema struct {
+	Type      json.RawMessage `json:"type"`
+	Ephemeral bool            `json:"ephemeral"`
+	Mutable   *bool           `json:"mutable"`
+}
+
+// isMutable returns whether the column can be modified after the row is inserted. Columns are mutable
+// unless the schema says otherwise.
+func (c columnSchema) isMutable() bool {
+	return c.Mutable == nil || *c.Mutable
+}
+
+// SchemaDiff compares schema of the running database specified by the "dbSpec" argument with the schema
+// file shipped with the OVN/OVS packages and returns changes that would be applied by the schema conversion.
+func SchemaDiff(ctx context.Context, s state.State, dbSpec *ovnCmd.OvsdbSpec) (types.OvsdbSchemaDiff, error) {
+	running, err := ovnCmd.OvsdbClient(ctx, s, dbSpec, 10, 30, "get-schema", dbSpec.SocketURL, dbSpec.Name)
+	if err != nil {
+		return types.OvsdbSchemaDiff{}, fmt.Errorf("failed to get schema of the running %s database: %w", dbSpec.FriendlyName, err)
+	}
+
+	target, err := os.ReadFile(dbSpec.Schema)
+	if err != nil {
+		return types.OvsdbSchemaDiff{}, fmt.Errorf("failed to read schema file '%s': %w", dbSpec.Schema, err)
+	}
+
+	return DiffSchemas([]byte(running), target)
+}
+
+// DiffSchemas compares two JSON encoded OVSDB schemas and returns changes needed to convert database from the
+// "running" schema to the "target" schema. Every change is marked as breaking if it can cause failures of
+// existing database clients or of the schema conversion itself.
+func DiffSchemas(running []byte, target []byte) (types.OvsdbSchemaDiff, error) {
+	var runningSchema, targetSchema databaseSchema
+	err := json.Unmarshal(running, &runningSchema)
+	if err != nil {
+		return types.OvsdbSchemaDiff{}, fmt.Errorf("failed to parse running schema: %w", err)
+	}
+
+	err = json.Unmarshal(target, &targetSchema)
+	if err != nil {
+		return types.OvsdbSchemaDiff{}, fmt.Errorf("failed to parse target schema: %w", err)
+	}
+
+	if runningSchema.Name != targetSchema.Name {
+		return types.OvsdbSchemaDiff{}, fmt.Errorf(
+			"schemas belong to different databases: '%s' and '%s'", runningSchema.Name, targetSchema.Name,
+		)
+	}
+
+	diff := types.OvsdbSchemaDiff{
+		Database:       runningSchema.Name,
+		RunningVersion: runningSchema.Version,
+		TargetVersion:  targetSchema.Version,
+		Changes:        []types.OvsdbSchemaChange{},
+	}
+
+	for name, table := range runningSchema.Tables {
+		if _, ok := targetSchema.Tables[name]; !ok {
+			diff.Changes = append(diff.Changes, types.OvsdbSchemaChange{
+				Kind: types.SchemaChangeRemoved, Table: name, Breaking: true,
+			})
+			continue
+		}
+		diff.Changes = append(diff.Changes, diffTables(name, table, targetSchema.Tables[name])...)
+	}
+
+	for name := range targetSchema.Tables {
+		if _, ok := runningSchema.Tables[name]; !ok {
+			diff.Changes = append(diff.Changes, types.OvsdbSchemaChange{Kind: types.SchemaChangeAdded, Table: name})
+		}
+	}
+
+	sort.SliceStable(diff.Changes, func(i, j int) bool {
+		a, b := diff.Changes[i], diff.Changes[j]
+		if a.Table != b.Table {
+			return a.Table < b.Table
+		}
+		if a.Column != b.Column {
+			return a.Column < b.Column
+		}
+		return a.Detail < b.Detail
+	})
+
+	return diff, nil
+}
+
+// diffTables returns changes of columns and constraints between two versions of the table "name".
+func diffTables(name string, running tableSchema, target tableSchema) []types.OvsdbSchemaChange {
+	changes := []types.OvsdbSchemaChange{}
+
+	for column, runningColumn := range running.Columns {
+		targetColumn, ok := target.Columns[column]
+		if !ok {
+			changes = append(changes, types.OvsdbSchemaChange{
+				Kind: types.SchemaChangeRemoved, Table: name, Column: column, Breaking: true,
+			})
+			continue
+		}
+
+		runningType, targetType := canonicalJSON(runningColumn.Type), canonicalJSON(targetColumn.Type)
+		if runningType != targetType {
+			changes = append(changes, types.OvsdbSchemaChange{
+				Kind:     types.SchemaChangeChanged,
+				Table:    name,
+				Column:   column,
+				Detail:   fmt.Sprintf("type changed from %s to %s", runningType, targetType),
+				Breaking: true,
+			})
+		}
+
+		if runningColumn.isMutable() != targetColumn.isMutable() {
+			changes = append(changes, types.OvsdbSchemaChange{
+				Kind:     types.SchemaChangeChanged,
+				Table:    name,
+				Column:   column,
+				Detail:   fmt.Sprintf("mutable changed from %t to %t", runningColumn.isMutable(), targetColumn.isMutable()),
+				Breaking: !targetColumn.isMutable(),
+			})
+		}
+
+		if runningColumn.Ephemeral != targetColumn.Ephemeral {
+			changes = append(changes, types.OvsdbSchemaChange{
+				Kind:   types.SchemaChangeChanged,
+				Table:  name,
+				Column: column,
+				Detail: fmt.Sprintf("ephemeral changed from %t to %t", runningColumn.Ephemeral, targetColumn.Ephemeral),
+			})
+		}
+	}
+
+	for column := range target.Columns {
+		if _, ok := running.Columns[column]; !ok {
+			changes = append(changes, types.OvsdbSchemaChange{Kind: types.SchemaChangeAdded, Table: name, Column: column})
+		}
+	}
+
+	// Rows that are no longer referenced from other tables are garbage collected from non-root tables
+	if running.IsRoot != target.IsRoot {
+		changes = append(changes, types.OvsdbSchemaChange{
+			Kind:     types.SchemaChangeChanged,
+			Table:    name,
+			Detail:   fmt.Sprintf("isRoot changed from %t to %t", running.IsRoot, target.IsRoot),
+			Breaking: !target.IsRoot,
+		})
+	}
+
+	runningRows, targetRows := formatMaxRows(running.MaxRows), formatMaxRows(target.MaxRows)
+	if runningRows != targetRows {
+		changes = append(changes, types.OvsdbSchemaChange{
+			Kind:     types.SchemaChangeChanged,
+			Table:    name,
+			Detail:   fmt.Sprintf("maxRows changed from %s to %s", runningRows, targetRows),
+			Breaking: target.MaxRows != nil && (running.MaxRows == nil || *target.MaxRows < *running.MaxRows),
+		})
+	}
+
+	// New indexes reject rows that were allowed before, and the conversion fails if existing rows violate them
+	runningIndexes, targetIndexes := formatIndexes(running.Indexes), formatIndexes(target.Indexes)
+	for index := range runningIndexes {
+		if !targetIndexes[index] {
+			changes = append(changes, types.OvsdbSchemaChange{
+				Kind: types.SchemaChangeRemoved, Table: name, Detail: fmt.Sprintf("index [%s]", index),
+			})
+		}
+	}
+	for index := range targetIndexes {
+		if !runningIndexes[index] {
+			changes = append(changes, types.OvsdbSchemaChange{
+				Kind: types.SchemaChangeAdded, Table: name, Detail: fmt.Sprintf("index [%s]", index), Breaking: true,
+			})
+		}
+	}
+
+	return changes
+}
+
+// canonicalJSON re-encodes JSON value with sorted object keys and without whitespace, so that equal values
+// can be compared as strings.
+func canonicalJSON(value json.RawMessage) string {
+	var decoded any
+	err := json.Unmarshal(value, &decoded)
+	if err != nil {
+		return string(value)
+	}
+
+	encoded, err := json.Marshal(decoded)
+	if err != nil {
+		return string(value)
+	}
+	return string(encoded)
+}
+
+// formatMaxRows returns human-readable value of the table's "maxRows" constraint.
+func formatMaxRows(maxRows *int) string {
+	if maxRows == nil {
+		return "unlimited"
+	}
+	return fmt.Sprint(*maxRows)
+}
+
+// formatIndexes returns set of the table's indexes, each formatted as a sorted, comma-separated list of columns.
+func formatIndexes(indexes [][]string) map[string]bool {
+	result := make(map[string]bool, len(indexes))
+	for _, index := range indexes {
+		columns := append([]string{}, index...)
+		sort.Strings(columns)
+		result[strings.Join(columns, ", ")] = true
+	}
+	return result
+}
@@ -0,0 +1,124 @@
+package ovsdb
+
+import (
+	"testing"
+
+	"github.com/canonical/microovn/microovn/api/types"
+)
+
+func TestDiffSchemas(t *testing.T) {
+	running := `{
+  "name": "OVN_Northbound",
+  "version": "7.3.0",
+  "tables": {
+    "Logical_Switch": {
+      "columns": {
+        "name": {"type": "string"},
+        "ports": {"type": {"key": {"type": "uuid", "refTable": "Logical_Switch_Port"}, "min": 0, "max": "unlimited"}},
+        "legacy": {"type": "string"}
+      },
+      "isRoot": true
+    },
+    "Logical_Switch_Port": {
+      "columns": {
+        "name": {"type": "string"},
+        "type": {"type": "string", "mutable": true}
+      },
+      "indexes": [["name"]],
+      "isRoot": false
+    },
+    "NB_Global": {
+      "columns": {"nb_cfg": {"type": "integer"}},
+      "maxRows": 1,
+      "isRoot": true
+    },
+    "Obsolete": {
+      "columns": {"name": {"type": "string"}},
+      "isRoot": true
+    }
+  }
+}`
+	target := `{
+  "name": "OVN_Northbound",
+  "version": "7.4.0",
+  "tables": {
+    "Logical_Switch": {
+      "columns": {
+        "name": {"type": "string"},
+        "ports": {"type": {"max": "unlimited", "min": 0, "key": {"refTable": "Logical_Switch_Port", "type": "uuid"}}},
+        "copp": {"type": {"key": {"type": "uuid", "refTable": "Copp"}, "min": 0, "max": 1}}
+      },
+      "indexes": [["name"]],
+      "isRoot": true
+    },
+    "Logical_Switch_Port": {
+      "columns": {
+        "name": {"type": "string"},
+        "type": {"type": {"key": {"type": "string", "enum": ["set", ["", "router"]]}}, "mutable": false}
+      },
+      "indexes": [["name"]],
+      "isRoot": false
+    },
+    "NB_Global": {
+      "columns": {"nb_cfg": {"type": "integer"}},
+      "maxRows": 1,
+      "isRoot": true
+    },
+    "Copp": {
+      "columns": {"name": {"type": "string"}},
+      "isRoot": true
+    }
+  }
+}`
+
+	diff, err := DiffSchemas([]byte(running), []byte(target))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if diff.Database != "OVN_Northbound" || diff.RunningVersion != "7.3.0" || diff.TargetVersion != "7.4.0" {
+		t.Errorf("Unexpected schema versions: %+v", diff)
+	}
+
+	expected := []types.OvsdbSchemaChange{
+		{Kind: types.SchemaChangeAdded, Table: "Copp"},
+		{Kind: types.SchemaChangeAdded, Table: "Logical_Switch", Detail: "index [name]", Breaking: true},
+		{Kind: types.SchemaChangeAdded, Table: "Logical_Switch", Column: "copp"},
+		{Kind: types.SchemaChangeRemoved, Table: "Logical_Switch", Column: "legacy", Breaking: true},
+		{Kind: types.SchemaChangeChanged, Table: "Logical_Switch_Port", Column: "type", Detail: "mutable changed from true to false", Breaking: true},
+		{
+			Kind:     types.SchemaChangeChanged,
+			Table:    "Logical_Switch_Port",
+			Column:   "type",
+			Detail:   `type changed from "string" to {"key":{"enum":["set",["","router"]],"type":"string"}}`,
+			Breaking: true,
+		},
+		{Kind: types.SchemaChangeRemoved, Table: "Obsolete", Breaking: true},
+	}
+
+	if len(diff.Changes) != len(expected) {
+		t.Fatalf("Expected %d changes, got %+v", len(expected), diff.Changes)
+	}
+	for i := range expected {
+		if diff.Changes[i] != expected[i] {
+			t.Errorf("Expected change %+v, got %+v", expected[i], diff.Changes[i])
+		}
+	}
+
+	if !diff.HasBreakingChanges() {
+		t.Errorf("Expected diff to contain breaking changes")
+	}
+
+	identical, err := DiffSchemas([]byte(running), []byte(running))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(identical.Changes) != 0 || identical.HasBreakingChanges() {
+		t.Errorf("Expected no changes between identical schemas, got %+v", identical.Changes)
+	}
+
+	_, err = DiffSchemas([]byte(running), []byte(`{"name": "OVN_Southbound", "version": "20.0.0", "tables": {}}`))
+	if err == nil {
+		t.Errorf("Expected error when comparing schemas of different databases")
+	}
+}
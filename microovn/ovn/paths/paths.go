@@ -113,6 +113,12 @@ func PkiCaCertFile() string {
 	return filepath.Join(PkiDir(), "cacert.pem")
 }
 
+// PkiClientCaBundleFile returns path to the bundle of CA certificates that OVN database servers use to
+// verify their clients
+func PkiClientCaBundleFile() string {
+	return filepath.Join(PkiDir(), "client-ca-bundle.pem")
+}
+
 // PkiOvnNbCertFiles returns paths to certificate and private key used by OVN Northbound service
 func PkiOvnNbCertFiles() (string, string) {
 	return getServiceCertFiles("ovnnb")
@@ -133,6 +139,11 @@ func PkiOvnControllerCertFiles() (string, string) {
 	return getServiceCertFiles("ovn-controller")
 }
 
+// PkiOvsManagerCertFiles returns paths to certificate and private key used by remote OVS manager listener
+func PkiOvsManagerCertFiles() (string, string) {
+	return getServiceCertFiles("ovs-manager")
+}
+
 // PkiClientCertFiles returns paths to certificate and private key used by client
 func PkiClientCertFiles() (string, string) {
 	return getServiceCertFiles("client")
@@ -0,0 +1,216 @@
+// Package realization tracks how far were the changes in the OVN Northbound database realized. It uses the
+// same mechanism as the "--wait" option of ovn-nbctl: the "nb_cfg" sequence number in the NB_Global table is
+// incremented and ovn-northd and ovn-controllers report the sequence number of the changes they processed.
+package realization
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/canonical/microcluster/v2/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	ovnCmd "github.com/canonical/microovn/microovn/ovn/cmd"
+)
+
+// pollInterval is a period in which the realization status is checked while waiting.
+const pollInterval = 500 * time.Millisecond
+
+// ErrNotRealized is returned by Wait if the changes were not realized within the timeout.
+var ErrNotRealized = errors.New("changes were not realized")
+
+// operationResult is a result of a single operation in the OVSDB transaction.
+type operationResult struct {
+	Rows    []map[string]json.RawMessage `json:"rows"`
+	Error   string                       `json:"error"`
+	Details string                       `json:"details"`
+}
+
+// Bump increments the "nb_cfg" sequence number in the OVN Northbound database and returns its new value.
+// Changes made in the database before the call are realized once ovn-northd and ovn-controllers report
+// this sequence number.
+func Bump(ctx context.Context, s state.State) (int, error) {
+	transaction := `["OVN_Northbound",
+		{"op": "mutate", "table": "NB_Global", "where": [], "mutations": [["nb_cfg", "+=", 1]]},
+		{"op": "select", "table": "NB_Global", "where": [], "columns": ["nb_cfg"]}]`
+
+	out, err := ovnCmd.NBTransact(ctx, s, transaction)
+	if err != nil {
+		return 0, fmt.Errorf("failed to increment nb_cfg: %w", err)
+	}
+
+	rows, err := parseRows(out, 1)
+	if err != nil {
+		return 0, fmt.Errorf("failed to increment nb_cfg: %w", err)
+	}
+
+	if len(rows) != 1 {
+		return 0, fmt.Errorf("expected single NB_Global record, found %d", len(rows))
+	}
+	return intColumn(rows[0], "nb_cfg"), nil
+}
+
+// Status returns realization status of the changes up to the "nb_cfg" sequence number "target" at the
+// realization "level" (types.RealizationSB or types.RealizationHV).
+func Status(ctx context.Context, s state.State, target int, level string) (types.RealizationStatus, error) {
+	out, err := ovnCmd.NBTransact(ctx, s,
+		`["OVN_Northbound", {"op": "select", "table": "NB_Global", "where": [], "columns": ["sb_cfg", "hv_cfg"]}]`)
+	if err != nil {
+		return types.RealizationStatus{}, fmt.Errorf("failed to read NB_Global: %w", err)
+	}
+
+	nbGlobal, err := parseRows(out, 0)
+	if err != nil {
+		return types.RealizationStatus{}, fmt.Errorf("failed to read NB_Global: %w", err)
+	}
+	if len(nbGlobal) != 1 {
+		return types.RealizationStatus{}, fmt.Errorf("expected single NB_Global record, found %d", len(nbGlobal))
+	}
+
+	out, err = ovnCmd.SBTransact(ctx, s,
+		`["OVN_Southbound", {"op": "select", "table": "Chassis_Private", "where": [], "columns": ["name", "nb_cfg"]}]`)
+	if err != nil {
+		return types.RealizationStatus{}, fmt.Errorf("failed to read Chassis_Private: %w", err)
+	}
+
+	chassisRows, err := parseRows(out, 0)
+	if err != nil {
+		return types.RealizationStatus{}, fmt.Errorf("failed to read Chassis_Private: %w", err)
+	}
+
+	chassis := make([]types.ChassisRealization, 0, len(chassisRows))
+	for _, row := range chassisRows {
+		chassis = append(chassis, types.ChassisRealization{
+			Chassis: stringColumn(row, "name"),
+			NbCfg:   intColumn(row, "nb_cfg"),
+		})
+	}
+
+	return newStatus(target, level, intColumn(nbGlobal[0], "sb_cfg"), intColumn(nbGlobal[0], "hv_cfg"), chassis), nil
+}
+
+// Wait increments the "nb_cfg" sequence number and waits until all the changes made in the OVN Northbound
+// database so far are realized at the realization "level" (types.RealizationSB or types.RealizationHV).
+// If the changes are not realized within the "timeout", the last status is returned together with an error
+// that wraps ErrNotRealized and lists lagging chassis. Other errors mean that the realization status could
+// not be determined at all.
+func Wait(ctx context.Context, s state.State, level string, timeout time.Duration) (types.RealizationStatus, error) {
+	err := types.ValidateRealizationLevel(level)
+	if err != nil {
+		return types.RealizationStatus{}, err
+	}
+
+	target, err := Bump(ctx, s)
+	if err != nil {
+		return types.RealizationStatus{}, err
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		status, err := Status(waitCtx, s, target, level)
+		if err == nil && status.Realized {
+			return status, nil
+		}
+
+		select {
+		case <-waitCtx.Done():
+			if err != nil {
+				return status, fmt.Errorf("failed to get realization status of the changes: %w", err)
+			}
+			return status, fmt.Errorf("%w within %s: %s", ErrNotRealized, timeout, describeLag(status, level))
+		case <-ticker.C:
+		}
+	}
+}
+
+// newStatus evaluates realization of the changes up to the "target" sequence number at the "level", given
+// the sequence numbers reported by ovn-northd ("sbCfg", "hvCfg") and by individual chassis.
+func newStatus(target int, level string, sbCfg int, hvCfg int, chassis []types.ChassisRealization) types.RealizationStatus {
+	status := types.RealizationStatus{
+		Target:  target,
+		SbCfg:   sbCfg,
+		HvCfg:   hvCfg,
+		Lagging: []types.ChassisRealization{},
+	}
+
+	for _, ch := range chassis {
+		if ch.NbCfg < target {
+			status.Lagging = append(status.Lagging, ch)
+		}
+	}
+	sort.Slice(status.Lagging, func(i, j int) bool {
+		return status.Lagging[i].Chassis < status.Lagging[j].Chassis
+	})
+
+	switch level {
+	case types.RealizationSB:
+		status.Realized = sbCfg >= target
+	case types.RealizationHV:
+		// ovn-northd updates "hv_cfg" only periodically, chassis may have caught up sooner
+		status.Realized = hvCfg >= target || (sbCfg >= target && len(status.Lagging) == 0)
+	}
+
+	return status
+}
+
+// describeLag returns human-readable reason why the changes are not realized at the "level".
+func describeLag(status types.RealizationStatus, level string) string {
+	if status.SbCfg < status.Target {
+		return "ovn-northd did not process the changes yet"
+	}
+
+	if level == types.RealizationHV && len(status.Lagging) > 0 {
+		lagging := make([]string, 0, len(status.Lagging))
+		for _, ch := range status.Lagging {
+			lagging = append(lagging, ch.Chassis)
+		}
+		return fmt.Sprintf("lagging chassis: %s", strings.Join(lagging, ", "))
+	}
+
+	return "ovn-northd did not report realization of the changes"
+}
+
+// parseRows parses output of the "ovsdb-client transact" command and returns rows selected by the operation
+// with "index" in the transaction. An error is returned if any of the operations failed.
+func parseRows(output string, index int) ([]map[string]json.RawMessage, error) {
+	var results []*operationResult
+	err := json.Unmarshal([]byte(output), &results)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse OVSDB transaction result: %w", err)
+	}
+
+	for _, result := range results {
+		if result != nil && result.Error != "" {
+			return nil, fmt.Errorf("OVSDB operation failed: %s: %s", result.Error, result.Details)
+		}
+	}
+
+	if index >= len(results) || results[index] == nil {
+		return nil, fmt.Errorf("unexpected number of OVSDB results: %d", len(results))
+	}
+	return results[index].Rows, nil
+}
+
+// intColumn returns value of the integer column from the OVSDB row, or 0 if the column is missing.
+func intColumn(row map[string]json.RawMessage, column string) int {
+	var value int
+	_ = json.Unmarshal(row[column], &value)
+	return value
+}
+
+// stringColumn returns value of the string column from the OVSDB row, or "" if the column is missing.
+func stringColumn(row map[string]json.RawMessage, column string) string {
+	var value string
+	_ = json.Unmarshal(row[column], &value)
+	return value
+}
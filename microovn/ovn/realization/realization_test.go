@@ -0,0 +1,83 @@
+package realization
+
+import (
+	"testing"
+
+	"github.com/canonical/microovn/microovn/api/types"
+)
+
+func TestUnexported_newStatus(t *testing.T) {
+	chassis := []types.ChassisRealization{
+		{Chassis: "micro3", NbCfg: 9},
+		{Chassis: "micro1", NbCfg: 10},
+		{Chassis: "micro2", NbCfg: 8},
+	}
+
+	status := newStatus(10, types.RealizationHV, 10, 8, chassis)
+	if status.Realized {
+		t.Errorf("Expected changes not to be realized on chassis, got %+v", status)
+	}
+
+	expectedLagging := []types.ChassisRealization{{Chassis: "micro2", NbCfg: 8}, {Chassis: "micro3", NbCfg: 9}}
+	if len(status.Lagging) != len(expectedLagging) {
+		t.Fatalf("Expected lagging chassis %+v, got %+v", expectedLagging, status.Lagging)
+	}
+	for i := range expectedLagging {
+		if status.Lagging[i] != expectedLagging[i] {
+			t.Errorf("Expected lagging chassis %+v, got %+v", expectedLagging[i], status.Lagging[i])
+		}
+	}
+
+	if lag := describeLag(status, types.RealizationHV); lag != "lagging chassis: micro2, micro3" {
+		t.Errorf("Unexpected lag description: %s", lag)
+	}
+
+	// Southbound realization does not depend on chassis
+	if status = newStatus(10, types.RealizationSB, 10, 8, chassis); !status.Realized {
+		t.Errorf("Expected changes to be realized in Southbound database, got %+v", status)
+	}
+
+	// Chassis caught up before ovn-northd updated hv_cfg
+	caughtUp := []types.ChassisRealization{{Chassis: "micro1", NbCfg: 10}, {Chassis: "micro2", NbCfg: 11}}
+	if status = newStatus(10, types.RealizationHV, 10, 8, caughtUp); !status.Realized {
+		t.Errorf("Expected changes to be realized on chassis, got %+v", status)
+	}
+
+	// Changes not yet processed by ovn-northd
+	status = newStatus(10, types.RealizationHV, 9, 9, caughtUp)
+	if status.Realized {
+		t.Errorf("Expected changes not to be realized, got %+v", status)
+	}
+	if lag := describeLag(status, types.RealizationHV); lag != "ovn-northd did not process the changes yet" {
+		t.Errorf("Unexpected lag description: %s", lag)
+	}
+}
+
+func TestUnexported_parseRows(t *testing.T) {
+	output := `[{"count":1},{"rows":[{"nb_cfg":42}]}]`
+	rows, err := parseRows(output, 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(rows) != 1 || intColumn(rows[0], "nb_cfg") != 42 {
+		t.Errorf("Unexpected rows: %v", rows)
+	}
+
+	rows, err = parseRows(`[{"rows":[{"name":"micro1","nb_cfg":7}]}]`, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if stringColumn(rows[0], "name") != "micro1" || intColumn(rows[0], "missing") != 0 {
+		t.Errorf("Unexpected rows: %v", rows)
+	}
+
+	_, err = parseRows(`[{"error":"constraint violation","details":"bad value"},null]`, 1)
+	if err == nil {
+		t.Errorf("Expected error for failed operation")
+	}
+
+	_, err = parseRows(`[{"count":1}]`, 1)
+	if err == nil {
+		t.Errorf("Expected error for missing operation result")
+	}
+}
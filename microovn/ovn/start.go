@@ -9,10 +9,14 @@ import (
 	"github.com/canonical/microcluster/v2/state"
 
 	"github.com/canonical/microovn/microovn/node"
+	"github.com/canonical/microovn/microovn/ovn/certificates"
 	ovnCluster "github.com/canonical/microovn/microovn/ovn/cluster"
 	ovnCmd "github.com/canonical/microovn/microovn/ovn/cmd"
+	"github.com/canonical/microovn/microovn/ovn/copp"
 	"github.com/canonical/microovn/microovn/ovn/environment"
+	"github.com/canonical/microovn/microovn/ovn/hostport"
 	"github.com/canonical/microovn/microovn/ovn/ovsdb"
+	"github.com/canonical/microovn/microovn/ovn/vswitch"
 )
 
 // Start will update the existing OVN central and OVS switch configs.
@@ -30,6 +34,12 @@ func Start(ctx context.Context, s state.State) error {
 		return err
 	}
 
+	// Refresh CA certificate and the bundle of trusted client CAs before the database servers start
+	err = certificates.DumpCA(ctx, s)
+	if err != nil {
+		logger.Warnf("Failed to refresh CA certificates: %v", err)
+	}
+
 	err = node.ActivateEnabledServices(ctx, s, true)
 	if err != nil {
 		return fmt.Errorf("failed to enable required services: %w", err)
@@ -58,12 +68,36 @@ func Start(ctx context.Context, s state.State) error {
 		return err
 	}
 
+	// Re-apply remote OVS manager listener, if it's configured on this member.
+	err = vswitch.UpdateRemoteManager(ctx, s)
+	if err != nil {
+		logger.Warnf("Failed to update remote OVS manager configuration: %v", err)
+	}
+
+	// Re-apply tunnel encapsulation options, in case they were changed while this member was offline.
+	err = vswitch.UpdateEncapOptions(ctx, s)
+	if err != nil {
+		logger.Warnf("Failed to update tunnel encapsulation options: %v", err)
+	}
+
+	// Addresses of host ports are not persistent, restore them if any host ports exist on this member.
+	err = hostport.ApplyAll(ctx, s)
+	if err != nil {
+		logger.Warnf("Failed to restore host ports: %v", err)
+	}
+
+	// Conntrack limits are not persistent in the datapath, re-apply them if they are configured on this member.
+	err = vswitch.UpdateConntrackLimits(ctx, s, false)
+	if err != nil {
+		logger.Warnf("Failed to update conntrack limits: %v", err)
+	}
+
 	// If "central" services are active on this node, start two goroutines that will check if OVN database schemas
 	// are up-to-date. If a schema upgrade is required, they will coordinate with other members in the cluster and
-	// trigger the schema upgrade.
+	// trigger the schema upgrade. Third goroutine applies the MicroOVN CoPP profile.
 	//
-	// Note: The upgrade functions for NB and SB databases are started in goroutines, otherwise they'd block
-	// microovnd service from fully starting.
+	// Note: These functions are started in goroutines, otherwise they'd block microovnd service from fully
+	// starting.
 	if centralActive {
 		go func() {
 			err := ovsdb.UpgradeCentralDB(ctx, s, ovnCmd.OvsdbTypeSBLocal)
@@ -78,6 +112,17 @@ func Start(ctx context.Context, s state.State) error {
 				logger.Errorf("Failed to perform OVN NB schema upgrade. '%s'", err)
 			}
 		}()
+
+		// Make sure that the CoPP profile managed by MicroOVN is in line with the current configuration. This
+		// also attaches the profile to Logical Routers created since the last start, if "ovn.copp.all-routers"
+		// is enabled. Every member with central service applies the profile, because updates of the profile
+		// are idempotent and tolerate concurrent creation of the same records by other members.
+		go func() {
+			err := copp.Apply(ctx, s)
+			if err != nil {
+				logger.Warnf("Failed to apply OVN CoPP profile: %v", err)
+			}
+		}()
 	}
 
 	return nil
@@ -0,0 +1,425 @@
+package topology
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/canonical/microcluster/v2/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/bgp"
+)
+
+// ErrRouterNotFound is returned when the graph is requested for a Logical Router that does not exist.
+var ErrRouterNotFound = errors.New("logical router not found")
+
+// Kinds of the nodes in the topology graph.
+const (
+	NodeRouter         = "router"
+	NodeSwitch         = "switch"
+	NodePort           = "port"
+	NodeLocalnet       = "localnet"
+	NodeChassis        = "chassis"
+	NodeHaChassisGroup = "ha-chassis-group"
+)
+
+// Kinds of the edges in the topology graph.
+const (
+	EdgeRouterPort     = "router-port"      // Switch connected to a router
+	EdgePeer           = "peer"             // Router connected directly to another router
+	EdgePort           = "port"             // Port of a switch
+	EdgeLocalnet       = "localnet"         // Localnet port of a switch
+	EdgeGatewayChassis = "gateway-chassis"  // Chassis that can host gateway router port
+	EdgeHaChassisGroup = "ha-chassis-group" // HA chassis group of a gateway router port
+	EdgeHaChassis      = "ha-chassis"       // Chassis that is a member of the HA chassis group
+	EdgeActiveGateway  = "active-gateway"   // Chassis that currently hosts gateway router port
+	EdgeBinding        = "binding"          // Chassis to which a port is bound
+)
+
+// DefaultGraphDepth is a default distance from the selected router up to which nodes are included in
+// the filtered topology graph.
+const DefaultGraphDepth = 2
+
+// graphNBTables lists OVN Northbound tables needed to build the topology graph.
+var graphNBTables = []string{
+	"Logical_Switch", "Logical_Switch_Port", "Logical_Router", "Logical_Router_Port",
+	"Gateway_Chassis", "HA_Chassis_Group", "HA_Chassis",
+}
+
+// graphSBTables lists OVN Southbound tables needed to build the topology graph.
+var graphSBTables = []string{"Chassis", "Port_Binding"}
+
+// stringList returns string atoms from the "column" of the record. Sets are expanded to their members.
+func (r ovsdbRecord) stringList(column string) []string {
+	value, ok := exportValue(r[column], nil)
+	if !ok {
+		return nil
+	}
+
+	if members, ok := value.([]any); ok {
+		result := make([]string, 0, len(members))
+		for _, member := range members {
+			result = append(result, atomString(member))
+		}
+		return result
+	}
+	return []string{atomString(value)}
+}
+
+// stringMap returns value of the map "column" of the record.
+func (r ovsdbRecord) stringMap(column string) map[string]string {
+	result := make(map[string]string)
+	value, _ := exportValue(r[column], nil)
+	if items, ok := value.(map[string]any); ok {
+		for key, item := range items {
+			result[key] = atomString(item)
+		}
+	}
+	return result
+}
+
+// isBgpManaged returns true if the record is tagged as managed by MicroOVN BGP integration.
+func (r ovsdbRecord) isBgpManaged() bool {
+	return r.stringMap("external_ids")[bgp.BgpManagedTag] == "true"
+}
+
+// graphBuilder accumulates nodes and edges of the topology graph.
+type graphBuilder struct {
+	nodes map[string]*types.TopologyNode
+	edges map[types.TopologyEdge]bool
+}
+
+// node adds node of the "kind" with the "name" to the graph, unless it already exists, and returns its ID.
+func (b *graphBuilder) node(kind string, name string) string {
+	id := fmt.Sprintf("%s:%s", kind, name)
+	if _, ok := b.nodes[id]; !ok {
+		b.nodes[id] = &types.TopologyNode{ID: id, Kind: kind, Name: name, Attributes: map[string]string{}}
+	}
+	return id
+}
+
+// edge adds edge of the "kind" between nodes "from" and "to" to the graph.
+func (b *graphBuilder) edge(from string, to string, kind string, label string) {
+	b.edges[types.TopologyEdge{From: from, To: to, Kind: kind, Label: label}] = true
+}
+
+// graph returns the accumulated graph with nodes and edges in a stable order.
+func (b *graphBuilder) graph() types.TopologyGraph {
+	graph := types.TopologyGraph{Nodes: []types.TopologyNode{}, Edges: []types.TopologyEdge{}}
+	for _, node := range b.nodes {
+		graph.Nodes = append(graph.Nodes, *node)
+	}
+	sort.Slice(graph.Nodes, func(i, j int) bool { return graph.Nodes[i].ID < graph.Nodes[j].ID })
+
+	for edge := range b.edges {
+		graph.Edges = append(graph.Edges, edge)
+	}
+	sort.Slice(graph.Edges, func(i, j int) bool {
+		a, b := graph.Edges[i], graph.Edges[j]
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		if a.To != b.To {
+			return a.To < b.To
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Label < b.Label
+	})
+	return graph
+}
+
+// indexByUUID returns map of records keyed by their UUIDs.
+func indexByUUID(records []ovsdbRecord) map[string]ovsdbRecord {
+	index := make(map[string]ovsdbRecord, len(records))
+	for _, record := range records {
+		index[record.uuid()] = record
+	}
+	return index
+}
+
+// buildGraph assembles topology graph from the records of OVN Northbound ("nb") and Southbound ("sb") tables.
+func buildGraph(nb map[string][]ovsdbRecord, sb map[string][]ovsdbRecord) types.TopologyGraph {
+	builder := &graphBuilder{nodes: make(map[string]*types.TopologyNode), edges: make(map[types.TopologyEdge]bool)}
+
+	switchPorts := indexByUUID(nb["Logical_Switch_Port"])
+	routerPorts := indexByUUID(nb["Logical_Router_Port"])
+	gatewayChassis := indexByUUID(nb["Gateway_Chassis"])
+	haGroups := indexByUUID(nb["HA_Chassis_Group"])
+	haChassis := indexByUUID(nb["HA_Chassis"])
+	sbChassis := indexByUUID(sb["Chassis"])
+
+	for _, chassis := range sb["Chassis"] {
+		id := builder.node(NodeChassis, chassis.name())
+		if hostname := atomString(chassis["hostname"]); hostname != "" {
+			builder.nodes[id].Attributes["hostname"] = hostname
+		}
+	}
+
+	routerPortsByName := make(map[string]ovsdbRecord, len(routerPorts))
+	for _, port := range routerPorts {
+		routerPortsByName[port.name()] = port
+	}
+
+	// Routers and their ports
+	portRouters := make(map[string]string) // Maps names of router ports to IDs of their router nodes
+	for _, router := range nb["Logical_Router"] {
+		routerID := builder.node(NodeRouter, router.name())
+		builder.nodes[routerID].Managed = router.isBgpManaged()
+
+		for _, portUUID := range referencedUUIDs(router["ports"]) {
+			port, ok := routerPorts[portUUID]
+			if !ok {
+				continue
+			}
+			portRouters[port.name()] = routerID
+
+			for _, gwUUID := range referencedUUIDs(port["gateway_chassis"]) {
+				gw, ok := gatewayChassis[gwUUID]
+				if !ok {
+					continue
+				}
+				chassisID := builder.node(NodeChassis, atomString(gw["chassis_name"]))
+				builder.edge(routerID, chassisID, EdgeGatewayChassis,
+					fmt.Sprintf("%s (priority %s)", port.name(), atomString(gw["priority"])),
+				)
+			}
+
+			for _, groupUUID := range referencedUUIDs(port["ha_chassis_group"]) {
+				group, ok := haGroups[groupUUID]
+				if !ok {
+					continue
+				}
+				groupID := builder.node(NodeHaChassisGroup, group.name())
+				builder.edge(routerID, groupID, EdgeHaChassisGroup, port.name())
+
+				for _, memberUUID := range referencedUUIDs(group["ha_chassis"]) {
+					member, ok := haChassis[memberUUID]
+					if !ok {
+						continue
+					}
+					chassisID := builder.node(NodeChassis, atomString(member["chassis_name"]))
+					builder.edge(groupID, chassisID, EdgeHaChassis, fmt.Sprintf("priority %s", atomString(member["priority"])))
+				}
+			}
+		}
+	}
+
+	// Router ports connected directly to each other
+	for _, port := range nb["Logical_Router_Port"] {
+		for _, peer := range port.stringList("peer") {
+			from, to := portRouters[port.name()], portRouters[peer]
+			if from == "" || to == "" || port.name() > peer {
+				continue
+			}
+			builder.edge(from, to, EdgePeer, fmt.Sprintf("%s - %s", port.name(), peer))
+		}
+	}
+
+	// Switches and their ports
+	for _, sw := range nb["Logical_Switch"] {
+		switchID := builder.node(NodeSwitch, sw.name())
+		builder.nodes[switchID].Managed = sw.isBgpManaged()
+
+		for _, portUUID := range referencedUUIDs(sw["ports"]) {
+			port, ok := switchPorts[portUUID]
+			if !ok {
+				continue
+			}
+
+			portType := atomString(port["type"])
+			options := port.stringMap("options")
+			switch portType {
+			case "router":
+				routerPort := options["router-port"]
+				routerID, ok := portRouters[routerPort]
+				if !ok {
+					continue
+				}
+				label := routerPort
+				if networks := routerPortsByName[routerPort].stringList("networks"); len(networks) > 0 {
+					label = fmt.Sprintf("%s (%s)", routerPort, strings.Join(networks, ", "))
+				}
+				builder.edge(switchID, routerID, EdgeRouterPort, label)
+			case "localnet":
+				localnetID := builder.node(NodeLocalnet, port.name())
+				builder.nodes[localnetID].Attributes["network_name"] = options["network_name"]
+				builder.edge(switchID, localnetID, EdgeLocalnet, "")
+			default:
+				portID := builder.node(NodePort, port.name())
+				builder.nodes[portID].Managed = port.isBgpManaged()
+				if portType != "" {
+					builder.nodes[portID].Attributes["type"] = portType
+				}
+				if addresses := port.stringList("addresses"); len(addresses) > 0 {
+					builder.nodes[portID].Attributes["addresses"] = strings.Join(addresses, ", ")
+				}
+				builder.edge(switchID, portID, EdgePort, "")
+			}
+		}
+	}
+
+	// Port bindings
+	for _, binding := range sb["Port_Binding"] {
+		chassisUUIDs := referencedUUIDs(binding["chassis"])
+		if len(chassisUUIDs) == 0 {
+			continue
+		}
+
+		chassis, ok := sbChassis[chassisUUIDs[0]]
+		if !ok {
+			continue
+		}
+		chassisID := builder.node(NodeChassis, chassis.name())
+		logicalPort := atomString(binding["logical_port"])
+
+		if atomString(binding["type"]) == "chassisredirect" {
+			routerPort := strings.TrimPrefix(logicalPort, "cr-")
+			if routerID, ok := portRouters[routerPort]; ok {
+				builder.edge(routerID, chassisID, EdgeActiveGateway, routerPort)
+			}
+			continue
+		}
+
+		portID := fmt.Sprintf("%s:%s", NodePort, logicalPort)
+		if _, ok := builder.nodes[portID]; ok {
+			builder.edge(portID, chassisID, EdgeBinding, "")
+		}
+	}
+
+	return builder.graph()
+}
+
+// FilterGraph returns part of the "graph" that contains the router "routerName" and nodes that are at most
+// "depth" edges away from it.
+func FilterGraph(graph types.TopologyGraph, routerName string, depth int) (types.TopologyGraph, error) {
+	rootID := fmt.Sprintf("%s:%s", NodeRouter, routerName)
+	adjacency := make(map[string][]string)
+	for _, edge := range graph.Edges {
+		adjacency[edge.From] = append(adjacency[edge.From], edge.To)
+		adjacency[edge.To] = append(adjacency[edge.To], edge.From)
+	}
+
+	found := false
+	for _, node := range graph.Nodes {
+		if node.ID == rootID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return types.TopologyGraph{}, fmt.Errorf("%w: '%s'", ErrRouterNotFound, routerName)
+	}
+
+	distances := map[string]int{rootID: 0}
+	queue := []string{rootID}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if distances[current] >= depth {
+			continue
+		}
+
+		for _, neighbor := range adjacency[current] {
+			if _, ok := distances[neighbor]; !ok {
+				distances[neighbor] = distances[current] + 1
+				queue = append(queue, neighbor)
+			}
+		}
+	}
+
+	filtered := types.TopologyGraph{Nodes: []types.TopologyNode{}, Edges: []types.TopologyEdge{}}
+	for _, node := range graph.Nodes {
+		if _, ok := distances[node.ID]; ok {
+			filtered.Nodes = append(filtered.Nodes, node)
+		}
+	}
+
+	for _, edge := range graph.Edges {
+		_, fromOk := distances[edge.From]
+		_, toOk := distances[edge.To]
+		if fromOk && toOk {
+			filtered.Edges = append(filtered.Edges, edge)
+		}
+	}
+	return filtered, nil
+}
+
+// Graph builds topology graph from the OVN Northbound and Southbound databases. If "routerName" is not empty,
+// only the neighborhood of the router, up to "depth" edges away from it, is returned.
+func Graph(ctx context.Context, s state.State, routerName string, depth int) (types.TopologyGraph, error) {
+	nb, err := selectTables(ctx, s, nbDatabase, graphNBTables)
+	if err != nil {
+		return types.TopologyGraph{}, fmt.Errorf("failed to read OVN Northbound database: %w", err)
+	}
+
+	sb, err := selectTables(ctx, s, sbDatabase, graphSBTables)
+	if err != nil {
+		return types.TopologyGraph{}, fmt.Errorf("failed to read OVN Southbound database: %w", err)
+	}
+
+	graph := buildGraph(nb, sb)
+	if routerName == "" {
+		return graph, nil
+	}
+	return FilterGraph(graph, routerName, depth)
+}
+
+// dotShapes maps kinds of the nodes to their shapes in the DOT output.
+var dotShapes = map[string]string{
+	NodeRouter:         "box",
+	NodeSwitch:         "ellipse",
+	NodePort:           "plaintext",
+	NodeLocalnet:       "cds",
+	NodeChassis:        "box3d",
+	NodeHaChassisGroup: "diamond",
+}
+
+// dotQuote returns "value" as a quoted DOT string. Newlines are preserved as line breaks in labels.
+func dotQuote(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + replacer.Replace(value) + `"`
+}
+
+// RenderDot renders the "graph" in the Graphviz DOT format. Nodes managed by MicroOVN BGP integration are drawn
+// with dashed lines.
+func RenderDot(graph types.TopologyGraph) string {
+	var builder strings.Builder
+	builder.WriteString("graph ovn {\n")
+	builder.WriteString("  rankdir=LR;\n")
+
+	for _, node := range graph.Nodes {
+		label := node.Name
+		if hostname := node.Attributes["hostname"]; hostname != "" {
+			label = fmt.Sprintf("%s\n%s", hostname, node.Name)
+		}
+		if network := node.Attributes["network_name"]; network != "" {
+			label = fmt.Sprintf("%s\nnetwork: %s", node.Name, network)
+		}
+
+		style := "solid"
+		if node.Managed {
+			style = "dashed"
+			label = fmt.Sprintf("%s\n(BGP)", label)
+		}
+
+		fmt.Fprintf(&builder, "  %s [label=%s, shape=%s, style=%s];\n",
+			dotQuote(node.ID), dotQuote(label), dotShapes[node.Kind], style,
+		)
+	}
+
+	for _, edge := range graph.Edges {
+		label := edge.Kind
+		if edge.Label != "" {
+			label = fmt.Sprintf("%s: %s", edge.Kind, edge.Label)
+		}
+		fmt.Fprintf(&builder, "  %s -- %s [label=%s];\n", dotQuote(edge.From), dotQuote(edge.To), dotQuote(label))
+	}
+
+	builder.WriteString("}\n")
+	return builder.String()
+}
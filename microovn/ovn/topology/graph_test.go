@@ -0,0 +1,209 @@
+package topology
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/canonical/microovn/microovn/api/types"
+)
+
+// testGraphNB is an OVSDB "select" output of Northbound tables with a gateway router connected to a switch
+// with a VM and to a BGP-managed external switch.
+const testGraphNB = `{
+  "Logical_Switch": [
+    {"_uuid": ["uuid", "ls-1"], "name": "sw0", "ports": ["set", [["uuid", "lsp-1"], ["uuid", "lsp-2"]]],
+     "external_ids": ["map", []]},
+    {"_uuid": ["uuid", "ls-2"], "name": "ext", "ports": ["set", [["uuid", "lsp-3"], ["uuid", "lsp-4"]]],
+     "external_ids": ["map", [["microovn-bgp-managed", "true"]]]}
+  ],
+  "Logical_Switch_Port": [
+    {"_uuid": ["uuid", "lsp-1"], "name": "vm1", "type": "", "addresses": "00:00:00:00:00:01 10.0.0.10",
+     "options": ["map", []], "external_ids": ["map", []]},
+    {"_uuid": ["uuid", "lsp-2"], "name": "sw0-lr0", "type": "router", "addresses": "router",
+     "options": ["map", [["router-port", "lr0-sw0"]]], "external_ids": ["map", []]},
+    {"_uuid": ["uuid", "lsp-3"], "name": "ext-lr0", "type": "router", "addresses": "router",
+     "options": ["map", [["router-port", "lr0-ext"]]], "external_ids": ["map", []]},
+    {"_uuid": ["uuid", "lsp-4"], "name": "ext-localnet", "type": "localnet", "addresses": "unknown",
+     "options": ["map", [["network_name", "physnet1"]]], "external_ids": ["map", []]}
+  ],
+  "Logical_Router": [
+    {"_uuid": ["uuid", "lr-1"], "name": "lr0", "ports": ["set", [["uuid", "lrp-1"], ["uuid", "lrp-2"]]],
+     "external_ids": ["map", []]},
+    {"_uuid": ["uuid", "lr-2"], "name": "lr1", "ports": ["uuid", "lrp-3"], "external_ids": ["map", []]}
+  ],
+  "Logical_Router_Port": [
+    {"_uuid": ["uuid", "lrp-1"], "name": "lr0-sw0", "networks": "10.0.0.1/24", "peer": ["set", []],
+     "gateway_chassis": ["set", []], "ha_chassis_group": ["set", []]},
+    {"_uuid": ["uuid", "lrp-2"], "name": "lr0-ext", "networks": "192.0.2.1/24", "peer": ["set", []],
+     "gateway_chassis": ["uuid", "gw-1"], "ha_chassis_group": ["uuid", "ha-1"]},
+    {"_uuid": ["uuid", "lrp-3"], "name": "lr1-lr0", "networks": "172.16.0.1/30", "peer": "lr0-lr1",
+     "gateway_chassis": ["set", []], "ha_chassis_group": ["set", []]}
+  ],
+  "Gateway_Chassis": [
+    {"_uuid": ["uuid", "gw-1"], "chassis_name": "ch1", "priority": 10}
+  ],
+  "HA_Chassis_Group": [
+    {"_uuid": ["uuid", "ha-1"], "name": "ha0", "ha_chassis": ["set", [["uuid", "hac-1"], ["uuid", "hac-2"]]]}
+  ],
+  "HA_Chassis": [
+    {"_uuid": ["uuid", "hac-1"], "chassis_name": "ch1", "priority": 20},
+    {"_uuid": ["uuid", "hac-2"], "chassis_name": "ch2", "priority": 10}
+  ]
+}`
+
+// testGraphSB is an OVSDB "select" output of Southbound tables matching testGraphNB.
+const testGraphSB = `{
+  "Chassis": [
+    {"_uuid": ["uuid", "c-1"], "name": "ch1", "hostname": "node1"},
+    {"_uuid": ["uuid", "c-2"], "name": "ch2", "hostname": "node2"}
+  ],
+  "Port_Binding": [
+    {"_uuid": ["uuid", "pb-1"], "logical_port": "vm1", "type": "", "chassis": ["uuid", "c-2"]},
+    {"_uuid": ["uuid", "pb-2"], "logical_port": "cr-lr0-ext", "type": "chassisredirect", "chassis": ["uuid", "c-1"]},
+    {"_uuid": ["uuid", "pb-3"], "logical_port": "ext-localnet", "type": "localnet", "chassis": ["set", []]}
+  ]
+}`
+
+// loadTestGraph builds topology graph from testGraphNB and testGraphSB.
+func loadTestGraph(t *testing.T) types.TopologyGraph {
+	t.Helper()
+	var nb, sb map[string][]ovsdbRecord
+	err := decodeJSON([]byte(testGraphNB), &nb)
+	if err != nil {
+		t.Fatalf("Failed to parse test NB records: %v", err)
+	}
+
+	err = decodeJSON([]byte(testGraphSB), &sb)
+	if err != nil {
+		t.Fatalf("Failed to parse test SB records: %v", err)
+	}
+	return buildGraph(nb, sb)
+}
+
+// hasEdge returns true if the "graph" contains edge of the "kind" between nodes "from" and "to".
+func hasEdge(graph types.TopologyGraph, from string, to string, kind string) bool {
+	for _, edge := range graph.Edges {
+		if edge.From == from && edge.To == to && edge.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// findNode returns node with the "id" from the "graph".
+func findNode(graph types.TopologyGraph, id string) *types.TopologyNode {
+	for i := range graph.Nodes {
+		if graph.Nodes[i].ID == id {
+			return &graph.Nodes[i]
+		}
+	}
+	return nil
+}
+
+func TestUnexported_buildGraph(t *testing.T) {
+	graph := loadTestGraph(t)
+
+	expectedEdges := []types.TopologyEdge{
+		{From: "switch:sw0", To: "router:lr0", Kind: EdgeRouterPort},
+		{From: "switch:ext", To: "router:lr0", Kind: EdgeRouterPort},
+		{From: "switch:sw0", To: "port:vm1", Kind: EdgePort},
+		{From: "switch:ext", To: "localnet:ext-localnet", Kind: EdgeLocalnet},
+		{From: "router:lr0", To: "chassis:ch1", Kind: EdgeGatewayChassis},
+		{From: "router:lr0", To: "ha-chassis-group:ha0", Kind: EdgeHaChassisGroup},
+		{From: "ha-chassis-group:ha0", To: "chassis:ch1", Kind: EdgeHaChassis},
+		{From: "ha-chassis-group:ha0", To: "chassis:ch2", Kind: EdgeHaChassis},
+		{From: "router:lr0", To: "chassis:ch1", Kind: EdgeActiveGateway},
+		{From: "port:vm1", To: "chassis:ch2", Kind: EdgeBinding},
+	}
+	for _, edge := range expectedEdges {
+		if !hasEdge(graph, edge.From, edge.To, edge.Kind) {
+			t.Errorf("Expected %s edge from '%s' to '%s'", edge.Kind, edge.From, edge.To)
+		}
+	}
+
+	if len(graph.Edges) != len(expectedEdges) {
+		t.Errorf("Expected %d edges, got %d: %v", len(expectedEdges), len(graph.Edges), graph.Edges)
+	}
+
+	ext := findNode(graph, "switch:ext")
+	if ext == nil || !ext.Managed {
+		t.Errorf("Expected switch 'ext' to be marked as BGP-managed")
+	}
+
+	chassis := findNode(graph, "chassis:ch1")
+	if chassis == nil || chassis.Attributes["hostname"] != "node1" {
+		t.Errorf("Expected chassis 'ch1' with hostname 'node1', got %v", chassis)
+	}
+
+	localnet := findNode(graph, "localnet:ext-localnet")
+	if localnet == nil || localnet.Attributes["network_name"] != "physnet1" {
+		t.Errorf("Expected localnet port with network 'physnet1', got %v", localnet)
+	}
+}
+
+func TestFilterGraph(t *testing.T) {
+	graph := loadTestGraph(t)
+
+	filtered, err := FilterGraph(graph, "lr0", 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, id := range []string{"router:lr0", "switch:sw0", "switch:ext", "chassis:ch1", "ha-chassis-group:ha0"} {
+		if findNode(filtered, id) == nil {
+			t.Errorf("Expected node '%s' in the neighborhood of 'lr0'", id)
+		}
+	}
+
+	for _, id := range []string{"port:vm1", "chassis:ch2", "router:lr1"} {
+		if findNode(filtered, id) != nil {
+			t.Errorf("Expected node '%s' to be filtered out", id)
+		}
+	}
+
+	filtered, err = FilterGraph(graph, "lr0", 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if findNode(filtered, "chassis:ch2") == nil || findNode(filtered, "port:vm1") == nil {
+		t.Errorf("Expected depth 2 to include HA chassis and switch ports")
+	}
+
+	_, err = FilterGraph(graph, "missing", 1)
+	if !errors.Is(err, ErrRouterNotFound) {
+		t.Errorf("Expected ErrRouterNotFound for unknown router, got %v", err)
+	}
+}
+
+func TestRenderDot(t *testing.T) {
+	graph := types.TopologyGraph{
+		Nodes: []types.TopologyNode{
+			{ID: "router:lr0", Kind: NodeRouter, Name: "lr0"},
+			{ID: "switch:ext", Kind: NodeSwitch, Name: "ext", Managed: true},
+			{ID: "chassis:ch1", Kind: NodeChassis, Name: "ch1", Attributes: map[string]string{"hostname": "node1"}},
+		},
+		Edges: []types.TopologyEdge{
+			{From: "switch:ext", To: "router:lr0", Kind: EdgeRouterPort, Label: "lr0-ext"},
+		},
+	}
+
+	dot := RenderDot(graph)
+	expectedLines := []string{
+		"graph ovn {",
+		`  "router:lr0" [label="lr0", shape=box, style=solid];`,
+		`  "switch:ext" [label="ext\n(BGP)", shape=ellipse, style=dashed];`,
+		`  "chassis:ch1" [label="node1\nch1", shape=box3d, style=solid];`,
+		`  "switch:ext" -- "router:lr0" [label="router-port: lr0-ext"];`,
+	}
+	for _, line := range expectedLines {
+		if !strings.Contains(dot, line+"\n") {
+			t.Errorf("Expected line '%s' in DOT output:\n%s", line, dot)
+		}
+	}
+
+	if dotQuote(`a "b" \ c`) != `"a \"b\" \\ c"` {
+		t.Errorf("Unexpected quoting: %s", dotQuote(`a "b" \ c`))
+	}
+}
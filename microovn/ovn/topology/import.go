@@ -0,0 +1,212 @@
+package topology
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/canonical/microcluster/v2/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+)
+
+// importPlan holds OVSDB operations that import a TopologyDocument, together with names of the records that
+// must not already exist in the OVN Northbound database.
+type importPlan struct {
+	Operations []any
+	Names      map[string][]string // Maps table names to names of the records that are created in them
+	Created    map[string]int      // Maps table names to number of records that are created in them
+}
+
+// getUUIDName returns name under which the record with the "index" is known within the import transaction.
+func getUUIDName(table string, index int) string {
+	return fmt.Sprintf("%s_%d", strings.ToLower(table), index)
+}
+
+// importAtom converts single atom from the portable representation to OVSDB JSON. If "refTable" is not empty,
+// the atom is an ID of a record in that table and is converted to the reference to that record.
+func importAtom(value any, refTable string, uuidNames map[string]map[string]string) (any, error) {
+	if refTable != "" {
+		id, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("reference '%v' is not a string", value)
+		}
+
+		uuidName, ok := uuidNames[refTable][id]
+		if !ok {
+			return nil, fmt.Errorf("referenced record '%s' not found in table '%s'", id, refTable)
+		}
+		return []any{"named-uuid", uuidName}, nil
+	}
+
+	switch value.(type) {
+	case []any, map[string]any:
+		return nil, fmt.Errorf("nested value '%v' is not supported", value)
+	}
+	return value, nil
+}
+
+// importValue converts column value from the portable representation to OVSDB JSON. Lists are converted to sets
+// and objects to maps.
+func importValue(value any, refTable string, uuidNames map[string]map[string]string) (any, error) {
+	switch typedValue := value.(type) {
+	case []any:
+		members := make([]any, 0, len(typedValue))
+		for _, member := range typedValue {
+			converted, err := importAtom(member, refTable, uuidNames)
+			if err != nil {
+				return nil, err
+			}
+			members = append(members, converted)
+		}
+		return []any{"set", members}, nil
+	case map[string]any:
+		keys := make([]string, 0, len(typedValue))
+		for key := range typedValue {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		items := make([]any, 0, len(keys))
+		for _, key := range keys {
+			converted, err := importAtom(typedValue[key], "", uuidNames)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, []any{key, converted})
+		}
+		return []any{"map", items}, nil
+	default:
+		return importAtom(value, refTable, uuidNames)
+	}
+}
+
+// buildImportPlan converts "document" into OVSDB operations that create all of its records in a single
+// transaction. For every named record, the transaction contains a "wait" operation that aborts the
+// transaction if a record with the same name already exists.
+func buildImportPlan(document types.TopologyDocument) (importPlan, error) {
+	plan := importPlan{Names: make(map[string][]string), Created: make(map[string]int)}
+
+	if document.Version != DocumentVersion {
+		return plan, fmt.Errorf("unsupported document version %d (expected %d)", document.Version, DocumentVersion)
+	}
+
+	for table := range document.Tables {
+		if getTableSpec(table) == nil {
+			return plan, fmt.Errorf("table '%s' is not supported. Supported tables are: %s", table, strings.Join(tableNames(), ", "))
+		}
+	}
+
+	// Assign names to all records first, so that references can be resolved regardless of the record order
+	uuidNames := make(map[string]map[string]string)
+	for _, spec := range tableSpecs {
+		uuidNames[spec.Name] = make(map[string]string)
+		for i, record := range document.Tables[spec.Name] {
+			if record.ID == "" {
+				return plan, fmt.Errorf("record %d in table '%s' has no ID", i, spec.Name)
+			}
+
+			if _, ok := uuidNames[spec.Name][record.ID]; ok {
+				return plan, fmt.Errorf("ID '%s' is used multiple times in table '%s'", record.ID, spec.Name)
+			}
+			uuidNames[spec.Name][record.ID] = getUUIDName(spec.Name, i)
+		}
+	}
+
+	plan.Operations = append(plan.Operations, map[string]any{"op": "comment", "comment": "microovn topology import"})
+	for _, spec := range tableSpecs {
+		for i, record := range document.Tables[spec.Name] {
+			if spec.Named {
+				name, ok := record.Columns["name"].(string)
+				if !ok || name == "" {
+					return plan, fmt.Errorf("record '%s' in table '%s' has no name", record.ID, spec.Name)
+				}
+
+				plan.Names[spec.Name] = append(plan.Names[spec.Name], name)
+				plan.Operations = append(plan.Operations, map[string]any{
+					"op":      "wait",
+					"table":   spec.Name,
+					"timeout": 0,
+					"where":   []any{[]any{"name", "==", name}},
+					"columns": []string{"name"},
+					"until":   "==",
+					"rows":    []any{},
+				})
+			}
+
+			row := make(map[string]any, len(record.Columns))
+			for column, value := range record.Columns {
+				converted, err := importValue(value, spec.refTable(column), uuidNames)
+				if err != nil {
+					return plan, fmt.Errorf("invalid column '%s' of record '%s' in table '%s': %w", column, record.ID, spec.Name, err)
+				}
+				row[column] = converted
+			}
+
+			plan.Operations = append(plan.Operations, map[string]any{
+				"op":        "insert",
+				"table":     spec.Name,
+				"row":       row,
+				"uuid-name": getUUIDName(spec.Name, i),
+			})
+			plan.Created[spec.Name]++
+		}
+	}
+
+	return plan, nil
+}
+
+// findConflicts returns descriptions of records from the "plan" whose names are already used in the
+// OVN Northbound database "records".
+func findConflicts(plan importPlan, records map[string][]ovsdbRecord) []string {
+	var conflicts []string
+	for table, names := range plan.Names {
+		existing := make(map[string]bool, len(records[table]))
+		for _, record := range records[table] {
+			existing[record.name()] = true
+		}
+
+		for _, name := range names {
+			if existing[name] {
+				conflicts = append(conflicts, fmt.Sprintf("%s '%s'", table, name))
+			}
+		}
+	}
+	sort.Strings(conflicts)
+	return conflicts
+}
+
+// Import creates all records from the "document" in the OVN Northbound database in a single transaction. The
+// import fails without any changes if a record with the same name as any of the imported records already exists.
+func Import(ctx context.Context, s state.State, document types.TopologyDocument) (types.TopologyImportResult, error) {
+	plan, err := buildImportPlan(document)
+	if err != nil {
+		return types.TopologyImportResult{}, err
+	}
+
+	tables := make([]string, 0, len(plan.Names))
+	for table := range plan.Names {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+
+	// Conflicts are checked upfront to report them in a readable way. The "wait" operations in the
+	// transaction guard against conflicting records created in the meantime.
+	existing, err := selectTables(ctx, s, nbDatabase, tables, "name")
+	if err != nil {
+		return types.TopologyImportResult{}, fmt.Errorf("failed to read OVN Northbound database: %w", err)
+	}
+
+	conflicts := findConflicts(plan, existing)
+	if len(conflicts) > 0 {
+		return types.TopologyImportResult{}, fmt.Errorf("records already exist: %s", strings.Join(conflicts, ", "))
+	}
+
+	_, err = transact(ctx, s, nbDatabase, plan.Operations)
+	if err != nil {
+		return types.TopologyImportResult{}, fmt.Errorf("failed to import topology: %w", err)
+	}
+
+	return types.TopologyImportResult{Created: plan.Created}, nil
+}
@@ -0,0 +1,448 @@
+// Package topology implements export and import of logical objects from the OVN Northbound database, so that
+// logical topology can be moved between OVN deployments.
+package topology
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/canonical/microcluster/v2/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	ovnCmd "github.com/canonical/microovn/microovn/ovn/cmd"
+)
+
+// DocumentVersion is a version of the TopologyDocument format produced by this package.
+const DocumentVersion = 1
+
+// Names of the OVN databases.
+const (
+	nbDatabase = "OVN_Northbound"
+	sbDatabase = "OVN_Southbound"
+)
+
+// tableSpec describes how records of a single OVN Northbound table are exported and imported.
+type tableSpec struct {
+	Name     string            // Name of the table
+	Named    bool              // True if records are identified by the "name" column
+	Root     bool              // True if records may be selected for export directly
+	Refs     map[string]string // Columns that reference records in other tables. Referenced records are exported as well
+	WeakRefs map[string]string // Columns that reference records in other tables, but only if they are exported anyway
+	Skip     []string          // Columns populated by OVN itself that are not exported
+}
+
+// tableSpecs lists tables that can be exported. Tables are ordered so that referenced records are inserted before
+// records that reference them.
+var tableSpecs = []tableSpec{
+	{Name: "Logical_Switch_Port", Named: true, Skip: []string{"up", "dynamic_addresses", "tag"}},
+	{Name: "Logical_Router_Port", Named: true, Skip: []string{"status"}},
+	{Name: "ACL"},
+	{Name: "NAT", WeakRefs: map[string]string{"gateway_port": "Logical_Router_Port"}},
+	{Name: "Logical_Router_Static_Route"},
+	{Name: "Load_Balancer", Named: true, Root: true},
+	{
+		Name:  "Logical_Switch",
+		Named: true,
+		Root:  true,
+		Refs: map[string]string{
+			"ports":         "Logical_Switch_Port",
+			"acls":          "ACL",
+			"load_balancer": "Load_Balancer",
+		},
+	},
+	{
+		Name:  "Logical_Router",
+		Named: true,
+		Root:  true,
+		Refs: map[string]string{
+			"ports":         "Logical_Router_Port",
+			"nat":           "NAT",
+			"static_routes": "Logical_Router_Static_Route",
+			"load_balancer": "Load_Balancer",
+		},
+	},
+	{
+		Name:     "Port_Group",
+		Named:    true,
+		Root:     true,
+		Refs:     map[string]string{"acls": "ACL"},
+		WeakRefs: map[string]string{"ports": "Logical_Switch_Port"},
+	},
+}
+
+// getTableSpec returns specification of the "table", or nil if the table can't be exported.
+func getTableSpec(table string) *tableSpec {
+	for i := range tableSpecs {
+		if tableSpecs[i].Name == table {
+			return &tableSpecs[i]
+		}
+	}
+	return nil
+}
+
+// refTable returns name of the table referenced by the "column", or an empty string if the column is not
+// a reference.
+func (t *tableSpec) refTable(column string) string {
+	if table, ok := t.Refs[column]; ok {
+		return table
+	}
+	return t.WeakRefs[column]
+}
+
+// tableNames returns names of the tables that can be exported.
+func tableNames() []string {
+	names := make([]string, 0, len(tableSpecs))
+	for _, spec := range tableSpecs {
+		names = append(names, spec.Name)
+	}
+	return names
+}
+
+// ovsdbRecord is a single record of the OVN database as returned by the OVSDB "select" operation.
+type ovsdbRecord map[string]any
+
+// uuid returns UUID of the record.
+func (r ovsdbRecord) uuid() string {
+	return atomString(r["_uuid"])
+}
+
+// name returns value of the "name" column of the record.
+func (r ovsdbRecord) name() string {
+	return atomString(r["name"])
+}
+
+// atomString returns string representation of the OVSDB JSON atom. UUIDs are returned without the "uuid" tag.
+func atomString(value any) string {
+	switch atom := value.(type) {
+	case string:
+		return atom
+	case json.Number:
+		return atom.String()
+	case bool:
+		return fmt.Sprintf("%t", atom)
+	case []any:
+		if len(atom) == 2 && (atom[0] == "uuid" || atom[0] == "named-uuid") {
+			return atomString(atom[1])
+		}
+	}
+	return ""
+}
+
+// referencedUUIDs returns UUIDs referenced by the OVSDB JSON value of a reference column.
+func referencedUUIDs(value any) []string {
+	pair, ok := value.([]any)
+	if !ok || len(pair) != 2 {
+		return nil
+	}
+
+	if pair[0] == "uuid" {
+		return []string{atomString(pair[1])}
+	}
+
+	var uuids []string
+	if members, ok := pair[1].([]any); ok && pair[0] == "set" {
+		for _, member := range members {
+			uuids = append(uuids, referencedUUIDs(member)...)
+		}
+	}
+	return uuids
+}
+
+// decodeJSON decodes "data" into "target", preserving exact representation of numbers.
+func decodeJSON(data []byte, target any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(target)
+}
+
+// transact executes OVSDB "operations" against the OVN database "db" and returns results of the individual
+// operations. An error is returned if any of the operations failed.
+func transact(ctx context.Context, s state.State, db string, operations []any) ([]map[string]any, error) {
+	transaction, err := json.Marshal(append([]any{db}, operations...))
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize OVSDB transaction: %w", err)
+	}
+
+	var out string
+	if db == sbDatabase {
+		out, err = ovnCmd.SBTransact(ctx, s, string(transaction))
+	} else {
+		out, err = ovnCmd.NBTransact(ctx, s, string(transaction))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var results []map[string]any
+	err = decodeJSON([]byte(out), &results)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse OVSDB transaction result: %w", err)
+	}
+
+	for i, result := range results {
+		if result == nil || result["error"] == nil {
+			continue
+		}
+
+		operation := "commit"
+		if i < len(operations) {
+			if op, ok := operations[i].(map[string]any); ok {
+				operation = fmt.Sprintf("%v on table %v", op["op"], op["table"])
+			}
+		}
+		return results, fmt.Errorf("OVSDB operation '%s' failed: %v: %v", operation, result["error"], result["details"])
+	}
+
+	return results, nil
+}
+
+// selectTables reads all records from the "tables" of the OVN database "db".
+func selectTables(ctx context.Context, s state.State, db string, tables []string, columns ...string) (map[string][]ovsdbRecord, error) {
+	operations := make([]any, 0, len(tables))
+	for _, table := range tables {
+		operation := map[string]any{"op": "select", "table": table, "where": []any{}}
+		if len(columns) > 0 {
+			operation["columns"] = columns
+		}
+		operations = append(operations, operation)
+	}
+
+	results, err := transact(ctx, s, db, operations)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(results) < len(tables) {
+		return nil, fmt.Errorf("unexpected number of OVSDB results: %d", len(results))
+	}
+
+	records := make(map[string][]ovsdbRecord, len(tables))
+	for i, table := range tables {
+		rows, _ := results[i]["rows"].([]any)
+		for _, row := range rows {
+			if record, ok := row.(map[string]any); ok {
+				records[table] = append(records[table], record)
+			}
+		}
+	}
+	return records, nil
+}
+
+// matchesSelector returns true if the "record" has one of the "names" and all "externalIDs". Empty selector
+// matches all records.
+func matchesSelector(record ovsdbRecord, names []string, externalIDs map[string]string) bool {
+	if len(names) > 0 && !slices.Contains(names, record.name()) {
+		return false
+	}
+
+	if len(externalIDs) > 0 {
+		recordIDs, _ := exportValue(record["external_ids"], nil)
+		recordMap, _ := recordIDs.(map[string]any)
+		for key, value := range externalIDs {
+			if recordMap[key] != value {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// exportValue converts OVSDB JSON value into its portable representation. Sets are converted to lists, maps to
+// objects and UUIDs to IDs of the exported records found in "exportedIDs". References to records that are not
+// exported are omitted. The second return value is false if the whole value should be omitted.
+func exportValue(value any, exportedIDs map[string]string) (any, bool) {
+	pair, ok := value.([]any)
+	if !ok || len(pair) != 2 {
+		return value, true
+	}
+
+	switch pair[0] {
+	case "uuid":
+		id, ok := exportedIDs[atomString(pair[1])]
+		return id, ok
+	case "set":
+		members, _ := pair[1].([]any)
+		result := make([]any, 0, len(members))
+		for _, member := range members {
+			if converted, ok := exportValue(member, exportedIDs); ok {
+				result = append(result, converted)
+			}
+		}
+		return result, len(result) > 0
+	case "map":
+		items, _ := pair[1].([]any)
+		result := make(map[string]any, len(items))
+		for _, item := range items {
+			keyValue, ok := item.([]any)
+			if !ok || len(keyValue) != 2 {
+				continue
+			}
+			if converted, ok := exportValue(keyValue[1], exportedIDs); ok {
+				result[atomString(keyValue[0])] = converted
+			}
+		}
+		return result, len(result) > 0
+	default:
+		return value, true
+	}
+}
+
+// buildDocument assembles TopologyDocument from the OVN Northbound "records". Root records that match the
+// selector from the "request" are exported, together with all records they reference. References to tables
+// that can't be exported (e.g. "copp" or "dns_records") are omitted and reported in the document warnings.
+func buildDocument(records map[string][]ovsdbRecord, request types.TopologyExportRequest) types.TopologyDocument {
+	recordTables := make(map[string]string)
+	recordsByUUID := make(map[string]ovsdbRecord)
+	for table, tableRecords := range records {
+		for _, record := range tableRecords {
+			recordTables[record.uuid()] = table
+			recordsByUUID[record.uuid()] = record
+		}
+	}
+
+	// Select root records and everything they reference
+	var queue []string
+	for _, spec := range tableSpecs {
+		if !spec.Root {
+			continue
+		}
+
+		roots := slices.Clone(records[spec.Name])
+		sort.Slice(roots, func(i, j int) bool { return roots[i].name() < roots[j].name() })
+		for _, record := range roots {
+			if matchesSelector(record, request.Names, request.ExternalIDs) {
+				queue = append(queue, record.uuid())
+			}
+		}
+	}
+
+	exported := make(map[string]bool)
+	var order []string
+	for len(queue) > 0 {
+		uuid := queue[0]
+		queue = queue[1:]
+		if exported[uuid] {
+			continue
+		}
+		exported[uuid] = true
+		order = append(order, uuid)
+
+		spec := getTableSpec(recordTables[uuid])
+		columns := make([]string, 0, len(spec.Refs))
+		for column := range spec.Refs {
+			columns = append(columns, column)
+		}
+		sort.Strings(columns)
+		for _, column := range columns {
+			for _, referenced := range referencedUUIDs(recordsByUUID[uuid][column]) {
+				if _, ok := recordsByUUID[referenced]; ok {
+					queue = append(queue, referenced)
+				}
+			}
+		}
+	}
+
+	// Assign IDs to the exported records
+	exportedIDs := make(map[string]string, len(order))
+	usedIDs := make(map[string]map[string]bool)
+	counters := make(map[string]int)
+	for _, uuid := range order {
+		table := recordTables[uuid]
+		if usedIDs[table] == nil {
+			usedIDs[table] = make(map[string]bool)
+		}
+
+		var id string
+		if getTableSpec(table).Named && recordsByUUID[uuid].name() != "" {
+			id = recordsByUUID[uuid].name()
+			for suffix := 2; usedIDs[table][id]; suffix++ {
+				id = fmt.Sprintf("%s-%d", recordsByUUID[uuid].name(), suffix)
+			}
+		} else {
+			for id == "" || usedIDs[table][id] {
+				counters[table]++
+				id = fmt.Sprintf("%s-%d", strings.ToLower(strings.ReplaceAll(table, "_", "-")), counters[table])
+			}
+		}
+		usedIDs[table][id] = true
+		exportedIDs[uuid] = id
+	}
+
+	document := types.TopologyDocument{Version: DocumentVersion, Tables: make(map[string][]types.TopologyRecord)}
+	for _, uuid := range order {
+		table := recordTables[uuid]
+		spec := getTableSpec(table)
+		exportedRecord := types.TopologyRecord{ID: exportedIDs[uuid], Columns: make(map[string]any)}
+		for column, value := range recordsByUUID[uuid] {
+			if strings.HasPrefix(column, "_") || slices.Contains(spec.Skip, column) {
+				continue
+			}
+
+			// References to tables that are not supported by the export are dropped
+			if spec.refTable(column) == "" && len(referencedUUIDs(value)) > 0 {
+				document.Warnings = append(document.Warnings, fmt.Sprintf(
+					"%s '%s': column '%s' references records that can't be exported, it was omitted",
+					table, exportedRecord.ID, column,
+				))
+				continue
+			}
+
+			if converted, ok := exportValue(value, exportedIDs); ok {
+				exportedRecord.Columns[column] = converted
+			}
+		}
+		document.Tables[table] = append(document.Tables[table], exportedRecord)
+	}
+
+	sort.Strings(document.Warnings)
+	return document
+}
+
+// Export reads logical objects selected by the "request" from the OVN Northbound database and returns them as
+// a portable TopologyDocument.
+func Export(ctx context.Context, s state.State, request types.TopologyExportRequest) (types.TopologyDocument, error) {
+	records, err := selectTables(ctx, s, nbDatabase, tableNames())
+	if err != nil {
+		return types.TopologyDocument{}, fmt.Errorf("failed to read OVN Northbound database: %w", err)
+	}
+
+	return buildDocument(records, request), nil
+}
+
+// normalizeValue converts integral floating point numbers in the "value" to integers.
+func normalizeValue(value any) any {
+	switch typedValue := value.(type) {
+	case float64:
+		if typedValue == math.Trunc(typedValue) && math.Abs(typedValue) < math.MaxInt64 {
+			return int64(typedValue)
+		}
+	case []any:
+		for i, member := range typedValue {
+			typedValue[i] = normalizeValue(member)
+		}
+	case map[string]any:
+		for key, member := range typedValue {
+			typedValue[key] = normalizeValue(member)
+		}
+	}
+	return value
+}
+
+// NormalizeDocument converts integral floating point numbers in the "document" to integers. Numbers in the
+// document received over the API are decoded as floats, which would otherwise be serialized in the exponent
+// notation that OVSDB does not accept for integer columns.
+func NormalizeDocument(document *types.TopologyDocument) {
+	for _, records := range document.Tables {
+		for _, record := range records {
+			for column, value := range record.Columns {
+				record.Columns[column] = normalizeValue(value)
+			}
+		}
+	}
+}
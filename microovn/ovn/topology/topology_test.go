@@ -0,0 +1,258 @@
+package topology
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/canonical/microovn/microovn/api/types"
+)
+
+// testRecords is an OVSDB "select" output of tables with a single switch connected to a router.
+const testRecords = `{
+  "Logical_Switch": [
+    {"_uuid": ["uuid", "ls-1"], "_version": ["uuid", "v1"], "name": "sw0",
+     "ports": ["set", [["uuid", "lsp-1"], ["uuid", "lsp-2"]]], "acls": ["uuid", "acl-1"],
+     "load_balancer": ["set", []], "other_config": ["map", [["subnet", "10.0.0.0/24"]]],
+     "external_ids": ["map", [["tenant", "blue"]]], "dns_records": ["uuid", "dns-1"]},
+    {"_uuid": ["uuid", "ls-2"], "name": "other", "ports": ["set", []], "acls": ["set", []],
+     "external_ids": ["map", []]}
+  ],
+  "Logical_Switch_Port": [
+    {"_uuid": ["uuid", "lsp-1"], "name": "vm1", "addresses": "00:00:00:00:00:01 10.0.0.10",
+     "up": true, "tag_request": ["set", []], "dhcpv4_options": ["uuid", "dhcp-1"]},
+    {"_uuid": ["uuid", "lsp-2"], "name": "sw0-lr0", "type": "router",
+     "options": ["map", [["router-port", "lr0-sw0"]]]},
+    {"_uuid": ["uuid", "lsp-3"], "name": "foreign"}
+  ],
+  "ACL": [
+    {"_uuid": ["uuid", "acl-1"], "priority": 1000, "direction": "to-lport", "match": "ip4", "action": "allow"}
+  ],
+  "Logical_Router": [
+    {"_uuid": ["uuid", "lr-1"], "name": "lr0", "ports": ["uuid", "lrp-1"], "nat": ["uuid", "nat-1"],
+     "external_ids": ["map", [["tenant", "blue"]]]}
+  ],
+  "Logical_Router_Port": [
+    {"_uuid": ["uuid", "lrp-1"], "name": "lr0-sw0", "mac": "00:00:00:00:ff:01", "networks": "10.0.0.1/24",
+     "status": ["map", [["hosting-chassis", "ch1"]]]}
+  ],
+  "NAT": [
+    {"_uuid": ["uuid", "nat-1"], "type": "snat", "logical_ip": "10.0.0.0/24", "external_ip": "192.0.2.10",
+     "gateway_port": ["uuid", "lrp-1"]}
+  ],
+  "Port_Group": [
+    {"_uuid": ["uuid", "pg-1"], "name": "pg_web", "ports": ["set", [["uuid", "lsp-1"], ["uuid", "lsp-3"]]],
+     "acls": ["set", []], "external_ids": ["map", [["tenant", "blue"]]]}
+  ]
+}`
+
+// loadTestRecords parses testRecords.
+func loadTestRecords(t *testing.T) map[string][]ovsdbRecord {
+	t.Helper()
+	var records map[string][]ovsdbRecord
+	err := decodeJSON([]byte(testRecords), &records)
+	if err != nil {
+		t.Fatalf("Failed to parse test records: %v", err)
+	}
+	return records
+}
+
+func TestUnexported_exportValue(t *testing.T) {
+	exportedIDs := map[string]string{"a": "port-a"}
+	testCases := []struct {
+		value    any
+		expected any
+		keep     bool
+	}{
+		{"text", "text", true},
+		{json.Number("5"), json.Number("5"), true},
+		{[]any{"uuid", "a"}, "port-a", true},
+		{[]any{"uuid", "b"}, nil, false},
+		{[]any{"set", []any{}}, nil, false},
+		{[]any{"set", []any{[]any{"uuid", "a"}, []any{"uuid", "b"}}}, []any{"port-a"}, true},
+		{[]any{"set", []any{"x", "y"}}, []any{"x", "y"}, true},
+		{[]any{"map", []any{[]any{"k", "v"}}}, map[string]any{"k": "v"}, true},
+		{[]any{"map", []any{}}, nil, false},
+	}
+
+	for _, testCase := range testCases {
+		result, keep := exportValue(testCase.value, exportedIDs)
+		if keep != testCase.keep {
+			t.Errorf("Expected keep=%t for value %v, got %t", testCase.keep, testCase.value, keep)
+			continue
+		}
+
+		if keep && !reflect.DeepEqual(result, testCase.expected) {
+			t.Errorf("Expected value %v to be exported as %v, got %v", testCase.value, testCase.expected, result)
+		}
+	}
+}
+
+func TestUnexported_buildDocument(t *testing.T) {
+	records := loadTestRecords(t)
+	document := buildDocument(records, types.TopologyExportRequest{ExternalIDs: map[string]string{"tenant": "blue"}})
+
+	if document.Version != DocumentVersion {
+		t.Errorf("Expected document version %d, got %d", DocumentVersion, document.Version)
+	}
+
+	expectedCounts := map[string]int{
+		"Logical_Switch":      1,
+		"Logical_Switch_Port": 2,
+		"ACL":                 1,
+		"Logical_Router":      1,
+		"Logical_Router_Port": 1,
+		"NAT":                 1,
+		"Port_Group":          1,
+	}
+	for table, count := range expectedCounts {
+		if len(document.Tables[table]) != count {
+			t.Errorf("Expected %d records in table '%s', got %d", count, table, len(document.Tables[table]))
+		}
+	}
+
+	sw := document.Tables["Logical_Switch"][0]
+	if sw.ID != "sw0" {
+		t.Errorf("Expected switch ID 'sw0', got '%s'", sw.ID)
+	}
+
+	if !reflect.DeepEqual(sw.Columns["ports"], []any{"vm1", "sw0-lr0"}) {
+		t.Errorf("Expected switch ports to be resolved to IDs, got %v", sw.Columns["ports"])
+	}
+
+	if sw.Columns["acls"] != "acl-1" {
+		t.Errorf("Expected switch ACL to be resolved to ID 'acl-1', got %v", sw.Columns["acls"])
+	}
+
+	for _, column := range []string{"_uuid", "_version", "load_balancer", "dns_records"} {
+		if _, ok := sw.Columns[column]; ok {
+			t.Errorf("Expected column '%s' to be omitted from the switch", column)
+		}
+	}
+
+	for _, port := range document.Tables["Logical_Switch_Port"] {
+		for _, column := range []string{"up", "dhcpv4_options", "tag_request"} {
+			if _, ok := port.Columns[column]; ok {
+				t.Errorf("Expected column '%s' to be omitted from port '%s'", column, port.ID)
+			}
+		}
+	}
+
+	if document.Tables["NAT"][0].Columns["gateway_port"] != "lr0-sw0" {
+		t.Errorf("Expected NAT gateway port to be resolved to 'lr0-sw0', got %v", document.Tables["NAT"][0].Columns["gateway_port"])
+	}
+
+	// Port "foreign" is not exported, so the reference to it is dropped from the port group
+	if !reflect.DeepEqual(document.Tables["Port_Group"][0].Columns["ports"], []any{"vm1"}) {
+		t.Errorf("Expected port group ports [vm1], got %v", document.Tables["Port_Group"][0].Columns["ports"])
+	}
+
+	expectedWarnings := []string{
+		"Logical_Switch 'sw0': column 'dns_records' references records that can't be exported, it was omitted",
+		"Logical_Switch_Port 'vm1': column 'dhcpv4_options' references records that can't be exported, it was omitted",
+	}
+	if !reflect.DeepEqual(document.Warnings, expectedWarnings) {
+		t.Errorf("Expected warnings %v, got %v", expectedWarnings, document.Warnings)
+	}
+
+	document = buildDocument(records, types.TopologyExportRequest{Names: []string{"other"}})
+	if len(document.Tables) != 1 || len(document.Tables["Logical_Switch"]) != 1 {
+		t.Errorf("Expected only switch 'other' to be exported, got %v", document.Tables)
+	}
+}
+
+func TestUnexported_buildImportPlan(t *testing.T) {
+	document := buildDocument(loadTestRecords(t), types.TopologyExportRequest{Names: []string{"sw0", "lr0"}})
+	plan, err := buildImportPlan(document)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if plan.Created["Logical_Switch"] != 1 || plan.Created["Logical_Switch_Port"] != 2 || plan.Created["NAT"] != 1 {
+		t.Errorf("Unexpected number of created records: %v", plan.Created)
+	}
+
+	var switchRow map[string]any
+	waits := 0
+	for _, operation := range plan.Operations {
+		op := operation.(map[string]any)
+		if op["op"] == "wait" {
+			waits++
+		}
+		if op["op"] == "insert" && op["table"] == "Logical_Switch" {
+			switchRow = op["row"].(map[string]any)
+		}
+	}
+
+	// Named records: switch, router, two switch ports and router port
+	if waits != 5 {
+		t.Errorf("Expected 5 wait operations, got %d", waits)
+	}
+
+	expectedPorts := []any{"set", []any{
+		[]any{"named-uuid", "logical_switch_port_0"},
+		[]any{"named-uuid", "logical_switch_port_1"},
+	}}
+	if !reflect.DeepEqual(switchRow["ports"], expectedPorts) {
+		t.Errorf("Expected switch ports %v, got %v", expectedPorts, switchRow["ports"])
+	}
+
+	expectedConfig := []any{"map", []any{[]any{"subnet", "10.0.0.0/24"}}}
+	if !reflect.DeepEqual(switchRow["other_config"], expectedConfig) {
+		t.Errorf("Expected switch other_config %v, got %v", expectedConfig, switchRow["other_config"])
+	}
+
+	document.Tables["Logical_Switch"][0].Columns["ports"] = []any{"missing"}
+	_, err = buildImportPlan(document)
+	if err == nil {
+		t.Errorf("Expected reference to unknown record to be rejected")
+	}
+
+	invalidDocuments := []types.TopologyDocument{
+		{Version: 2},
+		{Version: DocumentVersion, Tables: map[string][]types.TopologyRecord{"Chassis": {}}},
+		{Version: DocumentVersion, Tables: map[string][]types.TopologyRecord{
+			"Logical_Switch": {{ID: "a", Columns: map[string]any{"name": "a"}}, {ID: "a", Columns: map[string]any{"name": "b"}}},
+		}},
+		{Version: DocumentVersion, Tables: map[string][]types.TopologyRecord{
+			"Logical_Switch": {{ID: "a", Columns: map[string]any{}}},
+		}},
+	}
+	for _, invalid := range invalidDocuments {
+		_, err = buildImportPlan(invalid)
+		if err == nil {
+			t.Errorf("Expected document %v to be rejected", invalid)
+		}
+	}
+}
+
+func TestUnexported_findConflicts(t *testing.T) {
+	plan := importPlan{Names: map[string][]string{"Logical_Switch": {"sw0", "sw1"}, "Port_Group": {"pg"}}}
+	conflicts := findConflicts(plan, loadTestRecords(t))
+	if !reflect.DeepEqual(conflicts, []string{"Logical_Switch 'sw0'"}) {
+		t.Errorf("Expected conflict with switch 'sw0', got %v", conflicts)
+	}
+}
+
+func TestNormalizeDocument(t *testing.T) {
+	document := types.TopologyDocument{Tables: map[string][]types.TopologyRecord{
+		"Logical_Switch_Port": {{ID: "p", Columns: map[string]any{
+			"tag_request": float64(1000000),
+			"ratio":       1.5,
+			"options":     map[string]any{"x": float64(3)},
+			"list":        []any{float64(7)},
+		}}},
+	}}
+	NormalizeDocument(&document)
+
+	columns := document.Tables["Logical_Switch_Port"][0].Columns
+	expected := map[string]any{
+		"tag_request": int64(1000000),
+		"ratio":       1.5,
+		"options":     map[string]any{"x": int64(3)},
+		"list":        []any{int64(7)},
+	}
+	if !reflect.DeepEqual(columns, expected) {
+		t.Errorf("Expected normalized columns %v, got %v", expected, columns)
+	}
+}
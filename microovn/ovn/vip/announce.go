@@ -0,0 +1,155 @@
+package vip
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+const (
+	etherTypeARP  = 0x0806
+	etherTypeIPv6 = 0x86dd
+	ipProtoICMPv6 = 58
+
+	// announceInterval is a delay between repeated announcements of the same address.
+	announceInterval = 500 * time.Millisecond
+)
+
+// ethernetBroadcast is the destination of gratuitous ARP announcements.
+var ethernetBroadcast = net.HardwareAddr{0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+
+// ipv6AllNodes is the destination of unsolicited Neighbor Advertisements.
+var ipv6AllNodes = netip.MustParseAddr("ff02::1")
+
+// ethernetHeader returns Ethernet header of a frame sent from "src" to "dst" that carries payload of the
+// "etherType".
+func ethernetHeader(dst net.HardwareAddr, src net.HardwareAddr, etherType uint16) []byte {
+	header := make([]byte, 0, 14)
+	header = append(header, dst...)
+	header = append(header, src...)
+	return binary.BigEndian.AppendUint16(header, etherType)
+}
+
+// buildGratuitousARP returns Ethernet frame with the gratuitous ARP request that announces that the IPv4
+// address "addr" is reachable on the interface with hardware address "mac".
+func buildGratuitousARP(mac net.HardwareAddr, addr netip.Addr) []byte {
+	frame := ethernetHeader(ethernetBroadcast, mac, etherTypeARP)
+	frame = binary.BigEndian.AppendUint16(frame, 1)      // Hardware type: Ethernet
+	frame = binary.BigEndian.AppendUint16(frame, 0x0800) // Protocol type: IPv4
+	frame = append(frame, 6, 4)                          // Hardware and protocol address lengths
+	frame = binary.BigEndian.AppendUint16(frame, 1)      // Operation: request
+
+	ip := addr.As4()
+	frame = append(frame, mac...)
+	frame = append(frame, ip[:]...)
+	frame = append(frame, 0, 0, 0, 0, 0, 0) // Target hardware address is unknown
+	return append(frame, ip[:]...)
+}
+
+// icmpv6Checksum calculates checksum of the ICMPv6 "message" sent from "src" to "dst", as defined by RFC 4443.
+func icmpv6Checksum(src netip.Addr, dst netip.Addr, message []byte) uint16 {
+	srcBytes := src.As16()
+	dstBytes := dst.As16()
+
+	pseudoHeader := make([]byte, 0, 40+len(message))
+	pseudoHeader = append(pseudoHeader, srcBytes[:]...)
+	pseudoHeader = append(pseudoHeader, dstBytes[:]...)
+	pseudoHeader = binary.BigEndian.AppendUint32(pseudoHeader, uint32(len(message)))
+	pseudoHeader = append(pseudoHeader, 0, 0, 0, ipProtoICMPv6)
+	pseudoHeader = append(pseudoHeader, message...)
+	if len(pseudoHeader)%2 == 1 {
+		pseudoHeader = append(pseudoHeader, 0)
+	}
+
+	var sum uint32
+	for i := 0; i < len(pseudoHeader); i += 2 {
+		sum += uint32(binary.BigEndian.Uint16(pseudoHeader[i:]))
+	}
+	for sum > 0xffff {
+		sum = (sum >> 16) + (sum & 0xffff)
+	}
+	return ^uint16(sum)
+}
+
+// buildUnsolicitedNA returns Ethernet frame with the unsolicited Neighbor Advertisement that announces that
+// the IPv6 address "addr" is reachable on the interface with hardware address "mac" (RFC 4861, section 7.2.6).
+func buildUnsolicitedNA(mac net.HardwareAddr, addr netip.Addr) []byte {
+	target := addr.As16()
+
+	message := []byte{136, 0, 0, 0}                              // Type: Neighbor Advertisement, code, checksum
+	message = binary.BigEndian.AppendUint32(message, 0x20000000) // Flags: Override
+	message = append(message, target[:]...)
+	message = append(message, 2, 1) // Option: Target link-layer address, length in units of 8 bytes
+	message = append(message, mac...)
+	binary.BigEndian.PutUint16(message[2:], icmpv6Checksum(addr, ipv6AllNodes, message))
+
+	allNodes := ipv6AllNodes.As16()
+	multicastMac := net.HardwareAddr{0x33, 0x33, allNodes[12], allNodes[13], allNodes[14], allNodes[15]}
+
+	frame := ethernetHeader(multicastMac, mac, etherTypeIPv6)
+	frame = append(frame, 0x60, 0, 0, 0) // Version 6, no traffic class or flow label
+	frame = binary.BigEndian.AppendUint16(frame, uint16(len(message)))
+	frame = append(frame, ipProtoICMPv6, 255) // Next header, hop limit required by RFC 4861
+	frame = append(frame, target[:]...)
+	frame = append(frame, allNodes[:]...)
+	return append(frame, message...)
+}
+
+// htons converts 16-bit integer from host to network byte order.
+func htons(value uint16) uint16 {
+	var networkOrder [2]byte
+	binary.BigEndian.PutUint16(networkOrder[:], value)
+	return binary.NativeEndian.Uint16(networkOrder[:])
+}
+
+// Announce sends "count" gratuitous ARP requests (for IPv4) or unsolicited Neighbor Advertisements (for IPv6)
+// from the interface "ifaceName" to let the neighbours know that the "addr" has moved to this interface.
+func Announce(ifaceName string, addr netip.Addr, count int) error {
+	iface, err := net.InterfaceByName(ifaceName)
+	if err != nil {
+		return fmt.Errorf("failed to find interface '%s': %w", ifaceName, err)
+	}
+
+	if len(iface.HardwareAddr) != 6 {
+		return fmt.Errorf("interface '%s' does not have an Ethernet address", ifaceName)
+	}
+
+	var frame []byte
+	var etherType uint16
+	if addr.Unmap().Is4() {
+		frame = buildGratuitousARP(iface.HardwareAddr, addr.Unmap())
+		etherType = etherTypeARP
+	} else {
+		frame = buildUnsolicitedNA(iface.HardwareAddr, addr)
+		etherType = etherTypeIPv6
+	}
+
+	fd, err := syscall.Socket(syscall.AF_PACKET, syscall.SOCK_RAW, int(htons(etherType)))
+	if err != nil {
+		return fmt.Errorf("failed to open packet socket: %w", err)
+	}
+	defer syscall.Close(fd)
+
+	destination := syscall.SockaddrLinklayer{
+		Protocol: htons(etherType),
+		Ifindex:  iface.Index,
+		Halen:    6,
+	}
+	copy(destination.Addr[:], frame[:6])
+
+	for i := 0; i < count; i++ {
+		if i > 0 {
+			time.Sleep(announceInterval)
+		}
+
+		err = syscall.Sendto(fd, frame, 0, &destination)
+		if err != nil {
+			return fmt.Errorf("failed to announce address %s on interface '%s': %w", addr, ifaceName, err)
+		}
+	}
+
+	return nil
+}
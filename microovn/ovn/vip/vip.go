@@ -0,0 +1,121 @@
+// Package vip implements configuration of floating virtual IP addresses (VIPs) of the OVN Northbound and
+// Southbound database APIs. Each VIP is held by the member whose database server is the RAFT leader, and
+// it is announced to the network when it moves to a different member.
+package vip
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/canonical/microcluster/v2/state"
+
+	"github.com/canonical/microovn/microovn/config"
+)
+
+// NBConfigKey - cluster config option that holds virtual IP address of the OVN Northbound database API.
+const NBConfigKey = "ovn.nb.vip"
+
+// SBConfigKey - cluster config option that holds virtual IP address of the OVN Southbound database API.
+const SBConfigKey = "ovn.sb.vip"
+
+// InterfaceConfigKey - member config option that selects network interface on which the member holds
+// virtual IP addresses. By default, the interface with a subnet that contains the virtual IP is used.
+const InterfaceConfigKey = "ovn.vip-interface"
+
+// ConfigKeys maps short names of the OVN databases ("nb", "sb") to the config options that hold their
+// virtual IP addresses.
+var ConfigKeys = map[string]string{
+	"nb": NBConfigKey,
+	"sb": SBConfigKey,
+}
+
+// certificateServices maps names of the services whose certificates include the virtual IP addresses to the
+// short names of the OVN databases.
+var certificateServices = map[string]string{
+	"ovnnb": "nb",
+	"ovnsb": "sb",
+}
+
+// VirtualIP represents parsed value of the "ovn.nb.vip" or "ovn.sb.vip" config option.
+type VirtualIP struct {
+	Address   netip.Addr // The virtual IP address
+	PrefixLen int        // Length of the network prefix, or -1 if it should be taken from the interface
+}
+
+// String returns the virtual IP in the "<address>[/<prefix length>]" format.
+func (v VirtualIP) String() string {
+	if v.PrefixLen < 0 {
+		return v.Address.String()
+	}
+	return netip.PrefixFrom(v.Address, v.PrefixLen).String()
+}
+
+// Parse parses value of the virtual IP config option. The value is an IPv4 or IPv6 address, optionally
+// followed by the length of the network prefix (e.g. "192.0.2.100/24").
+func Parse(value string) (VirtualIP, error) {
+	rawAddr, rawPrefixLen, hasPrefix := strings.Cut(value, "/")
+
+	addr, err := netip.ParseAddr(rawAddr)
+	if err != nil || addr.Zone() != "" {
+		return VirtualIP{}, fmt.Errorf("'%s' is not a valid IP address", rawAddr)
+	}
+
+	if !addr.IsGlobalUnicast() {
+		return VirtualIP{}, fmt.Errorf("'%s' is not a unicast address", rawAddr)
+	}
+
+	virtualIP := VirtualIP{Address: addr, PrefixLen: -1}
+	if hasPrefix {
+		virtualIP.PrefixLen, err = strconv.Atoi(rawPrefixLen)
+		if err != nil || virtualIP.PrefixLen < 0 || virtualIP.PrefixLen > addr.BitLen() {
+			return VirtualIP{}, fmt.Errorf("'%s' is not a valid prefix length", rawPrefixLen)
+		}
+	}
+
+	return virtualIP, nil
+}
+
+// Configured returns virtual IP addresses configured in the cluster, keyed by the short names of the OVN
+// databases ("nb", "sb"). Databases without virtual IP are omitted.
+func Configured(ctx context.Context, s state.State) (map[string]VirtualIP, error) {
+	virtualIPs := make(map[string]VirtualIP)
+	for db, key := range ConfigKeys {
+		item, err := config.GetConfig(ctx, s, key)
+		if err != nil {
+			return nil, err
+		}
+
+		if item == nil || item.Value == "" {
+			continue
+		}
+
+		virtualIPs[db], err = Parse(item.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of '%s' config option: %w", key, err)
+		}
+	}
+	return virtualIPs, nil
+}
+
+// CertificateAddresses returns virtual IP addresses that should be included in the certificate of the
+// "serviceName" as subject alternative names.
+func CertificateAddresses(ctx context.Context, s state.State, serviceName string) ([]netip.Addr, error) {
+	db, ok := certificateServices[serviceName]
+	if !ok {
+		return nil, nil
+	}
+
+	virtualIPs, err := Configured(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+
+	virtualIP, ok := virtualIPs[db]
+	if !ok {
+		return nil, nil
+	}
+	return []netip.Addr{virtualIP.Address}, nil
+}
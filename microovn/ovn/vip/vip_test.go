@@ -0,0 +1,107 @@
+package vip
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"net/netip"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected string
+		isValid  bool
+	}{
+		{value: "192.0.2.100", expected: "192.0.2.100", isValid: true},
+		{value: "192.0.2.100/24", expected: "192.0.2.100/24", isValid: true},
+		{value: "2001:db8::100/64", expected: "2001:db8::100/64", isValid: true},
+		// Not an address
+		{value: "vip", isValid: false},
+		{value: "", isValid: false},
+		// Invalid prefix length
+		{value: "192.0.2.100/33", isValid: false},
+		{value: "192.0.2.100/", isValid: false},
+		// Not a unicast address
+		{value: "224.0.0.1", isValid: false},
+		{value: "127.0.0.1", isValid: false},
+		{value: "fe80::1", isValid: false},
+		// Zone is not allowed
+		{value: "2001:db8::100%eth0", isValid: false},
+	}
+
+	for _, tc := range testCases {
+		virtualIP, err := Parse(tc.value)
+		if !tc.isValid {
+			if err == nil {
+				t.Errorf("Expected value '%s' to be rejected", tc.value)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Unexpected error for value '%s': %v", tc.value, err)
+			continue
+		}
+
+		if virtualIP.String() != tc.expected {
+			t.Errorf("Expected '%s', got '%s'", tc.expected, virtualIP)
+		}
+	}
+}
+
+func TestUnexported_buildGratuitousARP(t *testing.T) {
+	mac := net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x01}
+	frame := buildGratuitousARP(mac, netip.MustParseAddr("192.0.2.100"))
+
+	if len(frame) != 42 {
+		t.Fatalf("Expected 42 bytes long frame, got %d", len(frame))
+	}
+
+	if !bytes.Equal(frame[0:6], ethernetBroadcast) || !bytes.Equal(frame[6:12], mac) {
+		t.Errorf("Unexpected Ethernet addresses: %x", frame[0:12])
+	}
+
+	if binary.BigEndian.Uint16(frame[12:]) != etherTypeARP {
+		t.Errorf("Unexpected EtherType: %x", frame[12:14])
+	}
+
+	// Sender and target protocol addresses are both set to the announced address
+	expectedIP := []byte{192, 0, 2, 100}
+	if !bytes.Equal(frame[22:28], mac) || !bytes.Equal(frame[28:32], expectedIP) || !bytes.Equal(frame[38:42], expectedIP) {
+		t.Errorf("Unexpected ARP addresses: %x", frame[22:42])
+	}
+}
+
+func TestUnexported_buildUnsolicitedNA(t *testing.T) {
+	mac := net.HardwareAddr{0x02, 0x00, 0x00, 0x00, 0x00, 0x01}
+	addr := netip.MustParseAddr("2001:db8::100")
+	frame := buildUnsolicitedNA(mac, addr)
+
+	// Ethernet header, IPv6 header and 32 bytes long ICMPv6 message
+	if len(frame) != 14+40+32 {
+		t.Fatalf("Expected 86 bytes long frame, got %d", len(frame))
+	}
+
+	expectedDst := net.HardwareAddr{0x33, 0x33, 0x00, 0x00, 0x00, 0x01}
+	if !bytes.Equal(frame[0:6], expectedDst) || !bytes.Equal(frame[6:12], mac) {
+		t.Errorf("Unexpected Ethernet addresses: %x", frame[0:12])
+	}
+
+	ipHeader := frame[14:54]
+	if ipHeader[6] != ipProtoICMPv6 || ipHeader[7] != 255 {
+		t.Errorf("Unexpected next header or hop limit: %d, %d", ipHeader[6], ipHeader[7])
+	}
+
+	message := frame[54:]
+	target := addr.As16()
+	if message[0] != 136 || !bytes.Equal(message[8:24], target[:]) || !bytes.Equal(message[26:32], mac) {
+		t.Errorf("Unexpected Neighbor Advertisement: %x", message)
+	}
+
+	// Checksum over a message that already contains the checksum must be zero
+	if checksum := icmpv6Checksum(addr, ipv6AllNodes, message); checksum != 0 {
+		t.Errorf("Expected valid ICMPv6 checksum, got remainder %x", checksum)
+	}
+}
@@ -0,0 +1,448 @@
+package vswitch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v2/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/config"
+	"github.com/canonical/microovn/microovn/node"
+	ovnCmd "github.com/canonical/microovn/microovn/ovn/cmd"
+)
+
+// ConntrackLimitConfigKey - member config option that sets default limit of connections in each
+// conntrack zone of the local datapath.
+const ConntrackLimitConfigKey = "switch.ct-limit"
+
+// ConntrackZoneLimitsConfigKey - member config option that sets limit of connections in specific conntrack
+// zones of the local datapath. Value is a comma-separated list of "<zone>=<limit>" pairs, where zone is
+// either conntrack zone ID or name of the OVN object (e.g. logical port) that uses the zone.
+const ConntrackZoneLimitsConfigKey = "switch.ct-zone-limits"
+
+// ConntrackZoneRefreshInterval is a period in which conntrack zones of OVN objects, named in the
+// "switch.ct-zone-limits" config option, are resolved again.
+const ConntrackZoneRefreshInterval = 30 * time.Second
+
+// conntrackWarningThreshold is a fraction of the conntrack limit which, when reached, results in a warning.
+const conntrackWarningThreshold = 0.9
+
+// ParseConntrackLimit parses value of the "switch.ct-limit" config option.
+func ParseConntrackLimit(value string) (int, error) {
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 0 {
+		return 0, fmt.Errorf("limit '%s' is not a non-negative number", value)
+	}
+	return limit, nil
+}
+
+// ParseConntrackZoneLimits parses value of the "switch.ct-zone-limits" config option. It returns map of
+// zones (IDs or names of OVN objects) and their limits.
+func ParseConntrackZoneLimits(value string) (map[string]int, error) {
+	limits := make(map[string]int)
+	for _, item := range strings.Split(value, ",") {
+		zone, rawLimit, found := strings.Cut(strings.TrimSpace(item), "=")
+		if !found || zone == "" {
+			return nil, fmt.Errorf("value '%s' does not conform to the '<zone>=<limit>' format", item)
+		}
+
+		if _, ok := limits[zone]; ok {
+			return nil, fmt.Errorf("zone '%s' is specified multiple times", zone)
+		}
+
+		limit, err := ParseConntrackLimit(rawLimit)
+		if err != nil {
+			return nil, fmt.Errorf("invalid limit for zone '%s': %w", zone, err)
+		}
+		limits[zone] = limit
+	}
+	return limits, nil
+}
+
+// parseDatapathStats parses output of the "dpctl/show" ovs-appctl command.
+func parseDatapathStats(output string) []types.DatapathStat {
+	datapaths := []types.DatapathStat{}
+	var current *types.DatapathStat
+	for _, line := range strings.Split(output, "\n") {
+		if line == "" {
+			continue
+		}
+
+		if !strings.HasPrefix(line, " ") && strings.HasSuffix(line, ":") {
+			datapaths = append(datapaths, types.DatapathStat{Name: strings.TrimSuffix(line, ":")})
+			current = &datapaths[len(datapaths)-1]
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "lookups:":
+			for _, field := range fields[1:] {
+				name, rawValue, _ := strings.Cut(field, ":")
+				value, _ := strconv.ParseUint(rawValue, 10, 64)
+				switch name {
+				case "hit":
+					current.Hits = value
+				case "missed":
+					current.Misses = value
+				case "lost":
+					current.Lost = value
+				}
+			}
+		case "flows:":
+			if len(fields) > 1 {
+				current.Flows, _ = strconv.ParseUint(fields[1], 10, 64)
+			}
+		}
+	}
+	return datapaths
+}
+
+// parseConntrackLimits parses output of the "dpctl/ct-get-limits" ovs-appctl command. It returns default
+// limit and usage of each zone listed in the output.
+func parseConntrackLimits(output string) (int, []types.ConntrackZone) {
+	defaultLimit := 0
+	zones := []types.ConntrackZone{}
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if rawLimit, found := strings.CutPrefix(line, "default limit="); found {
+			defaultLimit, _ = strconv.Atoi(rawLimit)
+			continue
+		}
+
+		if !strings.HasPrefix(line, "zone=") {
+			continue
+		}
+
+		zone := types.ConntrackZone{}
+		for _, field := range strings.Split(line, ",") {
+			name, rawValue, _ := strings.Cut(field, "=")
+			value, _ := strconv.Atoi(rawValue)
+			switch name {
+			case "zone":
+				zone.Zone = value
+			case "limit":
+				zone.Limit = value
+			case "count":
+				zone.Count = value
+			}
+		}
+		zones = append(zones, zone)
+	}
+	return defaultLimit, zones
+}
+
+// parseConntrackZoneNames parses output of the "ct-zone-list" ovn-controller command. It returns map of
+// conntrack zone IDs and names of the OVN objects that use them.
+func parseConntrackZoneNames(output string) map[int][]string {
+	zoneNames := make(map[int][]string)
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+
+		zone, err := strconv.Atoi(fields[1])
+		if err != nil {
+			continue
+		}
+		zoneNames[zone] = append(zoneNames[zone], fields[0])
+	}
+
+	for _, names := range zoneNames {
+		sort.Strings(names)
+	}
+	return zoneNames
+}
+
+// conntrackWarnings returns warnings for conntrack zones whose usage approaches their limit.
+func conntrackWarnings(zones []types.ConntrackZone) []string {
+	warnings := []string{}
+	for _, zone := range zones {
+		if zone.Limit == 0 || float64(zone.Count) < float64(zone.Limit)*conntrackWarningThreshold {
+			continue
+		}
+
+		name := strconv.Itoa(zone.Zone)
+		if len(zone.Names) > 0 {
+			name = fmt.Sprintf("%d (%s)", zone.Zone, strings.Join(zone.Names, ", "))
+		}
+		warnings = append(warnings, fmt.Sprintf(
+			"conntrack zone %s uses %d of %d allowed connections", name, zone.Count, zone.Limit,
+		))
+	}
+	return warnings
+}
+
+// conntrackZoneNames returns conntrack zones allocated by the local ovn-controller. An empty map is returned
+// if the "chassis" service is not enabled on this member.
+func conntrackZoneNames(ctx context.Context, s state.State) (map[int][]string, error) {
+	hasChassis, err := node.HasServiceActive(ctx, s, types.SrvChassis)
+	if err != nil || !hasChassis {
+		return map[int][]string{}, err
+	}
+
+	out, err := ovnCmd.ControllerCtl(ctx, s, "ct-zone-list")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list OVN conntrack zones: %w", err)
+	}
+	return parseConntrackZoneNames(out), nil
+}
+
+// GetDatapathStats returns flow statistics of the local datapaths and conntrack usage in each conntrack
+// zone used by OVN.
+func GetDatapathStats(ctx context.Context, s state.State) (types.DatapathStats, error) {
+	stats := types.DatapathStats{Member: s.Name(), ConntrackZones: []types.ConntrackZone{}}
+
+	out, err := ovnCmd.SwitchCtl(ctx, s, "dpctl/show")
+	if err != nil {
+		return stats, fmt.Errorf("failed to get datapath statistics: %w", err)
+	}
+	stats.Datapaths = parseDatapathStats(out)
+
+	zoneNames, err := conntrackZoneNames(ctx, s)
+	if err != nil {
+		return stats, err
+	}
+
+	limitsArgs := []string{"dpctl/ct-get-limits"}
+	if len(zoneNames) > 0 {
+		zoneIDs := make([]string, 0, len(zoneNames))
+		for zone := range zoneNames {
+			zoneIDs = append(zoneIDs, strconv.Itoa(zone))
+		}
+		sort.Strings(zoneIDs)
+		limitsArgs = append(limitsArgs, fmt.Sprintf("zone=%s", strings.Join(zoneIDs, ",")))
+	}
+
+	out, err = ovnCmd.SwitchCtl(ctx, s, limitsArgs...)
+	if err != nil {
+		return stats, fmt.Errorf("failed to get conntrack limits: %w", err)
+	}
+	stats.ConntrackDefaultLimit, stats.ConntrackZones = parseConntrackLimits(out)
+
+	for i, zone := range stats.ConntrackZones {
+		stats.ConntrackZones[i].Names = zoneNames[zone.Zone]
+	}
+	sort.Slice(stats.ConntrackZones, func(i, j int) bool {
+		return stats.ConntrackZones[i].Zone < stats.ConntrackZones[j].Zone
+	})
+
+	stats.Warnings = conntrackWarnings(stats.ConntrackZones)
+	return stats, nil
+}
+
+// resolveConntrackZones translates zones from the "switch.ct-zone-limits" config option to conntrack zone IDs.
+// Zones specified by the name of an OVN object that does not currently have a zone allocated are skipped and
+// their names are returned in the sorted list of unresolved zones.
+func resolveConntrackZones(limits map[string]int, zoneNames map[int][]string) (map[int]int, []string) {
+	nameToZone := make(map[string]int)
+	for zone, names := range zoneNames {
+		for _, name := range names {
+			nameToZone[name] = zone
+		}
+	}
+
+	resolved := make(map[int]int, len(limits))
+	unresolved := []string{}
+	for zone, limit := range limits {
+		zoneID, err := strconv.Atoi(zone)
+		if err != nil {
+			var found bool
+			zoneID, found = nameToZone[zone]
+			if !found {
+				unresolved = append(unresolved, zone)
+				continue
+			}
+		}
+		resolved[zoneID] = limit
+	}
+
+	sort.Strings(unresolved)
+	return resolved, unresolved
+}
+
+// namedConntrackZones returns limits of conntrack zones that are specified by the name of an OVN object in
+// the "switch.ct-zone-limits" config option, keyed by the zone IDs currently allocated to these objects.
+// It returns nil if the option does not refer to any OVN object by name.
+func namedConntrackZones(ctx context.Context, s state.State) (map[int]int, error) {
+	zonesItem, err := config.GetMemberConfig(ctx, s, s.Name(), ConntrackZoneLimitsConfigKey)
+	if err != nil || zonesItem == nil {
+		return nil, err
+	}
+
+	limits, err := ParseConntrackZoneLimits(zonesItem.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	namedLimits := make(map[string]int)
+	for zone, limit := range limits {
+		_, err = strconv.Atoi(zone)
+		if err != nil {
+			namedLimits[zone] = limit
+		}
+	}
+	if len(namedLimits) == 0 {
+		return nil, nil
+	}
+
+	zoneNames, err := conntrackZoneNames(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved, _ := resolveConntrackZones(namedLimits, zoneNames)
+	return resolved, nil
+}
+
+// RunConntrackZoneRefresh periodically checks conntrack zones allocated to OVN objects that are named in the
+// "switch.ct-zone-limits" config option, until the "ctx" is cancelled. Conntrack limits are applied again
+// whenever these zones change, e.g. when a named port is created after the option was set, or when
+// ovn-controller allocates a different zone to it.
+func RunConntrackZoneRefresh(ctx context.Context, s state.State) {
+	ticker := time.NewTicker(ConntrackZoneRefreshInterval)
+	defer ticker.Stop()
+
+	var lastZones map[int]int
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// Skip if the database isn't ready, the member might not be part of the cluster yet.
+		err := s.Database().IsOpen(ctx)
+		if err != nil {
+			continue
+		}
+
+		hasSwitch, err := node.HasServiceActive(ctx, s, types.SrvSwitch)
+		if err != nil || !hasSwitch {
+			continue
+		}
+
+		zones, err := namedConntrackZones(ctx, s)
+		if err != nil {
+			logger.Debugf("Failed to resolve named conntrack zones: %v", err)
+			continue
+		}
+
+		if maps.Equal(zones, lastZones) {
+			continue
+		}
+		lastZones = zones
+
+		err = UpdateConntrackLimits(ctx, s, false)
+		if err != nil {
+			logger.Warnf("Failed to update conntrack limits: %v", err)
+		}
+	}
+}
+
+// UpdateConntrackLimits applies values of the "switch.ct-limit" and "switch.ct-zone-limits" config options
+// to the local datapath. Zone limits that are not present in the config are removed. If "force" is false and
+// neither option is set, the limits in the datapath are left untouched.
+//
+// This function does nothing if the "switch" service is not enabled on this member.
+func UpdateConntrackLimits(ctx context.Context, s state.State, force bool) error {
+	hasSwitch, err := node.HasServiceActive(ctx, s, types.SrvSwitch)
+	if err != nil {
+		return fmt.Errorf("failed to query local services: %w", err)
+	}
+	if !hasSwitch {
+		logger.Debug("Skipping conntrack limits configuration, switch service is not enabled on this member")
+		return nil
+	}
+
+	defaultItem, err := config.GetMemberConfig(ctx, s, s.Name(), ConntrackLimitConfigKey)
+	if err != nil {
+		return err
+	}
+
+	zonesItem, err := config.GetMemberConfig(ctx, s, s.Name(), ConntrackZoneLimitsConfigKey)
+	if err != nil {
+		return err
+	}
+
+	if !force && defaultItem == nil && zonesItem == nil {
+		return nil
+	}
+
+	defaultLimit := 0
+	if defaultItem != nil {
+		defaultLimit, err = ParseConntrackLimit(defaultItem.Value)
+		if err != nil {
+			return err
+		}
+	}
+
+	zoneLimits := make(map[int]int)
+	if zonesItem != nil {
+		limits, err := ParseConntrackZoneLimits(zonesItem.Value)
+		if err != nil {
+			return err
+		}
+
+		zoneNames, err := conntrackZoneNames(ctx, s)
+		if err != nil {
+			return err
+		}
+		var unresolved []string
+		zoneLimits, unresolved = resolveConntrackZones(limits, zoneNames)
+		for _, zone := range unresolved {
+			logger.Warnf("Conntrack limit for '%s' is not applied yet, OVN conntrack zone not found", zone)
+		}
+	}
+
+	// Remove limits from zones that are no longer configured
+	out, err := ovnCmd.SwitchCtl(ctx, s, "dpctl/ct-get-limits")
+	if err != nil {
+		return fmt.Errorf("failed to get conntrack limits: %w", err)
+	}
+	_, currentZones := parseConntrackLimits(out)
+
+	staleZones := []string{}
+	for _, zone := range currentZones {
+		if _, ok := zoneLimits[zone.Zone]; !ok {
+			staleZones = append(staleZones, strconv.Itoa(zone.Zone))
+		}
+	}
+
+	var errs []error
+	if len(staleZones) > 0 {
+		_, err = ovnCmd.SwitchCtl(ctx, s, "dpctl/ct-del-limits", fmt.Sprintf("zone=%s", strings.Join(staleZones, ",")))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to remove conntrack limits: %w", err))
+		}
+	}
+
+	setArgs := []string{"dpctl/ct-set-limits", fmt.Sprintf("default=%d", defaultLimit)}
+	for zone, limit := range zoneLimits {
+		setArgs = append(setArgs, fmt.Sprintf("zone=%d,limit=%d", zone, limit))
+	}
+
+	_, err = ovnCmd.SwitchCtl(ctx, s, setArgs...)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to set conntrack limits: %w", err))
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,78 @@
+package vswitch
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/canonical/microovn/microovn/api/types"
+)
+
+func TestUnexported_parseDatapathStats(t *testing.T) {
+	output := `system@ovs-system:
+  lookups: hit:263 missed:23 lost:1
+  flows: 3
+  masks: hit:292 total:1 hit/pkt:1.02
+  cache: hit:231 hit-rate:80.77%
+  caches:
+    masks-cache: size:256
+  port 0: ovs-system (internal)
+  port 1: br-int (internal)
+`
+	datapaths := parseDatapathStats(output)
+	expected := []types.DatapathStat{{Name: "system@ovs-system", Hits: 263, Misses: 23, Lost: 1, Flows: 3}}
+	if !slices.Equal(datapaths, expected) {
+		t.Errorf("Expected datapath stats %+v, got %+v", expected, datapaths)
+	}
+}
+
+func TestUnexported_parseConntrackLimits(t *testing.T) {
+	output := `default limit=1000
+zone=3,limit=1000,count=950
+zone=7,limit=0,count=12
+`
+	defaultLimit, zones := parseConntrackLimits(output)
+	if defaultLimit != 1000 {
+		t.Errorf("Expected default limit 1000, got %d", defaultLimit)
+	}
+
+	expected := []types.ConntrackZone{{Zone: 3, Limit: 1000, Count: 950}, {Zone: 7, Limit: 0, Count: 12}}
+	if len(zones) != len(expected) {
+		t.Fatalf("Expected zones %+v, got %+v", expected, zones)
+	}
+	for i := range expected {
+		if zones[i].Zone != expected[i].Zone || zones[i].Limit != expected[i].Limit || zones[i].Count != expected[i].Count {
+			t.Errorf("Expected zone %+v, got %+v", expected[i], zones[i])
+		}
+	}
+
+	warnings := conntrackWarnings(zones)
+	if len(warnings) != 1 {
+		t.Errorf("Expected single warning for zone 3, got %v", warnings)
+	}
+}
+
+func TestUnexported_resolveConntrackZones(t *testing.T) {
+	zoneNames := parseConntrackZoneNames("lsp-vm1 3\nlr-gw_dnat 5\nlr-gw_snat 6\n")
+
+	limits, err := ParseConntrackZoneLimits("lsp-vm1=100,6=200,lsp-missing=300")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	resolved, unresolved := resolveConntrackZones(limits, zoneNames)
+	expected := map[int]int{3: 100, 6: 200}
+	if !maps.Equal(resolved, expected) {
+		t.Errorf("Expected resolved zone limits %v, got %v", expected, resolved)
+	}
+	if !slices.Equal(unresolved, []string{"lsp-missing"}) {
+		t.Errorf("Expected unresolved zones [lsp-missing], got %v", unresolved)
+	}
+
+	for _, value := range []string{"", "lsp-vm1", "lsp-vm1=-1", "lsp-vm1=1,lsp-vm1=2"} {
+		_, err = ParseConntrackZoneLimits(value)
+		if err == nil {
+			t.Errorf("Expected value '%s' to be rejected", value)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package vswitch
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v2/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/config"
+	"github.com/canonical/microovn/microovn/node"
+	ovnCmd "github.com/canonical/microovn/microovn/ovn/cmd"
+)
+
+// EncapCsumConfigKey - cluster config option that enables checksums of the tunnel encapsulation packets.
+const EncapCsumConfigKey = "ovn.encap-csum"
+
+// EncapDfDefaultConfigKey - cluster config option that sets the "don't fragment" bit of the tunnel
+// encapsulation packets.
+const EncapDfDefaultConfigKey = "ovn.encap-df-default"
+
+// EncapGenevePortConfigKey - cluster config option that sets destination UDP port of the Geneve tunnels.
+const EncapGenevePortConfigKey = "ovn.encap-geneve-port"
+
+// MemberEncapCsumConfigKey - member config option that overrides "ovn.encap-csum" on a single member.
+const MemberEncapCsumConfigKey = "switch.encap-csum"
+
+// MemberEncapDfDefaultConfigKey - member config option that overrides "ovn.encap-df-default" on a single member.
+const MemberEncapDfDefaultConfigKey = "switch.encap-df-default"
+
+// MemberEncapGenevePortConfigKey - member config option that overrides "ovn.encap-geneve-port" on a single member.
+const MemberEncapGenevePortConfigKey = "switch.encap-geneve-port"
+
+// encapManagedKey is a key in the "external_ids" column of the Open_vSwitch table that holds comma-separated
+// list of the tunnel encapsulation options set by MicroOVN.
+const encapManagedKey = "microovn-encap-options"
+
+// encapOption maps a pair of cluster and member config options to the key in the "external_ids" column
+// of the Open_vSwitch table that is read by ovn-controller.
+type encapOption struct {
+	ClusterKey string
+	MemberKey  string
+	ExternalID string
+}
+
+// encapOptions is a list of tunnel encapsulation options managed by MicroOVN.
+var encapOptions = []encapOption{
+	{ClusterKey: EncapCsumConfigKey, MemberKey: MemberEncapCsumConfigKey, ExternalID: "ovn-encap-csum"},
+	{ClusterKey: EncapDfDefaultConfigKey, MemberKey: MemberEncapDfDefaultConfigKey, ExternalID: "ovn-encap-df_default"},
+	{ClusterKey: EncapGenevePortConfigKey, MemberKey: MemberEncapGenevePortConfigKey, ExternalID: "ovn-encap-port"},
+}
+
+// ParseEncapGenevePort parses value of the "ovn.encap-geneve-port" config option and its member counterpart.
+func ParseEncapGenevePort(value string) (int, error) {
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 1 || port > 65535 {
+		return 0, fmt.Errorf("'%s' is not a valid port number", value)
+	}
+	return port, nil
+}
+
+// EncapOptions returns tunnel encapsulation options that apply to this member, keyed by their names in the
+// "external_ids" column of the Open_vSwitch table. Member config options take precedence over cluster
+// config options. Options that are not configured are not included.
+func EncapOptions(ctx context.Context, s state.State) (map[string]string, error) {
+	values := make(map[string]string)
+	for _, option := range encapOptions {
+		memberItem, err := config.GetMemberConfig(ctx, s, s.Name(), option.MemberKey)
+		if err != nil {
+			return nil, err
+		}
+		if memberItem != nil {
+			values[option.ExternalID] = memberItem.Value
+			continue
+		}
+
+		clusterItem, err := config.GetConfig(ctx, s, option.ClusterKey)
+		if err != nil {
+			return nil, err
+		}
+		if clusterItem != nil {
+			values[option.ExternalID] = clusterItem.Value
+		}
+	}
+	return values, nil
+}
+
+// AppliedEncapOptions returns tunnel encapsulation options currently set in the local Open vSwitch, keyed
+// by their names in the "external_ids" column of the Open_vSwitch table. Options that are not set are not
+// included.
+func AppliedEncapOptions(ctx context.Context, s state.State) (map[string]string, error) {
+	values := make(map[string]string)
+	for _, option := range encapOptions {
+		out, err := ovnCmd.VSCtl(ctx, s, "--if-exists", "get", "Open_vSwitch", ".",
+			fmt.Sprintf("external_ids:%s", option.ExternalID),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tunnel encapsulation option '%s': %w", option.ExternalID, err)
+		}
+
+		value := strings.Trim(strings.TrimSpace(out), "\"")
+		if value != "" {
+			values[option.ExternalID] = value
+		}
+	}
+	return values, nil
+}
+
+// managedEncapOptions returns names of the tunnel encapsulation options that were set in the local Open
+// vSwitch by MicroOVN, as recorded under the "encapManagedKey" in the "external_ids" column.
+func managedEncapOptions(ctx context.Context, s state.State) ([]string, error) {
+	out, err := ovnCmd.VSCtl(ctx, s, "--if-exists", "get", "Open_vSwitch", ".",
+		fmt.Sprintf("external_ids:%s", encapManagedKey),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tunnel encapsulation options managed by MicroOVN: %w", err)
+	}
+
+	managed := []string{}
+	for _, name := range strings.Split(strings.Trim(strings.TrimSpace(out), "\""), ",") {
+		if name != "" {
+			managed = append(managed, name)
+		}
+	}
+	return managed, nil
+}
+
+// encapOptionsArgs returns arguments of the ovs-vsctl command that set tunnel encapsulation options to
+// "values" and remove the options that are not included in "values", but were set by MicroOVN before, as
+// listed in "managed". Options set by the operator are left intact. Names of the options set by the command
+// are recorded under the "encapManagedKey".
+func encapOptionsArgs(values map[string]string, managed []string) []string {
+	args := []string{}
+	applied := []string{}
+	for _, option := range encapOptions {
+		value, ok := values[option.ExternalID]
+		if ok {
+			args = append(args, "--", "set", "Open_vSwitch", ".", fmt.Sprintf("external_ids:%s=%s", option.ExternalID, value))
+			applied = append(applied, option.ExternalID)
+		} else if slices.Contains(managed, option.ExternalID) {
+			args = append(args, "--", "remove", "Open_vSwitch", ".", "external_ids", option.ExternalID)
+		}
+	}
+
+	if len(applied) > 0 {
+		args = append(args, "--", "set", "Open_vSwitch", ".",
+			fmt.Sprintf("external_ids:%s=\"%s\"", encapManagedKey, strings.Join(applied, ",")),
+		)
+	} else {
+		args = append(args, "--", "remove", "Open_vSwitch", ".", "external_ids", encapManagedKey)
+	}
+	return args[1:]
+}
+
+// UpdateEncapOptions applies tunnel encapsulation options configured for this member to the local Open
+// vSwitch, from where they are picked up by ovn-controller. Options that are no longer configured are removed,
+// so that ovn-controller uses its defaults, unless they were set by the operator rather than by MicroOVN.
+//
+// This function does nothing if the "chassis" service is not enabled on this member.
+func UpdateEncapOptions(ctx context.Context, s state.State) error {
+	hasChassis, err := node.HasServiceActive(ctx, s, types.SrvChassis)
+	if err != nil {
+		return fmt.Errorf("failed to query local services: %w", err)
+	}
+	if !hasChassis {
+		logger.Debug("Skipping tunnel encapsulation options, chassis service is not enabled on this member")
+		return nil
+	}
+
+	values, err := EncapOptions(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	managed, err := managedEncapOptions(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	_, err = ovnCmd.VSCtl(ctx, s, encapOptionsArgs(values, managed)...)
+	if err != nil {
+		return fmt.Errorf("failed to update tunnel encapsulation options: %w", err)
+	}
+
+	logger.Infof("Tunnel encapsulation options updated: %v", values)
+	return nil
+}
@@ -0,0 +1,83 @@
+package vswitch
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseEncapGenevePort(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected int
+		isValid  bool
+	}{
+		{value: "6081", expected: 6081, isValid: true},
+		{value: "16081", expected: 16081, isValid: true},
+		{value: "0", isValid: false},
+		{value: "65536", isValid: false},
+		{value: "geneve", isValid: false},
+		{value: "", isValid: false},
+	}
+
+	for _, tc := range testCases {
+		port, err := ParseEncapGenevePort(tc.value)
+		if !tc.isValid {
+			if err == nil {
+				t.Errorf("Expected value '%s' to be rejected, got %d", tc.value, port)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Failed to parse value '%s': %s", tc.value, err)
+		}
+		if port != tc.expected {
+			t.Errorf("Expected port %d, got %d", tc.expected, port)
+		}
+	}
+}
+
+func TestUnexported_encapOptionsArgs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		values   map[string]string
+		managed  []string
+		expected []string
+	}{
+		{
+			name:    "options set by MicroOVN are removed",
+			values:  map[string]string{"ovn-encap-csum": "false", "ovn-encap-port": "16081"},
+			managed: []string{"ovn-encap-csum", "ovn-encap-df_default"},
+			expected: []string{
+				"set", "Open_vSwitch", ".", "external_ids:ovn-encap-csum=false",
+				"--", "remove", "Open_vSwitch", ".", "external_ids", "ovn-encap-df_default",
+				"--", "set", "Open_vSwitch", ".", "external_ids:ovn-encap-port=16081",
+				"--", "set", "Open_vSwitch", ".", `external_ids:microovn-encap-options="ovn-encap-csum,ovn-encap-port"`,
+			},
+		},
+		{
+			name:    "options set by operator are kept",
+			values:  map[string]string{},
+			managed: []string{},
+			expected: []string{
+				"remove", "Open_vSwitch", ".", "external_ids", "microovn-encap-options",
+			},
+		},
+		{
+			name:    "all options removed",
+			values:  map[string]string{},
+			managed: []string{"ovn-encap-port"},
+			expected: []string{
+				"remove", "Open_vSwitch", ".", "external_ids", "ovn-encap-port",
+				"--", "remove", "Open_vSwitch", ".", "external_ids", "microovn-encap-options",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		args := encapOptionsArgs(tc.values, tc.managed)
+		if !slices.Equal(args, tc.expected) {
+			t.Errorf("%s: expected arguments %v, got %v", tc.name, tc.expected, args)
+		}
+	}
+}
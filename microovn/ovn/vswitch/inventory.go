@@ -0,0 +1,289 @@
+package vswitch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/canonical/microcluster/v2/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/bgp"
+	ovnCmd "github.com/canonical/microovn/microovn/ovn/cmd"
+	"github.com/canonical/microovn/microovn/ovn/hostport"
+)
+
+// ManagedTags maps keys used in "external_ids" column of OVS tables to mark records that are created and
+// managed by MicroOVN to their expected values. Records that have any of these keys set to the expected
+// value are reported as managed by MicroOVN. Empty expected value matches any non-empty value, e.g. the
+// name of the member that owns a host management port.
+var ManagedTags = map[string]string{
+	ManagedTag:          "true",
+	bgp.BgpManagedTag:   "true",
+	hostport.ManagedTag: "",
+}
+
+// ovsdbTable represents output of the "ovs-vsctl --format=json --data=json list" command.
+type ovsdbTable struct {
+	Headings []string `json:"headings"`
+	Data     [][]any  `json:"data"`
+}
+
+// rows returns records of the table as a list of maps, keyed by column names.
+func (t *ovsdbTable) rows() []map[string]any {
+	rows := make([]map[string]any, 0, len(t.Data))
+	for _, record := range t.Data {
+		row := make(map[string]any, len(t.Headings))
+		for i, heading := range t.Headings {
+			if i < len(record) {
+				row[heading] = record[i]
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// parseOvsdbTable parses JSON output of the "ovs-vsctl list" command.
+func parseOvsdbTable(output string) (*ovsdbTable, error) {
+	table := &ovsdbTable{}
+	err := json.Unmarshal([]byte(output), table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ovs-vsctl output: %w", err)
+	}
+	return table, nil
+}
+
+// ovsdbAtoms returns list of atoms from the OVSDB JSON value. Sets are expanded to their members and
+// scalar values are returned as a single-item list.
+func ovsdbAtoms(value any) []any {
+	pair, ok := value.([]any)
+	if !ok || len(pair) != 2 {
+		return []any{value}
+	}
+
+	switch pair[0] {
+	case "set":
+		members, _ := pair[1].([]any)
+		result := make([]any, 0, len(members))
+		for _, member := range members {
+			result = append(result, ovsdbAtoms(member)...)
+		}
+		return result
+	case "uuid", "named-uuid":
+		return []any{pair[1]}
+	default:
+		return []any{value}
+	}
+}
+
+// ovsdbString returns string representation of the first atom of the OVSDB JSON value.
+func ovsdbString(value any) string {
+	atoms := ovsdbAtoms(value)
+	if len(atoms) == 0 {
+		return ""
+	}
+
+	switch atom := atoms[0].(type) {
+	case string:
+		return atom
+	case float64:
+		return fmt.Sprintf("%d", int(atom))
+	case bool:
+		return fmt.Sprintf("%t", atom)
+	default:
+		return ""
+	}
+}
+
+// ovsdbInt returns integer value of the first atom of the OVSDB JSON value, or "fallback" if the
+// value is empty.
+func ovsdbInt(value any, fallback int) int {
+	atoms := ovsdbAtoms(value)
+	if len(atoms) == 0 {
+		return fallback
+	}
+
+	number, ok := atoms[0].(float64)
+	if !ok {
+		return fallback
+	}
+	return int(number)
+}
+
+// ovsdbStrings returns string representation of all atoms of the OVSDB JSON value.
+func ovsdbStrings(value any) []string {
+	var result []string
+	for _, atom := range ovsdbAtoms(value) {
+		if str, ok := atom.(string); ok {
+			result = append(result, str)
+		}
+	}
+	return result
+}
+
+// ovsdbMap returns OVSDB JSON map value as a Go map of strings.
+func ovsdbMap(value any) map[string]string {
+	result := make(map[string]string)
+	pair, ok := value.([]any)
+	if !ok || len(pair) != 2 || pair[0] != "map" {
+		return result
+	}
+
+	items, _ := pair[1].([]any)
+	for _, item := range items {
+		keyValue, ok := item.([]any)
+		if !ok || len(keyValue) != 2 {
+			continue
+		}
+		result[ovsdbString(keyValue[0])] = ovsdbString(keyValue[1])
+	}
+	return result
+}
+
+// isManaged returns true if any of the ManagedTags is set to its expected value in the "externalIDs".
+func isManaged(externalIDs map[string]string) bool {
+	for tag, expected := range ManagedTags {
+		value := externalIDs[tag]
+		if value != "" && (expected == "" || value == expected) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseBridgeMappings parses value of the "ovn-bridge-mappings" external ID into a map of physical network
+// names and OVS bridges.
+func ParseBridgeMappings(value string) map[string]string {
+	mappings := make(map[string]string)
+	for _, mapping := range strings.Split(strings.Trim(value, "\""), ",") {
+		physnet, bridge, found := strings.Cut(strings.TrimSpace(mapping), ":")
+		if found {
+			mappings[physnet] = bridge
+		}
+	}
+	return mappings
+}
+
+// listTable returns records from the OVS database "table" with selected "columns".
+func listTable(ctx context.Context, s state.State, table string, columns ...string) ([]map[string]any, error) {
+	out, err := ovnCmd.VSCtl(ctx, s,
+		"--format=json", "--data=json", "--columns", strings.Join(columns, ","), "list", table,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list OVS table '%s': %w", table, err)
+	}
+
+	parsed, err := parseOvsdbTable(out)
+	if err != nil {
+		return nil, err
+	}
+	return parsed.rows(), nil
+}
+
+// buildInventory assembles SwitchInventory from the records of OVS "Open_vSwitch", "Bridge", "Port" and
+// "Interface" tables.
+func buildInventory(vswitchRows, bridgeRows, portRows, ifaceRows []map[string]any) types.SwitchInventory {
+	inventory := types.SwitchInventory{
+		Bridges:        []types.SwitchBridge{},
+		BridgeMappings: map[string]string{},
+		ExternalIDs:    map[string]string{},
+	}
+
+	if len(vswitchRows) > 0 {
+		inventory.ExternalIDs = ovsdbMap(vswitchRows[0]["external_ids"])
+		inventory.BridgeMappings = ParseBridgeMappings(inventory.ExternalIDs["ovn-bridge-mappings"])
+	}
+
+	interfaces := make(map[string]types.SwitchInterface, len(ifaceRows))
+	for _, row := range ifaceRows {
+		externalIDs := ovsdbMap(row["external_ids"])
+		interfaces[ovsdbString(row["_uuid"])] = types.SwitchInterface{
+			Name:        ovsdbString(row["name"]),
+			Type:        ovsdbString(row["type"]),
+			OfPort:      ovsdbInt(row["ofport"], -1),
+			MTU:         ovsdbInt(row["mtu"], 0),
+			AdminState:  ovsdbString(row["admin_state"]),
+			LinkState:   ovsdbString(row["link_state"]),
+			IfaceID:     externalIDs["iface-id"],
+			Managed:     isManaged(externalIDs),
+			ExternalIDs: externalIDs,
+		}
+	}
+
+	ports := make(map[string]types.SwitchPort, len(portRows))
+	for _, row := range portRows {
+		externalIDs := ovsdbMap(row["external_ids"])
+		port := types.SwitchPort{
+			Name:        ovsdbString(row["name"]),
+			Managed:     isManaged(externalIDs),
+			ExternalIDs: externalIDs,
+			Interfaces:  []types.SwitchInterface{},
+		}
+
+		for _, ifaceUUID := range ovsdbStrings(row["interfaces"]) {
+			iface, ok := interfaces[ifaceUUID]
+			if ok {
+				port.Interfaces = append(port.Interfaces, iface)
+			}
+		}
+		sort.Slice(port.Interfaces, func(i, j int) bool { return port.Interfaces[i].Name < port.Interfaces[j].Name })
+
+		ports[ovsdbString(row["_uuid"])] = port
+	}
+
+	for _, row := range bridgeRows {
+		externalIDs := ovsdbMap(row["external_ids"])
+		bridge := types.SwitchBridge{
+			Name:        ovsdbString(row["name"]),
+			Managed:     isManaged(externalIDs),
+			ExternalIDs: externalIDs,
+			Ports:       []types.SwitchPort{},
+		}
+
+		for _, portUUID := range ovsdbStrings(row["ports"]) {
+			port, ok := ports[portUUID]
+			if ok {
+				bridge.Ports = append(bridge.Ports, port)
+			}
+		}
+		sort.Slice(bridge.Ports, func(i, j int) bool { return bridge.Ports[i].Name < bridge.Ports[j].Name })
+
+		inventory.Bridges = append(inventory.Bridges, bridge)
+	}
+	sort.Slice(inventory.Bridges, func(i, j int) bool { return inventory.Bridges[i].Name < inventory.Bridges[j].Name })
+
+	return inventory
+}
+
+// GetInventory returns bridges, ports and interfaces configured in the local Open vSwitch, together with
+// OVN bridge mappings and external IDs of the Open_vSwitch table.
+func GetInventory(ctx context.Context, s state.State) (types.SwitchInventory, error) {
+	vswitchRows, err := listTable(ctx, s, "Open_vSwitch", "external_ids")
+	if err != nil {
+		return types.SwitchInventory{}, err
+	}
+
+	bridgeRows, err := listTable(ctx, s, "Bridge", "name", "ports", "external_ids")
+	if err != nil {
+		return types.SwitchInventory{}, err
+	}
+
+	portRows, err := listTable(ctx, s, "Port", "_uuid", "name", "interfaces", "external_ids")
+	if err != nil {
+		return types.SwitchInventory{}, err
+	}
+
+	ifaceRows, err := listTable(ctx, s, "Interface",
+		"_uuid", "name", "type", "ofport", "mtu", "admin_state", "link_state", "external_ids",
+	)
+	if err != nil {
+		return types.SwitchInventory{}, err
+	}
+
+	inventory := buildInventory(vswitchRows, bridgeRows, portRows, ifaceRows)
+	inventory.Member = s.Name()
+	return inventory, nil
+}
@@ -0,0 +1,88 @@
+package vswitch
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestUnexported_buildInventory(t *testing.T) {
+	parse := func(output string) []map[string]any {
+		table, err := parseOvsdbTable(output)
+		if err != nil {
+			t.Fatalf("Failed to parse table: %v", err)
+		}
+		return table.rows()
+	}
+
+	vswitchRows := parse(`{"data":[[["map",[["ovn-bridge-mappings","physnet_first_eth1:br-eth1"],["ovn-encap-ip","10.0.0.1"]]]]],"headings":["external_ids"]}`)
+	bridgeRows := parse(`{"data":[
+		["br-int",["set",[["uuid","p1"],["uuid","p2"]]],["map",[]]],
+		["br-eth1",["uuid","p3"],["map",[["microovn-bgp-managed","true"]]]]
+	],"headings":["name","ports","external_ids"]}`)
+	portRows := parse(`{"data":[
+		[["uuid","p1"],"veth1-brg",["uuid","i1"],["map",[["microovn-bgp-managed","true"]]]],
+		[["uuid","p2"],"br-int",["uuid","i2"],["map",[]]],
+		[["uuid","p3"],"eth1",["uuid","i3"],["map",[]]]
+	],"headings":["_uuid","name","interfaces","external_ids"]}`)
+	ifaceRows := parse(`{"data":[
+		[["uuid","i1"],"veth1-brg","system",3,1500,"up","up",["map",[["iface-id","lsp-first-veth1-bgp"],["microovn-bgp-managed","true"]]]],
+		[["uuid","i2"],"br-int","internal",65534,["set",[]],"down","down",["map",[]]],
+		[["uuid","i3"],"eth1","",["set",[]],1500,"up","up",["map",[]]]
+	],"headings":["_uuid","name","type","ofport","mtu","admin_state","link_state","external_ids"]}`)
+
+	inventory := buildInventory(vswitchRows, bridgeRows, portRows, ifaceRows)
+
+	if !maps.Equal(inventory.BridgeMappings, map[string]string{"physnet_first_eth1": "br-eth1"}) {
+		t.Errorf("Unexpected bridge mappings: %v", inventory.BridgeMappings)
+	}
+
+	if len(inventory.Bridges) != 2 || inventory.Bridges[0].Name != "br-eth1" || inventory.Bridges[1].Name != "br-int" {
+		t.Fatalf("Unexpected bridges: %+v", inventory.Bridges)
+	}
+
+	if !inventory.Bridges[0].Managed || inventory.Bridges[1].Managed {
+		t.Errorf("Unexpected managed state of bridges: %+v", inventory.Bridges)
+	}
+
+	brInt := inventory.Bridges[1]
+	if len(brInt.Ports) != 2 || brInt.Ports[0].Name != "br-int" || brInt.Ports[1].Name != "veth1-brg" {
+		t.Fatalf("Unexpected ports of br-int: %+v", brInt.Ports)
+	}
+
+	iface := brInt.Ports[1].Interfaces[0]
+	if iface.OfPort != 3 || iface.MTU != 1500 || iface.IfaceID != "lsp-first-veth1-bgp" || !iface.Managed {
+		t.Errorf("Unexpected interface: %+v", iface)
+	}
+
+	iface = brInt.Ports[0].Interfaces[0]
+	if iface.Type != "internal" || iface.MTU != 0 || iface.Managed {
+		t.Errorf("Unexpected interface: %+v", iface)
+	}
+
+	iface = inventory.Bridges[0].Ports[0].Interfaces[0]
+	if iface.OfPort != -1 {
+		t.Errorf("Expected ofport -1 for interface without ofport, got %d", iface.OfPort)
+	}
+}
+
+func TestUnexported_isManaged(t *testing.T) {
+	testCases := []struct {
+		externalIDs map[string]string
+		expected    bool
+	}{
+		{externalIDs: map[string]string{"microovn-managed": "true"}, expected: true},
+		{externalIDs: map[string]string{"microovn-bgp-managed": "true"}, expected: true},
+		{externalIDs: map[string]string{"microovn-host-port": "micro1"}, expected: true},
+		{externalIDs: map[string]string{"microovn-managed": "false"}, expected: false},
+		{externalIDs: map[string]string{"microovn-host-port": ""}, expected: false},
+		{externalIDs: map[string]string{"iface-id": "lsp0"}, expected: false},
+		{externalIDs: map[string]string{}, expected: false},
+	}
+
+	for _, tc := range testCases {
+		managed := isManaged(tc.externalIDs)
+		if managed != tc.expected {
+			t.Errorf("Expected managed state %t of record with external IDs %v, got %t", tc.expected, tc.externalIDs, managed)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+// Package vswitch manages configuration of the local Open vSwitch.
+package vswitch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/canonical/lxd/shared/logger"
+	"github.com/canonical/microcluster/v2/state"
+
+	"github.com/canonical/microovn/microovn/api/types"
+	"github.com/canonical/microovn/microovn/config"
+	"github.com/canonical/microovn/microovn/node"
+	"github.com/canonical/microovn/microovn/ovn/certificates"
+	ovnCmd "github.com/canonical/microovn/microovn/ovn/cmd"
+	"github.com/canonical/microovn/microovn/ovn/paths"
+)
+
+// ManagedTag - a key used in "external_ids" column of OVS tables to mark records
+// that are managed by MicroOVN.
+const ManagedTag = "microovn-managed"
+
+// RemoteManagerConfigKey - member config option that enables remote OVS manager listener.
+const RemoteManagerConfigKey = "switch.remote-manager"
+
+// RemoteManagerCertService - name of the service for which is issued the certificate used
+// by remote OVS manager listener.
+const RemoteManagerCertService = "ovs-manager"
+
+// ParseRemoteManager parses value of the "switch.remote-manager" config option. Expected format
+// is "[<address>:]<port>", where IPv6 address must be enclosed in brackets. It returns OVS manager
+// target string in the "pssl:<port>[:<address>]" format.
+func ParseRemoteManager(value string) (string, error) {
+	host := ""
+	port := value
+	if strings.Contains(value, ":") {
+		var err error
+		host, port, err = net.SplitHostPort(value)
+		if err != nil {
+			return "", fmt.Errorf("value '%s' does not conform to the '[<address>:]<port>' format: %w", value, err)
+		}
+
+		if net.ParseIP(host) == nil {
+			return "", fmt.Errorf("cannot parse IP address '%s'", host)
+		}
+	}
+
+	portNumber, err := strconv.Atoi(port)
+	if err != nil || portNumber < 1 || portNumber > 65535 {
+		return "", fmt.Errorf("'%s' is not a valid port number", port)
+	}
+
+	if host == "" {
+		return fmt.Sprintf("pssl:%d", portNumber), nil
+	}
+
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	return fmt.Sprintf("pssl:%d:%s", portNumber, host), nil
+}
+
+// RemoteManagerEnabled returns true if the remote OVS manager listener is configured on this member.
+func RemoteManagerEnabled(ctx context.Context, s state.State) (bool, error) {
+	item, err := config.GetMemberConfig(ctx, s, s.Name(), RemoteManagerConfigKey)
+	if err != nil {
+		return false, err
+	}
+	return item != nil, nil
+}
+
+// UpdateRemoteManager applies the value of the "switch.remote-manager" config option to the local
+// Open vSwitch. Any remote manager listener previously created by MicroOVN is removed first. If the
+// option is set, new "pssl:" listener is created. It uses certificate issued by the MicroOVN CA and it
+// accepts only clients that present certificate signed by the same CA. If the option is not set, SSL
+// configuration and certificate used by the listener are removed as well.
+//
+// This function does nothing if the "switch" service is not enabled on this member.
+func UpdateRemoteManager(ctx context.Context, s state.State) error {
+	hasSwitch, err := node.HasServiceActive(ctx, s, types.SrvSwitch)
+	if err != nil {
+		return fmt.Errorf("failed to query local services: %w", err)
+	}
+	if !hasSwitch {
+		logger.Debug("Skipping remote OVS manager configuration, switch service is not enabled on this member")
+		return nil
+	}
+
+	configItem, err := config.GetMemberConfig(ctx, s, s.Name(), RemoteManagerConfigKey)
+	if err != nil {
+		return err
+	}
+
+	err = removeRemoteManagers(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	if configItem == nil {
+		return removeRemoteManagerSsl(ctx, s)
+	}
+
+	target, err := ParseRemoteManager(configItem.Value)
+	if err != nil {
+		return err
+	}
+
+	err = checkForeignSsl(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	certPath, keyPath := paths.PkiOvsManagerCertFiles()
+	_, err = os.Stat(certPath)
+	if errors.Is(err, os.ErrNotExist) {
+		err = certificates.GenerateNewServiceCertificate(ctx, s, RemoteManagerCertService, certificates.CertificateTypeServer)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to issue certificate for remote OVS manager: %w", err)
+	}
+
+	_, err = ovnCmd.VSCtl(ctx, s,
+		"set-ssl", keyPath, certPath, paths.PkiCaCertFile(),
+		"--", "set", "SSL", ".", fmt.Sprintf("external-ids:%s=true", ManagedTag),
+		"--", "--id=@manager", "create", "Manager", fmt.Sprintf("target=\"%s\"", target),
+		fmt.Sprintf("external-ids:%s=true", ManagedTag),
+		"--", "add", "Open_vSwitch", ".", "manager_options", "@manager",
+	)
+	if err != nil {
+		return fmt.Errorf("failed to configure remote OVS manager '%s': %w", target, err)
+	}
+
+	logger.Infof("Remote OVS manager listening on '%s'", target)
+	return nil
+}
+
+// checkForeignSsl returns an error if the local Open vSwitch has SSL configuration that was not created by
+// MicroOVN. Such configuration would be overwritten by the remote OVS manager.
+func checkForeignSsl(ctx context.Context, s state.State) error {
+	allSsl, err := ovnCmd.VSCtl(ctx, s, "--bare", "--columns", "_uuid", "list", "SSL")
+	if err != nil {
+		return fmt.Errorf("failed to lookup OVS SSL configuration: %w", err)
+	}
+
+	managedSsl, err := ovnCmd.VSCtl(ctx, s, "--bare", "--columns", "_uuid",
+		"find", "SSL", fmt.Sprintf("external-ids:%s=true", ManagedTag),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to lookup OVS SSL configuration: %w", err)
+	}
+
+	if len(strings.Fields(allSsl)) > len(strings.Fields(managedSsl)) {
+		return errors.New(
+			"local Open vSwitch already has SSL configuration that is not managed by MicroOVN, " +
+				"remove it with 'ovs-vsctl del-ssl' before enabling remote OVS manager",
+		)
+	}
+	return nil
+}
+
+// removeRemoteManagers removes every OVS manager that was created by MicroOVN.
+func removeRemoteManagers(ctx context.Context, s state.State) error {
+	managers, err := ovnCmd.VSCtl(ctx, s, "--bare", "--columns", "_uuid",
+		"find", "Manager", fmt.Sprintf("external-ids:%s=true", ManagedTag),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to lookup remote OVS managers: %w", err)
+	}
+
+	for _, manager := range strings.Fields(managers) {
+		_, err = ovnCmd.VSCtl(ctx, s, "remove", "Open_vSwitch", ".", "manager_options", manager)
+		if err != nil {
+			return fmt.Errorf("failed to remove remote OVS manager '%s': %w", manager, err)
+		}
+	}
+
+	return nil
+}
+
+// removeRemoteManagerSsl removes OVS SSL configuration and certificate used by the remote OVS manager.
+// SSL configuration is removed only if it was created by MicroOVN.
+func removeRemoteManagerSsl(ctx context.Context, s state.State) error {
+	sslConfig, err := ovnCmd.VSCtl(ctx, s, "--bare", "--columns", "_uuid",
+		"find", "SSL", fmt.Sprintf("external-ids:%s=true", ManagedTag),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to lookup OVS SSL configuration: %w", err)
+	}
+
+	if strings.TrimSpace(sslConfig) != "" {
+		_, err = ovnCmd.VSCtl(ctx, s, "del-ssl")
+		if err != nil {
+			return fmt.Errorf("failed to remove OVS SSL configuration: %w", err)
+		}
+	}
+
+	certPath, keyPath := paths.PkiOvsManagerCertFiles()
+	for _, path := range []string{certPath, keyPath} {
+		err = os.Remove(path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove '%s': %w", path, err)
+		}
+	}
+
+	return nil
+}
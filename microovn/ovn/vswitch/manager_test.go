@@ -0,0 +1,44 @@
+package vswitch
+
+import (
+	"testing"
+)
+
+func TestParseRemoteManager(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected string
+		isValid  bool
+	}{
+		{value: "6640", expected: "pssl:6640", isValid: true},
+		{value: "10.0.0.1:6640", expected: "pssl:6640:10.0.0.1", isValid: true},
+		{value: "[fd00::1]:6640", expected: "pssl:6640:[fd00::1]", isValid: true},
+		// Invalid port
+		{value: "0", isValid: false},
+		{value: "65536", isValid: false},
+		{value: "10.0.0.1:abc", isValid: false},
+		// Invalid address
+		{value: "foo:6640", isValid: false},
+		// IPv6 address without brackets
+		{value: "fd00::1:6640", isValid: false},
+		// Empty value
+		{value: "", isValid: false},
+	}
+
+	for _, tc := range testCases {
+		target, err := ParseRemoteManager(tc.value)
+		if !tc.isValid {
+			if err == nil {
+				t.Errorf("Expected value '%s' to be rejected, got '%s'", tc.value, target)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Failed to parse value '%s': %s", tc.value, err)
+		}
+		if target != tc.expected {
+			t.Errorf("Expected target '%s', got '%s'", tc.expected, target)
+		}
+	}
+}
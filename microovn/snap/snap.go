@@ -3,6 +3,7 @@ package snap
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/canonical/lxd/shared"
 )
@@ -77,3 +78,28 @@ func Reload(service string) error {
 
 	return nil
 }
+
+// IsActive returns true if snap service as represented by "service" string is currently running.
+func IsActive(service string) (bool, error) {
+	out, err := shared.RunCommand("snapctl", "services", fmt.Sprintf("microovn.%s", service))
+	if err != nil {
+		return false, err
+	}
+
+	return parseServiceActive(out, fmt.Sprintf("microovn.%s", service))
+}
+
+// parseServiceActive parses output of "snapctl services" command and returns true if the "service"
+// is reported as active.
+func parseServiceActive(output string, service string) (bool, error) {
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 || fields[0] != service {
+			continue
+		}
+
+		return fields[2] == "active", nil
+	}
+
+	return false, fmt.Errorf("service '%s' not found", service)
+}
@@ -0,0 +1,88 @@
+package snap
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const snapctlServicesOutput = `Service                   Startup  Current   Notes
+microovn.chassis          enabled  active    -
+microovn.ovn-northd       enabled  inactive  -
+microovn.switch           disabled inactive  -
+`
+
+func TestUnexported_parseServiceActive(t *testing.T) {
+	testCases := []struct {
+		service  string
+		expected bool
+		isValid  bool
+	}{
+		{service: "microovn.chassis", expected: true, isValid: true},
+		{service: "microovn.ovn-northd", expected: false, isValid: true},
+		{service: "microovn.switch", expected: false, isValid: true},
+		// Service names must match exactly
+		{service: "microovn.ovn", isValid: false},
+		{service: "microovn.central", isValid: false},
+	}
+
+	for _, tc := range testCases {
+		active, err := parseServiceActive(snapctlServicesOutput, tc.service)
+		if !tc.isValid {
+			if err == nil {
+				t.Errorf("Expected service '%s' not to be found", tc.service)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Failed to parse state of service '%s': %s", tc.service, err)
+		}
+		if active != tc.expected {
+			t.Errorf("Expected service '%s' active=%t, got %t", tc.service, tc.expected, active)
+		}
+	}
+
+	_, err := parseServiceActive("", "microovn.chassis")
+	if err == nil {
+		t.Errorf("Expected empty output to be rejected")
+	}
+}
+
+func TestIsActive(t *testing.T) {
+	// Replace "snapctl" with a script that prints canned output
+	binDir := t.TempDir()
+	script := "#!/bin/sh\ncat <<'EOF'\n" + snapctlServicesOutput + "EOF\n"
+	err := os.WriteFile(filepath.Join(binDir, "snapctl"), []byte(script), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	testCases := []struct {
+		service  string
+		expected bool
+		isValid  bool
+	}{
+		{service: "chassis", expected: true, isValid: true},
+		{service: "ovn-northd", expected: false, isValid: true},
+		{service: "central", isValid: false},
+	}
+
+	for _, tc := range testCases {
+		active, err := IsActive(tc.service)
+		if !tc.isValid {
+			if err == nil {
+				t.Errorf("Expected service '%s' not to be found", tc.service)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Failed to get state of service '%s': %s", tc.service, err)
+		}
+		if active != tc.expected {
+			t.Errorf("Expected service '%s' active=%t, got %t", tc.service, tc.expected, active)
+		}
+	}
+}
@@ -194,6 +194,10 @@ expect "Please choose a name for this system" {
 
 $custom_encapsulation_ip_dialog
 
+expect "Would you like to run OVN databases in standalone mode?" {
+    send "\n"
+}
+
 $user_ca_dialog
 
 expect "Would you like to add additional servers to the cluster?" {